@@ -1,15 +1,13 @@
 package main
 
 import (
+	"context"
 	"dapp/rollups"
-	"encoding/hex"
-	"encoding/json"
-	"fmt"
-	"io"
 	"log"
 	"os"
-	"strconv"
+	"os/signal"
 	"strings"
+	"syscall"
 )
 
 var (
@@ -17,89 +15,27 @@ var (
 	errlog  = log.New(os.Stderr, "[ error ] ", log.Lshortfile)
 )
 
-var lastestState string
-
-func HandleAdvance(data *rollups.AdvanceResponse) error {
-	dataMarshal, err := json.Marshal(data)
-	if err != nil {
-		return fmt.Errorf("HandleAdvance: failed marshaling json: %w", err)
-	}
-	infolog.Println("Received advance request data", string(dataMarshal))
-
-	// To-Upper
-	decodedPayload, err := hex.DecodeString(data.Payload[2:])
-	if err != nil {
-		return fmt.Errorf("Handler: Error decoding payload: %w", err)
-	}
-	lastestState = strings.ToUpper(string(decodedPayload))
-	infolog.Println("To-Upper:", lastestState)
-	return nil
+type ToUpper struct {
+	lastestState string
 }
 
-func HandleInspect(data *rollups.InspectResponse) error {
-	dataMarshal, err := json.Marshal(data)
-	if err != nil {
-		return fmt.Errorf("HandleInspect: failed marshaling json: %w", err)
-	}
-	infolog.Println("Received inspect request data", string(dataMarshal))
-	rollups.SendReport(&rollups.ReportRequest{
-		Payload: rollups.Str2Hex(lastestState),
-	})
+func (a *ToUpper) Advance(ctx context.Context, client *rollups.Client, payload []byte, metadata rollups.Metadata) error {
+	a.lastestState = strings.ToUpper(string(payload))
+	infolog.Println("To-Upper:", a.lastestState)
 	return nil
 }
 
-func Handler(response *rollups.FinishResponse) error {
-	var err error
-
-	switch response.Type {
-	case "advance_state":
-		data := new(rollups.AdvanceResponse)
-		if err := json.Unmarshal(response.Data, data); err != nil {
-			return fmt.Errorf("Handler: Error unmarshaling advance: %w", err)
-		}
-		HandleAdvance(data)
-	case "inspect_state":
-		data := new(rollups.InspectResponse)
-		if err = json.Unmarshal(response.Data, data); err != nil {
-			return fmt.Errorf("Handler: Error unmarshaling inspect: %w", err)
-		}
-		HandleInspect(data)
-	}
-	return err
+func (a *ToUpper) Inspect(ctx context.Context, client *rollups.Client, payload []byte) error {
+	return client.SendReport(ctx, &rollups.ReportRequest{
+		Payload: rollups.Payload(a.lastestState),
+	})
 }
 
 func main() {
-	finish := rollups.FinishRequest{"accept"}
-
-	for {
-		infolog.Println("Sending finish")
-		res, err := rollups.SendFinish(&finish)
-		if err != nil {
-			errlog.Panicln("Error: error making http request: ", err)
-		}
-		infolog.Println("Received finish status ", strconv.Itoa(res.StatusCode))
-
-		if res.StatusCode == 202 {
-			infolog.Println("No pending rollup request, trying again")
-		} else {
-
-			resBody, err := io.ReadAll(res.Body)
-			if err != nil {
-				errlog.Panicln("Error: could not read response body: ", err)
-			}
-
-			var response rollups.FinishResponse
-			err = json.Unmarshal(resBody, &response)
-			if err != nil {
-				errlog.Panicln("Error: unmarshaling body:", err)
-			}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-			finish.Status = "accept"
-			err = Handler(&response)
-			if err != nil {
-				errlog.Println(err)
-				finish.Status = "reject"
-			}
-		}
+	if err := rollups.Run(ctx, &ToUpper{}); err != nil {
+		errlog.Fatalln(err)
 	}
 }
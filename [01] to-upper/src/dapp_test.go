@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"dapp/rollups"
+	"dapp/rollups/rollupstest"
+)
+
+func TestToUpper(t *testing.T) {
+	srv := rollupstest.Run(t, &ToUpper{})
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "hello", expected: "HELLO"},
+		{input: "Cartesi Rollups", expected: "CARTESI ROLLUPS"},
+		{input: "", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if res := srv.Advance([]byte(tt.input), rollups.Metadata{}); !res.Accepted() {
+				t.Fatalf("expected advance to be accepted, got %q: %v", res.Status, res.Err)
+			}
+
+			res := srv.Inspect(nil)
+			if !res.Accepted() {
+				t.Fatalf("expected inspect to be accepted, got %q: %v", res.Status, res.Err)
+			}
+			if len(res.Reports) != 1 || string(res.Reports[0]) != tt.expected {
+				t.Fatalf("expected report %q, got %q", tt.expected, res.Reports)
+			}
+		})
+	}
+}
@@ -14,40 +14,40 @@ type FinishResponse struct {
 }
 
 type InspectResponse struct {
-	Payload string `json:"payload"`
+	Payload Payload `json:"payload"`
 }
 
 type AdvanceResponse struct {
 	Metadata Metadata `json:"metadata"`
-	Payload  string   `json:"payload"`
+	Payload  Payload  `json:"payload"`
 }
 
 type Metadata struct {
-	ChainID        uint64 `json:"chain_id"`
-	AppContract    string `json:"app_contract"`
-	MsgSender      string `json:"msg_sender"`
-	InputIndex     uint64 `json:"input_index"`
-	BlockNumber    uint64 `json:"block_number"`
-	BlockTimestamp uint64 `json:"block_timestamp"`
-	PrevRandao     string `json:"prev_randao"`
+	ChainID        uint64  `json:"chain_id"`
+	AppContract    Address `json:"app_contract"`
+	MsgSender      Address `json:"msg_sender"`
+	InputIndex     uint64  `json:"input_index"`
+	BlockNumber    uint64  `json:"block_number"`
+	BlockTimestamp uint64  `json:"block_timestamp"`
+	PrevRandao     Uint256 `json:"prev_randao"`
 }
 
 type ReportRequest struct {
-	Payload string `json:"payload"`
+	Payload Payload `json:"payload"`
 }
 
 type NoticeRequest struct {
-	Payload string `json:"payload"`
+	Payload Payload `json:"payload"`
 }
 
 type VoucherRequest struct {
-	Destination string `json:"destination"`
-	Value       string `json:"value"`
-	Payload     string `json:"payload"`
+	Destination Address `json:"destination"`
+	Value       Uint256 `json:"value"`
+	Payload     Payload `json:"payload"`
 }
 
 type ExceptionRequest struct {
-	Payload string `json:"payload"`
+	Payload Payload `json:"payload"`
 }
 
 type IndexResponse struct {
@@ -2,109 +2,73 @@ package main
 
 import (
 	"context"
-	"encoding/hex"
-	"encoding/json"
-	"fmt"
-	"io"
 	"log"
 	"os"
-	"strconv"
+	"os/signal"
+	"syscall"
 
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/cartesi/handler/advance"
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/cartesi/handler/inspect"
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository/factory"
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/wallet"
 )
 
+const databaseConn = "sqlite:///mnt/data/database.db"
+
 var (
 	infolog = log.New(os.Stderr, "[ info ] ", log.Lshortfile)
 	errlog  = log.New(os.Stderr, "[ error ] ", log.Lshortfile)
 )
 
-func DappStrategy(response *rollups.FinishResponse, router *rollups.Router, ih *inspect.ToDoInspectHandlers) error {
-	switch response.Type {
-	case "advance_state":
-		var data rollups.AdvanceResponse
-		if err := json.Unmarshal(response.Data, &data); err != nil {
-			return err
-		}
-		decodedPayload, err := hex.DecodeString(data.Payload[2:])
-		if err != nil {
-			return fmt.Errorf("handler: error decoding payload: %w", err)
-		}
-		return router.Advance(decodedPayload, data.Metadata)
-	case "inspect_state":
-		return ih.FindAllToDosHandler()
-	}
-	return nil
+func setupRouter(ah *advance.ToDoAdvanceHandlers, ih *inspect.ToDoInspectHandlers, w *wallet.Wallet) *rollups.Router {
+	r := rollups.NewRouter()
+	rollups.HandleAdvanceTyped(r, "createToDo", "To-Do created", ah.CreateToDoHandler)
+	rollups.HandleAdvanceTyped(r, "updateToDo", "To-Do updated", ah.UpdateToDoHandler)
+	rollups.HandleAdvanceTyped(r, "deleteToDo", "To-Do deleted", ah.DeleteToDoHandler)
+	rollups.HandleInspectTyped(r, "todo", ih.FindAllToDosHandler)
+	rollups.HandleInspectTyped(r, "todo/completed", ih.FindCompletedToDosHandler)
+	rollups.HandleInspectTyped(r, "todo/:id", ih.FindToDoByIdHandler)
+	w.Register(r)
+	return r
 }
 
 func main() {
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if len(os.Args) > 1 {
+		if err := runCommand(ctx, os.Stdout, os.Args[1:]); err != nil {
+			errlog.Fatalln(err)
+		}
+		return
+	}
+
 	// toDoRepository, err := factory.NewRepositoryFromConnectionString(ctx, "memory://")
 	// if err != nil {
 	// 	errlog.Panicln("Failed to initialize repository", "error", err)
 	// }
 
-	toDoRepository, err := factory.NewRepositoryFromConnectionString(ctx, "sqlite:///mnt/data/database.db")
+	toDoRepository, err := factory.NewRepositoryFromConnectionString(ctx, databaseConn)
 	if err != nil {
 		errlog.Panicln("Failed to initialize repository", "error", err)
 	}
+	defer toDoRepository.Close()
 
 	ah := advance.NewToDoAdvanceHandlers(toDoRepository)
-	if err != nil {
-		errlog.Panicln("Failed to initialize advance handlers", "error", err)
-	}
 	infolog.Println("Advance handlers initialized")
 
 	ih := inspect.NewToDoInspectHandlers(toDoRepository)
-	if err != nil {
-		errlog.Panicln("Failed to initialize inspect handlers", "error", err)
-	}
 	infolog.Println("Inspect handlers initialized")
 
+	w := wallet.New(toDoRepository)
+	infolog.Println("Wallet initialized")
+
 	// Router setup and handlers registration
-	defer toDoRepository.Close()
-	r := rollups.NewRouter()
-	r.HandleAdvance("createToDo", ah.CreateToDoHandler)
-	r.HandleAdvance("updateToDo", ah.UpdateToDoHandler)
-	r.HandleAdvance("deleteToDo", ah.DeleteToDoHandler)
+	r := setupRouter(ah, ih, w)
 	infolog.Println("Router setup successful")
 
-	// Polling loop ( Is there something new to process? )
-	finish := rollups.FinishRequest{
-		Status: "accept",
-	}
-	for {
-		infolog.Println("Sending finish")
-		res, err := rollups.SendFinish(&finish)
-		if err != nil {
-			errlog.Panicln("Error: error making http request: ", err)
-		}
-		infolog.Println("Received finish status ", strconv.Itoa(res.StatusCode))
-
-		if res.StatusCode == 202 {
-			infolog.Println("No pending rollup request, trying again")
-		} else {
-
-			resBody, err := io.ReadAll(res.Body)
-			if err != nil {
-				errlog.Panicln("Error: could not read response body: ", err)
-			}
-
-			var response rollups.FinishResponse
-			err = json.Unmarshal(resBody, &response)
-			if err != nil {
-				errlog.Panicln("Error: unmarshaling body:", err)
-			}
-			finish.Status = "accept"
-
-			// Strategy pattern to handle different types of requests (advance or inspect ?)
-			err = DappStrategy(&response, r, ih)
-			if err != nil {
-				errlog.Println(err)
-				finish.Status = "reject"
-			}
-		}
+	if err := rollups.Run(ctx, r); err != nil {
+		errlog.Println(err)
 	}
 }
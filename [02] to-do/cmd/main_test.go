@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/cartesi/handler/advance"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/cartesi/handler/inspect"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository/in_memory"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/usecase"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups/rollupstest"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/wallet"
+)
+
+func setupServer(t *testing.T) *rollupstest.Server {
+	repo, err := in_memory.NewInMemoryRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := setupRouter(advance.NewToDoAdvanceHandlers(repo), inspect.NewToDoInspectHandlers(repo), wallet.New(repo))
+	return rollupstest.Run(t, r)
+}
+
+func TestAdvance(t *testing.T) {
+	srv := setupServer(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		accepted bool
+		notice   string
+	}{
+		{
+			name:     "create",
+			input:    `{"path":"createToDo","payload":{"title":"Write tests","description":"Use rollupstest"}}`,
+			accepted: true,
+			notice:   `To-Do created - {"id":1,"title":"Write tests","description":"Use rollupstest","completed":false,"created_at":10}`,
+		},
+		{
+			name:     "update",
+			input:    `{"path":"updateToDo","payload":{"id":1,"title":"Write tests","description":"Use rollupstest","completed":true}}`,
+			accepted: true,
+			notice:   `To-Do updated - {"id":1,"title":"Write tests","description":"Use rollupstest","completed":true,"created_at":10,"updated_at":10}`,
+		},
+		{
+			name:  "invalid input",
+			input: `{"path":"createToDo","payload":{"title":"Write tests"}}`,
+		},
+		{
+			name:  "unknown path",
+			input: `{"path":"archiveToDo","payload":{"id":1}}`,
+		},
+		{
+			name:     "delete",
+			input:    `{"path":"deleteToDo","payload":{"id":1}}`,
+			accepted: true,
+			notice:   `To-Do deleted - {"id":1}`,
+		},
+		{
+			name:  "delete missing",
+			input: `{"path":"deleteToDo","payload":{"id":1}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := srv.Advance([]byte(tt.input), rollups.Metadata{BlockTimestamp: 10})
+			if res.Err != nil {
+				t.Fatal(res.Err)
+			}
+			if res.Accepted() != tt.accepted {
+				t.Fatalf("expected accepted to be %v, got status %q", tt.accepted, res.Status)
+			}
+			if !tt.accepted {
+				if len(res.Notices) != 0 {
+					t.Fatalf("expected no notices, got %d", len(res.Notices))
+				}
+				return
+			}
+			if len(res.Notices) != 1 || string(res.Notices[0]) != tt.notice {
+				t.Fatalf("expected notice %q, got %q", tt.notice, res.Notices)
+			}
+		})
+	}
+}
+
+func TestInspect(t *testing.T) {
+	srv := setupServer(t)
+	for _, input := range []string{
+		`{"path":"createToDo","payload":{"title":"First","description":"Pending"}}`,
+		`{"path":"createToDo","payload":{"title":"Second","description":"Done"}}`,
+		`{"path":"updateToDo","payload":{"id":2,"title":"Second","description":"Done","completed":true}}`,
+	} {
+		if res := srv.Advance([]byte(input), rollups.Metadata{}); !res.Accepted() {
+			t.Fatalf("failed to advance %s: %q", input, res.Status)
+		}
+	}
+
+	tests := []struct {
+		path     string
+		accepted bool
+		ids      []uint
+	}{
+		{path: "todo", accepted: true, ids: []uint{1, 2}},
+		{path: "todo?limit=1", accepted: true, ids: []uint{1}},
+		{path: "todo?limit=1&offset=1", accepted: true, ids: []uint{2}},
+		{path: "todo?cursor=1", accepted: true, ids: []uint{2}},
+		{path: "todo?sort=id&order=desc", accepted: true, ids: []uint{2, 1}},
+		{path: "todo?state=pending", accepted: true, ids: []uint{1}},
+		{path: "todo?from=1", accepted: true},
+		{path: "todo?sort=description"},
+		{path: "todo?state=done"},
+		{path: "todo?limit=many"},
+		{path: "todo/completed", accepted: true, ids: []uint{2}},
+		{path: "todo/completed?state=pending", accepted: true, ids: []uint{2}},
+		{path: "todo/1", accepted: true, ids: []uint{1}},
+		{path: "/todo/2/", accepted: true, ids: []uint{2}},
+		{path: "todo/3"},
+		{path: "todo/first"},
+		{path: "done"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			res := srv.Inspect([]byte(tt.path))
+			if res.Err != nil {
+				t.Fatal(res.Err)
+			}
+			if res.Accepted() != tt.accepted {
+				t.Fatalf("expected accepted to be %v, got status %q", tt.accepted, res.Status)
+			}
+			if !tt.accepted {
+				return
+			}
+			if len(res.Reports) != 1 {
+				t.Fatalf("expected 1 report, got %d", len(res.Reports))
+			}
+
+			var page usecase.FindAllToDosOutputDTO
+			if err := json.Unmarshal(res.Reports[0], &page); err != nil {
+				t.Fatal(err)
+			}
+			if page.Items == nil {
+				var toDo usecase.FindToDoOutputDTO
+				if err := json.Unmarshal(res.Reports[0], &toDo); err != nil {
+					t.Fatal(err)
+				}
+				page.Items = append(page.Items, &toDo)
+			}
+
+			ids := []uint{}
+			for _, toDo := range page.Items {
+				ids = append(ids, toDo.Id)
+			}
+			if !slices.Equal(ids, tt.ids) {
+				t.Fatalf("expected ids %v, got %s", tt.ids, res.Reports[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository/factory"
+)
+
+// runCommand runs the subcommand named by args instead of the rollup.
+func runCommand(ctx context.Context, w io.Writer, args []string) error {
+	switch strings.Join(args, " ") {
+	case "migrate status":
+		return migrateStatus(ctx, w)
+	default:
+		return fmt.Errorf("unknown command %q, expected \"migrate status\"", strings.Join(args, " "))
+	}
+}
+
+func migrateStatus(ctx context.Context, w io.Writer) error {
+	statuses, err := factory.MigrationStatusFromConnectionString(ctx, databaseConn)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tSTATE\tDESCRIPTION")
+	for _, status := range statuses {
+		state := "pending"
+		if status.Applied {
+			state = "applied"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", status.Version, state, status.Description)
+	}
+	return tw.Flush()
+}
@@ -10,6 +10,12 @@ var (
 	ErrNotFound    = errors.New("to-do not found")
 )
 
+// The states a to-do can be listed by.
+const (
+	ToDoStateCompleted = "completed"
+	ToDoStatePending   = "pending"
+)
+
 type ToDo struct {
 	Id          uint   `json:"id" gorm:"primaryKey"`
 	Title       string `json:"title" gorm:"type:text;not null"`
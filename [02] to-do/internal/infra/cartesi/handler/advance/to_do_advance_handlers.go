@@ -1,10 +1,6 @@
 package advance
 
 import (
-	"encoding/json"
-	"fmt"
-
-	"github.com/go-playground/validator/v10"
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/usecase"
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
@@ -20,80 +16,20 @@ func NewToDoAdvanceHandlers(toDoRepository repository.ToDoRepository) *ToDoAdvan
 	}
 }
 
-func (h *ToDoAdvanceHandlers) CreateToDoHandler(payload []byte, metadata rollups.Metadata) error {
-	var input usecase.CreateToDoInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return err
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
+func (h *ToDoAdvanceHandlers) CreateToDoHandler(input *usecase.CreateToDoInputDTO, metadata rollups.Metadata) (*usecase.CreateToDoOutputDTO, error) {
 	createToDo := usecase.NewCreateToDoUseCase(h.ToDoRepository)
-	res, err := createToDo.Execute(&input, metadata)
-	if err != nil {
-		return err
-	}
-	toDo, err := json.Marshal(res)
-	if err != nil {
-		return err
-	}
-	rollups.SendNotice(&rollups.NoticeRequest{
-		Payload: rollups.Str2Hex(fmt.Sprintf("To-Do created - %s", toDo)),
-	})
-	return nil
+	return createToDo.Execute(input, metadata)
 }
 
-func (h *ToDoAdvanceHandlers) UpdateToDoHandler(payload []byte, metadata rollups.Metadata) error {
-	var input usecase.UpdateToDoInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return err
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
+func (h *ToDoAdvanceHandlers) UpdateToDoHandler(input *usecase.UpdateToDoInputDTO, metadata rollups.Metadata) (*usecase.UpdateToDoOutputDTO, error) {
 	updateToDo := usecase.NewUpdateToDoUseCase(h.ToDoRepository)
-	res, err := updateToDo.Execute(&input, metadata)
-	if err != nil {
-		return err
-	}
-	toDo, err := json.Marshal(res)
-	if err != nil {
-		return err
-	}
-	rollups.SendNotice(&rollups.NoticeRequest{
-		Payload: rollups.Str2Hex(fmt.Sprintf("To-Do updated - %s", toDo)),
-	})
-	return nil
+	return updateToDo.Execute(input, metadata)
 }
 
-func (h *ToDoAdvanceHandlers) DeleteToDoHandler(payload []byte, metadata rollups.Metadata) error {
-	var input usecase.DeleteToDoInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return err
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-	
+func (h *ToDoAdvanceHandlers) DeleteToDoHandler(input *usecase.DeleteToDoInputDTO, metadata rollups.Metadata) (*usecase.DeleteToDoInputDTO, error) {
 	deleteToDo := usecase.NewDeleteToDoUseCase(h.ToDoRepository)
-	err := deleteToDo.Execute(&input)
-	if err != nil {
-		return err
-	}
-	toDo, err := json.Marshal(input)
-	if err != nil {
-		return err
+	if err := deleteToDo.Execute(input); err != nil {
+		return nil, err
 	}
-	rollups.SendNotice(&rollups.NoticeRequest{
-		Payload: rollups.Str2Hex(fmt.Sprintf("To-Do deleted - %s", toDo)),
-	})
-	return nil
+	return input, nil
 }
@@ -1,10 +1,13 @@
 package inspect
 
 import (
-	"encoding/json"
+	"fmt"
+	"strconv"
 
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/domain"
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/usecase"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/query"
 	rollups "github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
 )
 
@@ -18,18 +21,27 @@ func NewToDoInspectHandlers(toDoRepository repository.ToDoRepository) *ToDoInspe
 	}
 }
 
-func (h *ToDoInspectHandlers) FindAllToDosHandler() error {
-	findAllToDos := usecase.NewFindAllToDosUseCase(h.ToDoRepository)
-	res, err := findAllToDos.Execute()
+// FindAllToDosHandler lists to-dos paged, filtered and sorted by the query
+// string of the path, as in "todo?state=pending&sort=created_at&order=desc".
+func (h *ToDoInspectHandlers) FindAllToDosHandler(params rollups.Params) (*usecase.FindAllToDosOutputDTO, error) {
+	input, err := query.FromValues(params.Values())
 	if err != nil {
-		return err
+		return nil, err
 	}
-	toDos, err := json.Marshal(res)
+	findAllToDos := usecase.NewFindAllToDosUseCase(h.ToDoRepository)
+	return findAllToDos.Execute(&input)
+}
+
+func (h *ToDoInspectHandlers) FindToDoByIdHandler(params rollups.Params) (*usecase.FindToDoOutputDTO, error) {
+	id, err := strconv.ParseUint(params["id"], 10, 0)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("invalid to-do id %q: %w", params["id"], err)
 	}
-	rollups.SendReport(&rollups.ReportRequest{
-		Payload: rollups.Str2Hex(string(toDos)),
-	})
-	return nil
+	findToDoById := usecase.NewFindToDoByIdUseCase(h.ToDoRepository)
+	return findToDoById.Execute(&usecase.FindToDoByIdInputDTO{Id: uint(id)})
+}
+
+func (h *ToDoInspectHandlers) FindCompletedToDosHandler(params rollups.Params) (*usecase.FindAllToDosOutputDTO, error) {
+	params["state"] = domain.ToDoStateCompleted
+	return h.FindAllToDosHandler(params)
 }
@@ -8,6 +8,7 @@ import (
 	. "github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository/in_memory"
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository/sqlite"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/migrate"
 )
 
 // NewRepositoryFromConnectionString chooses the backend based on the connection string.
@@ -28,6 +29,19 @@ func NewRepositoryFromConnectionString(ctx context.Context, conn string) (Reposi
 	}
 }
 
+// MigrationStatusFromConnectionString reports which schema migrations have been
+// applied to the database at conn, without migrating it. Only SQLite databases
+// are migrated.
+func MigrationStatusFromConnectionString(ctx context.Context, conn string) ([]migrate.Status, error) {
+	lowerConn := strings.ToLower(conn)
+	switch {
+	case strings.HasPrefix(lowerConn, "sqlite://"):
+		return sqlite.MigrationStatus(ctx, conn)
+	default:
+		return nil, fmt.Errorf("no schema migrations for connection string: %s", conn)
+	}
+}
+
 func newInMemoryRepository() (Repository, error) {
 	inMemoryRepo, err := in_memory.NewInMemoryRepository()
 	if err != nil {
@@ -4,26 +4,30 @@ import (
 	"sync"
 
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/domain"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/wallet"
 )
 
 type InMemoryRepository struct {
-	Db     map[uint]*domain.ToDo
-	Mutex  *sync.RWMutex
-	NextID uint
+	Db       map[uint]*domain.ToDo
+	Balances map[balanceKey]*wallet.Balance
+	Mutex    *sync.RWMutex
+	NextID   uint
 }
 
 func (r *InMemoryRepository) Close() error {
 	r.Mutex.Lock()
 	defer r.Mutex.Unlock()
 	r.Db = make(map[uint]*domain.ToDo)
+	r.Balances = make(map[balanceKey]*wallet.Balance)
 	r.NextID = 1
 	return nil
 }
 
 func NewInMemoryRepository() (*InMemoryRepository, error) {
 	return &InMemoryRepository{
-		Db:     make(map[uint]*domain.ToDo),
-		Mutex:  &sync.RWMutex{},
-		NextID: 1,
+		Db:       make(map[uint]*domain.ToDo),
+		Balances: make(map[balanceKey]*wallet.Balance),
+		Mutex:    &sync.RWMutex{},
+		NextID:   1,
 	}, nil
 }
@@ -0,0 +1,19 @@
+package in_memory_test
+
+import (
+	"testing"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository/in_memory"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository/repositorytest"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.Repository {
+		repo, err := in_memory.NewInMemoryRepository()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repo
+	})
+}
@@ -1,7 +1,11 @@
 package in_memory
 
 import (
+	"cmp"
+	"slices"
+
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/domain"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/query"
 )
 
 func (r *InMemoryRepository) CreateToDo(input *domain.ToDo) (*domain.ToDo, error) {
@@ -10,19 +14,64 @@ func (r *InMemoryRepository) CreateToDo(input *domain.ToDo) (*domain.ToDo, error
 
 	input.Id = r.NextID
 	r.NextID++
-	r.Db[input.Id] = input
+	r.Db[input.Id] = copyToDo(input)
 	return input, nil
 }
 
-func (r *InMemoryRepository) FindAllToDos() ([]*domain.ToDo, error) {
+func (r *InMemoryRepository) FindAllToDos(q query.Query) ([]*domain.ToDo, int64, error) {
 	r.Mutex.RLock()
 	defer r.Mutex.RUnlock()
 
 	var todos []*domain.ToDo
 	for _, todo := range r.Db {
-		todos = append(todos, todo)
+		if q.State != "" && todo.Completed != (q.State == domain.ToDoStateCompleted) {
+			continue
+		}
+		if (q.From != 0 && todo.CreatedAt < uint64(q.From)) || (q.To != 0 && todo.CreatedAt > uint64(q.To)) {
+			continue
+		}
+		todos = append(todos, copyToDo(todo))
+	}
+	total := int64(len(todos))
+
+	desc := q.Order == query.Desc
+	slices.SortFunc(todos, func(a, b *domain.ToDo) int {
+		c := cmp.Or(compareToDos(a, b, q.Sort), cmp.Compare(a.Id, b.Id))
+		if desc {
+			return -c
+		}
+		return c
+	})
+	if q.Cursor != 0 {
+		todos = slices.DeleteFunc(todos, func(todo *domain.ToDo) bool {
+			return (!desc && todo.Id <= q.Cursor) || (desc && todo.Id >= q.Cursor)
+		})
 	}
-	return todos, nil
+	todos = todos[min(q.Offset, len(todos)):]
+	return todos[:min(q.Limit, len(todos))], total, nil
+}
+
+func compareToDos(a, b *domain.ToDo, field string) int {
+	switch field {
+	case "title":
+		return cmp.Compare(a.Title, b.Title)
+	case "created_at":
+		return cmp.Compare(a.CreatedAt, b.CreatedAt)
+	case "updated_at":
+		return cmp.Compare(a.UpdatedAt, b.UpdatedAt)
+	}
+	return cmp.Compare(a.Id, b.Id)
+}
+
+func (r *InMemoryRepository) FindToDoById(id uint) (*domain.ToDo, error) {
+	r.Mutex.RLock()
+	defer r.Mutex.RUnlock()
+
+	todo, exists := r.Db[id]
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+	return copyToDo(todo), nil
 }
 
 func (r *InMemoryRepository) UpdateToDo(input *domain.ToDo) (*domain.ToDo, error) {
@@ -37,10 +86,9 @@ func (r *InMemoryRepository) UpdateToDo(input *domain.ToDo) (*domain.ToDo, error
 	todo.Title = input.Title
 	todo.Description = input.Description
 	todo.Completed = input.Completed
+	todo.UpdatedAt = input.UpdatedAt
 
-	r.Db[input.Id] = todo
-
-	return todo, nil
+	return copyToDo(todo), nil
 }
 
 func (r *InMemoryRepository) DeleteToDo(id uint) error {
@@ -54,3 +102,9 @@ func (r *InMemoryRepository) DeleteToDo(id uint) error {
 	delete(r.Db, id)
 	return nil
 }
+
+// copyToDo keeps callers from changing a stored to-do without updating it.
+func copyToDo(todo *domain.ToDo) *domain.ToDo {
+	c := *todo
+	return &c
+}
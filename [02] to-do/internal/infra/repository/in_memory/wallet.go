@@ -0,0 +1,89 @@
+package in_memory
+
+import (
+	"context"
+	"maps"
+	"math/big"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/wallet"
+)
+
+type balanceKey struct {
+	asset wallet.Asset
+	owner rollups.Address
+}
+
+func copyBalance(balance *wallet.Balance) *wallet.Balance {
+	return &wallet.Balance{
+		Asset:  balance.Asset,
+		Owner:  balance.Owner,
+		Amount: new(big.Int).Set(balance.Amount),
+	}
+}
+
+func (r *InMemoryRepository) FindBalance(ctx context.Context, asset wallet.Asset, owner rollups.Address) (*big.Int, error) {
+	r.Mutex.RLock()
+	defer r.Mutex.RUnlock()
+
+	balance, exists := r.Balances[balanceKey{asset, owner}]
+	if !exists {
+		return new(big.Int), nil
+	}
+	return new(big.Int).Set(balance.Amount), nil
+}
+
+func (r *InMemoryRepository) FindBalancesByAsset(ctx context.Context, asset wallet.Asset) ([]*wallet.Balance, error) {
+	r.Mutex.RLock()
+	defer r.Mutex.RUnlock()
+
+	var balances []*wallet.Balance
+	for key, balance := range r.Balances {
+		if key.asset == asset {
+			balances = append(balances, copyBalance(balance))
+		}
+	}
+	return balances, nil
+}
+
+func (r *InMemoryRepository) FindBalancesByOwner(ctx context.Context, owner rollups.Address) ([]*wallet.Balance, error) {
+	r.Mutex.RLock()
+	defer r.Mutex.RUnlock()
+
+	var balances []*wallet.Balance
+	for key, balance := range r.Balances {
+		if key.owner == owner {
+			balances = append(balances, copyBalance(balance))
+		}
+	}
+	return balances, nil
+}
+
+func (r *InMemoryRepository) SaveBalance(ctx context.Context, balance *wallet.Balance) error {
+	r.Mutex.Lock()
+	defer r.Mutex.Unlock()
+
+	key := balanceKey{balance.Asset, balance.Owner}
+	if balance.Amount.Sign() == 0 {
+		delete(r.Balances, key)
+		return nil
+	}
+	r.Balances[key] = copyBalance(balance)
+	return nil
+}
+
+// RunInTransaction restores the balances held before fn when it fails. The
+// stored balances are never modified in place, so a shallow copy is enough.
+func (r *InMemoryRepository) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	r.Mutex.RLock()
+	balances := maps.Clone(r.Balances)
+	r.Mutex.RUnlock()
+
+	if err := fn(ctx); err != nil {
+		r.Mutex.Lock()
+		r.Balances = balances
+		r.Mutex.Unlock()
+		return err
+	}
+	return nil
+}
@@ -1,15 +1,22 @@
 package repository
 
-import "github.com/henriquemarlon/cartesi-golang-series/to-do/internal/domain"
+import (
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/domain"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/query"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/wallet"
+)
 
 type ToDoRepository interface {
 	CreateToDo(toDo *domain.ToDo) (*domain.ToDo, error)
-	FindAllToDos() ([]*domain.ToDo, error)
+	// FindAllToDos expects a normalized query, filtering by state and creation time.
+	FindAllToDos(q query.Query) ([]*domain.ToDo, int64, error)
+	FindToDoById(id uint) (*domain.ToDo, error)
 	UpdateToDo(toDo *domain.ToDo) (*domain.ToDo, error)
 	DeleteToDo(id uint) error
 }
 
 type Repository interface {
 	ToDoRepository
+	wallet.Store
 	Close() error
 }
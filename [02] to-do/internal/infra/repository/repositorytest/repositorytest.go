@@ -0,0 +1,283 @@
+// Package repositorytest checks that an implementation of repository.Repository
+// behaves like the others, so the application can switch backends without
+// noticing. Each backend runs it from its own tests.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/domain"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/query"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/wallet"
+)
+
+// Run runs the conformance tests, each against a new and empty repository
+// returned by open.
+func Run(t *testing.T, open func(t *testing.T) repository.Repository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo repository.Repository)
+	}{
+		{"CreateAssignsIncreasingIds", testCreateAssignsIncreasingIds},
+		{"MissingToDoIsNotFound", testMissingToDoIsNotFound},
+		{"UpdateReplacesFields", testUpdateReplacesFields},
+		{"ReturnedToDosAreCopies", testReturnedToDosAreCopies},
+		{"DeleteRemovesToDo", testDeleteRemovesToDo},
+		{"FindAllSortsAndPages", testFindAllSortsAndPages},
+		{"FindAllFilters", testFindAllFilters},
+		{"Balances", testBalances},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := open(t)
+			t.Cleanup(func() { repo.Close() })
+			tt.test(t, repo)
+		})
+	}
+}
+
+func create(t *testing.T, repo repository.Repository, title string, createdAt uint64) *domain.ToDo {
+	t.Helper()
+	toDo, err := domain.NewToDo(title, "description of "+title, createdAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toDo, err = repo.CreateToDo(toDo)
+	if err != nil {
+		t.Fatalf("CreateToDo: %v", err)
+	}
+	return toDo
+}
+
+func find(t *testing.T, repo repository.Repository, id uint) *domain.ToDo {
+	t.Helper()
+	toDo, err := repo.FindToDoById(id)
+	if err != nil {
+		t.Fatalf("FindToDoById(%d): %v", id, err)
+	}
+	return toDo
+}
+
+func testCreateAssignsIncreasingIds(t *testing.T, repo repository.Repository) {
+	first := create(t, repo, "first", 10)
+	second := create(t, repo, "second", 20)
+	if first.Id == 0 || second.Id <= first.Id {
+		t.Fatalf("ids = %d, %d; want increasing non-zero ids", first.Id, second.Id)
+	}
+
+	got := find(t, repo, second.Id)
+	want := domain.ToDo{Id: second.Id, Title: "second", Description: "description of second", CreatedAt: 20}
+	if *got != want {
+		t.Errorf("FindToDoById = %+v, want %+v", *got, want)
+	}
+}
+
+func testMissingToDoIsNotFound(t *testing.T, repo repository.Repository) {
+	if _, err := repo.FindToDoById(42); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("FindToDoById error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if _, err := repo.UpdateToDo(&domain.ToDo{Id: 42, Title: "t", Description: "d", UpdatedAt: 1}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("UpdateToDo error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if err := repo.DeleteToDo(42); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("DeleteToDo error = %v, want %v", err, domain.ErrNotFound)
+	}
+}
+
+func testUpdateReplacesFields(t *testing.T, repo repository.Repository) {
+	toDo := create(t, repo, "title", 10)
+
+	updated, err := repo.UpdateToDo(&domain.ToDo{Id: toDo.Id, Title: "new title", Description: "new description", Completed: true, UpdatedAt: 20})
+	if err != nil {
+		t.Fatalf("UpdateToDo: %v", err)
+	}
+	want := domain.ToDo{Id: toDo.Id, Title: "new title", Description: "new description", Completed: true, CreatedAt: 10, UpdatedAt: 20}
+	if *updated != want {
+		t.Errorf("UpdateToDo = %+v, want %+v", *updated, want)
+	}
+
+	// Zero values are written too: a completed to-do can be reopened.
+	if _, err := repo.UpdateToDo(&domain.ToDo{Id: toDo.Id, Title: "new title", Description: "new description", UpdatedAt: 30}); err != nil {
+		t.Fatalf("UpdateToDo: %v", err)
+	}
+	want.Completed, want.UpdatedAt = false, 30
+	if got := find(t, repo, toDo.Id); *got != want {
+		t.Errorf("FindToDoById = %+v, want %+v", *got, want)
+	}
+}
+
+func testReturnedToDosAreCopies(t *testing.T, repo repository.Repository) {
+	toDo := create(t, repo, "title", 10)
+	toDo.Title = "changed"
+	find(t, repo, toDo.Id).Completed = true
+
+	if got := find(t, repo, toDo.Id); got.Title != "title" || got.Completed {
+		t.Errorf("FindToDoById = %+v, want the stored to-do unchanged", *got)
+	}
+}
+
+func testDeleteRemovesToDo(t *testing.T, repo repository.Repository) {
+	toDo := create(t, repo, "title", 10)
+	other := create(t, repo, "other", 10)
+	if err := repo.DeleteToDo(toDo.Id); err != nil {
+		t.Fatalf("DeleteToDo: %v", err)
+	}
+	if _, err := repo.FindToDoById(toDo.Id); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("FindToDoById error = %v, want %v", err, domain.ErrNotFound)
+	}
+	find(t, repo, other.Id)
+}
+
+func ids(toDos []*domain.ToDo) []uint {
+	ids := make([]uint, len(toDos))
+	for i, toDo := range toDos {
+		ids[i] = toDo.Id
+	}
+	return ids
+}
+
+func findAll(t *testing.T, repo repository.Repository, q query.Query) ([]uint, int64) {
+	t.Helper()
+	q, err := q.Normalize("id", "title", "created_at", "updated_at")
+	if err != nil {
+		t.Fatal(err)
+	}
+	toDos, total, err := repo.FindAllToDos(q)
+	if err != nil {
+		t.Fatalf("FindAllToDos(%+v): %v", q, err)
+	}
+	return ids(toDos), total
+}
+
+func testFindAllSortsAndPages(t *testing.T, repo repository.Repository) {
+	// Created in an order that differs from both the title and time orders.
+	var all []uint
+	for _, c := range []struct {
+		title     string
+		createdAt uint64
+	}{{"b", 30}, {"a", 10}, {"c", 20}, {"a", 40}} {
+		all = append(all, create(t, repo, c.title, c.createdAt).Id)
+	}
+
+	tests := []struct {
+		name  string
+		q     query.Query
+		want  []uint
+		total int64
+	}{
+		{"by id", query.Query{}, all, 4},
+		{"by id descending", query.Query{Order: query.Desc}, []uint{all[3], all[2], all[1], all[0]}, 4},
+		{"by title, ties by id", query.Query{Sort: "title"}, []uint{all[1], all[3], all[0], all[2]}, 4},
+		{"by title descending", query.Query{Sort: "title", Order: query.Desc}, []uint{all[2], all[0], all[3], all[1]}, 4},
+		{"by creation time", query.Query{Sort: "created_at"}, []uint{all[1], all[2], all[0], all[3]}, 4},
+		{"page", query.Query{Sort: "created_at", Limit: 2, Offset: 1}, []uint{all[2], all[0]}, 4},
+		{"offset past the end", query.Query{Offset: 10}, []uint{}, 4},
+		{"after cursor", query.Query{Cursor: all[1], Limit: 1}, []uint{all[2]}, 4},
+		{"before cursor descending", query.Query{Cursor: all[2], Order: query.Desc}, []uint{all[1], all[0]}, 4},
+	}
+	for _, tt := range tests {
+		got, total := findAll(t, repo, tt.q)
+		if !slices.Equal(got, tt.want) || total != tt.total {
+			t.Errorf("%s: FindAllToDos = %v of %d, want %v of %d", tt.name, got, total, tt.want, tt.total)
+		}
+	}
+}
+
+func testFindAllFilters(t *testing.T, repo repository.Repository) {
+	early := create(t, repo, "early", 10)
+	middle := create(t, repo, "middle", 20)
+	late := create(t, repo, "late", 30)
+	if _, err := repo.UpdateToDo(&domain.ToDo{Id: middle.Id, Title: "middle", Description: "done", Completed: true, UpdatedAt: 40}); err != nil {
+		t.Fatalf("UpdateToDo: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		q    query.Query
+		want []uint
+	}{
+		{"completed", query.Query{State: domain.ToDoStateCompleted}, []uint{middle.Id}},
+		{"pending", query.Query{State: domain.ToDoStatePending}, []uint{early.Id, late.Id}},
+		{"created from, inclusive", query.Query{From: 20}, []uint{middle.Id, late.Id}},
+		{"created to, inclusive", query.Query{To: 20}, []uint{early.Id, middle.Id}},
+		{"pending in range", query.Query{State: domain.ToDoStatePending, From: 15, To: 35}, []uint{late.Id}},
+	}
+	for _, tt := range tests {
+		got, total := findAll(t, repo, tt.q)
+		if !slices.Equal(got, tt.want) || total != int64(len(tt.want)) {
+			t.Errorf("%s: FindAllToDos = %v of %d, want %v", tt.name, got, total, tt.want)
+		}
+	}
+}
+
+func testBalances(t *testing.T, repo repository.Repository) {
+	alice, bob := rollups.Address{0x01}, rollups.Address{0x02}
+	token := wallet.Asset{Kind: wallet.ERC20, Token: rollups.Address{0x09}}
+	ether := wallet.Asset{Kind: wallet.Ether}
+	ctx := context.Background()
+
+	amount, err := repo.FindBalance(ctx, token, alice)
+	if err != nil || amount.Sign() != 0 {
+		t.Fatalf("FindBalance of an unknown balance = %v, %v; want 0", amount, err)
+	}
+
+	for _, balance := range []*wallet.Balance{
+		{Asset: token, Owner: alice, Amount: big.NewInt(100)},
+		{Asset: token, Owner: bob, Amount: big.NewInt(5)},
+		{Asset: ether, Owner: alice, Amount: big.NewInt(7)},
+		{Asset: token, Owner: alice, Amount: big.NewInt(60)},
+	} {
+		if err := repo.SaveBalance(ctx, balance); err != nil {
+			t.Fatalf("SaveBalance: %v", err)
+		}
+	}
+	if amount, err := repo.FindBalance(ctx, token, alice); err != nil || amount.Int64() != 60 {
+		t.Errorf("FindBalance = %v, %v; want 60", amount, err)
+	}
+	byAsset, err := repo.FindBalancesByAsset(ctx, token)
+	if err != nil || len(byAsset) != 2 {
+		t.Errorf("FindBalancesByAsset = %d balances, %v; want 2", len(byAsset), err)
+	}
+
+	// A zero balance is deleted rather than stored.
+	if err := repo.SaveBalance(ctx, &wallet.Balance{Asset: ether, Owner: alice, Amount: new(big.Int)}); err != nil {
+		t.Fatalf("SaveBalance: %v", err)
+	}
+	byOwner, err := repo.FindBalancesByOwner(ctx, alice)
+	if err != nil {
+		t.Fatalf("FindBalancesByOwner: %v", err)
+	}
+	if len(byOwner) != 1 || byOwner[0].Asset != token || byOwner[0].Amount.Int64() != 60 {
+		t.Errorf("FindBalancesByOwner = %v, want only the token balance of 60", byOwner)
+	}
+
+	// A failed transaction leaves every balance as it was.
+	errRejected := errors.New("rejected")
+	err = repo.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := repo.SaveBalance(ctx, &wallet.Balance{Asset: token, Owner: alice, Amount: big.NewInt(1)}); err != nil {
+			return err
+		}
+		if err := repo.SaveBalance(ctx, &wallet.Balance{Asset: ether, Owner: bob, Amount: big.NewInt(9)}); err != nil {
+			return err
+		}
+		if amount, err := repo.FindBalance(ctx, token, alice); err != nil || amount.Int64() != 1 {
+			t.Errorf("FindBalance in the transaction = %v, %v; want 1", amount, err)
+		}
+		return errRejected
+	})
+	if !errors.Is(err, errRejected) {
+		t.Fatalf("RunInTransaction error = %v, want %v", err, errRejected)
+	}
+	if amount, err := repo.FindBalance(ctx, token, alice); err != nil || amount.Int64() != 60 {
+		t.Errorf("FindBalance after a rollback = %v, %v; want 60", amount, err)
+	}
+	if amount, err := repo.FindBalance(ctx, ether, bob); err != nil || amount.Sign() != 0 {
+		t.Errorf("FindBalance after a rollback = %v, %v; want 0", amount, err)
+	}
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+// migrations is the schema history of the database. Steps are append-only:
+// a deployed drive may already be at any of these versions, so change the
+// schema by adding a new version rather than editing an old one.
+var migrations = []migrate.Migration{
+	{
+		// Matches the schema AutoMigrate created before migrations were
+		// versioned, so existing drives adopt it unchanged.
+		Version:     1,
+		Description: "create to-dos",
+		Up: execAll(
+			"CREATE TABLE IF NOT EXISTS `to_dos` (`id` integer PRIMARY KEY AUTOINCREMENT,`title` text NOT NULL,`description` text NOT NULL,`completed` numeric DEFAULT false,`created_at` integer NOT NULL,`updated_at` integer DEFAULT 0)",
+		),
+	},
+	{
+		Version:     2,
+		Description: "create wallet balances",
+		Up: execAll(
+			"CREATE TABLE IF NOT EXISTS `balances` (`kind` text,`token` text,`token_id` text,`owner` text,`amount` text NOT NULL,PRIMARY KEY (`kind`,`token`,`token_id`,`owner`))",
+			"CREATE INDEX IF NOT EXISTS `idx_balances_owner` ON `balances`(`owner`)",
+		),
+	},
+}
+
+func execAll(statements ...string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
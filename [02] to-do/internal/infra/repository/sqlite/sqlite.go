@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/migrate"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -25,6 +26,42 @@ func (r *SQLiteRepository) Close() error {
 }
 
 func NewSQLiteRepository(ctx context.Context, conn string) (*SQLiteRepository, error) {
+	db, err := open(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := migrate.New(db, migrations...)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrator.Up(ctx); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return &SQLiteRepository{
+		Db: db,
+	}, nil
+}
+
+// MigrationStatus reports which migrations have been applied to the database
+// at conn, without migrating it.
+func MigrationStatus(ctx context.Context, conn string) ([]migrate.Status, error) {
+	db, err := open(conn)
+	if err != nil {
+		return nil, err
+	}
+	repo := &SQLiteRepository{Db: db}
+	defer repo.Close()
+
+	migrator, err := migrate.New(db, migrations...)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Status(ctx)
+}
+
+func open(conn string) (*gorm.DB, error) {
 	// Remove sqlite:// prefix if present
 	dbPath := strings.TrimPrefix(conn, "sqlite://")
 
@@ -39,14 +76,7 @@ func NewSQLiteRepository(ctx context.Context, conn string) (*SQLiteRepository, e
 		},
 	)
 
-	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{
+	return gorm.Open(sqlite.Open(dbPath), &gorm.Config{
 		Logger: gormLogger,
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	return &SQLiteRepository{
-		Db: db,
-	}, nil
 }
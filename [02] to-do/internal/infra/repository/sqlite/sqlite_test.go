@@ -0,0 +1,20 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository/repositorytest"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository/sqlite"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.Repository {
+		repo, err := sqlite.NewSQLiteRepository(context.Background(), "sqlite://:memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repo
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/domain"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/query"
 	"gorm.io/gorm"
 )
 
@@ -14,22 +15,35 @@ func (r *SQLiteRepository) CreateToDo(input *domain.ToDo) (*domain.ToDo, error)
 	return input, nil
 }
 
-func (r *SQLiteRepository) FindAllToDos() ([]*domain.ToDo, error) {
+func (r *SQLiteRepository) FindAllToDos(q query.Query) ([]*domain.ToDo, int64, error) {
 	var toDos []*domain.ToDo
-	if err := r.Db.Find(&toDos).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("failed to find all to-dos: %w", domain.ErrNotFound)
-		}
-		return nil, fmt.Errorf("failed to find all to-dos: %w", err)
+	db := r.Db
+	if q.State != "" {
+		db = db.Where("completed = ?", q.State == domain.ToDoStateCompleted)
 	}
-	return toDos, nil
+	total, err := findPage(db, q, &toDos, "created_at")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find all to-dos: %w", err)
+	}
+	return toDos, total, nil
 }
 
 func (r *SQLiteRepository) UpdateToDo(input *domain.ToDo) (*domain.ToDo, error) {
-	if err := r.Db.Updates(&input).Error; err != nil {
-		return nil, fmt.Errorf("failed to update to-do: %w", err)
+	// A map writes zero values too and UpdateColumns keeps the given
+	// updated_at instead of stamping the wall clock time.
+	res := r.Db.Model(&domain.ToDo{Id: input.Id}).UpdateColumns(map[string]any{
+		"title":       input.Title,
+		"description": input.Description,
+		"completed":   input.Completed,
+		"updated_at":  input.UpdatedAt,
+	})
+	if res.Error != nil {
+		return nil, fmt.Errorf("failed to update to-do: %w", res.Error)
 	}
-	toDo, err := r.findToDoById(input.Id)
+	if res.RowsAffected == 0 {
+		return nil, fmt.Errorf("failed to update to-do: %w", domain.ErrNotFound)
+	}
+	toDo, err := r.FindToDoById(input.Id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update to-do: %w", err)
 	}
@@ -37,16 +51,17 @@ func (r *SQLiteRepository) UpdateToDo(input *domain.ToDo) (*domain.ToDo, error)
 }
 
 func (r *SQLiteRepository) DeleteToDo(id uint) error {
-	if err := r.Db.Delete(&domain.ToDo{}, id).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("failed to delete to-do: %w", domain.ErrNotFound)
-		}
-		return fmt.Errorf("failed to delete to-do: %w", err)
+	res := r.Db.Delete(&domain.ToDo{}, id)
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete to-do: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("failed to delete to-do: %w", domain.ErrNotFound)
 	}
 	return nil
 }
 
-func (r *SQLiteRepository) findToDoById(id uint) (*domain.ToDo, error) {
+func (r *SQLiteRepository) FindToDoById(id uint) (*domain.ToDo, error) {
 	var toDo domain.ToDo
 	if err := r.Db.First(&toDo, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/wallet"
+	"gorm.io/gorm/clause"
+)
+
+// Balance is the row of a wallet balance. Addresses and amounts are stored as
+// text so that uint256 values fit.
+type Balance struct {
+	Kind    string `gorm:"primaryKey"`
+	Token   string `gorm:"primaryKey"`
+	TokenId string `gorm:"primaryKey"`
+	Owner   string `gorm:"primaryKey;index"`
+	Amount  string `gorm:"type:text;not null"`
+}
+
+func (b *Balance) toWallet() (*wallet.Balance, error) {
+	token, err := rollups.HexToAddress(b.Token)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := rollups.HexToAddress(b.Owner)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := new(big.Int).SetString(b.Amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount: %q", b.Amount)
+	}
+	return &wallet.Balance{
+		Asset:  wallet.Asset{Kind: wallet.Kind(b.Kind), Token: token, TokenId: b.TokenId},
+		Owner:  owner,
+		Amount: amount,
+	}, nil
+}
+
+func balanceRow(asset wallet.Asset, owner rollups.Address) *Balance {
+	return &Balance{
+		Kind:    string(asset.Kind),
+		Token:   asset.Token.String(),
+		TokenId: asset.TokenId,
+		Owner:   owner.String(),
+	}
+}
+
+func (r *SQLiteRepository) FindBalance(ctx context.Context, asset wallet.Asset, owner rollups.Address) (*big.Int, error) {
+	var balances []*Balance
+	if err := r.db(ctx).Where(balanceRow(asset, owner)).Limit(1).Find(&balances).Error; err != nil {
+		return nil, fmt.Errorf("failed to find balance: %w", err)
+	}
+	if len(balances) == 0 {
+		return new(big.Int), nil
+	}
+	balance, err := balances[0].toWallet()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find balance: %w", err)
+	}
+	return balance.Amount, nil
+}
+
+func (r *SQLiteRepository) FindBalancesByAsset(ctx context.Context, asset wallet.Asset) ([]*wallet.Balance, error) {
+	return r.findBalances(ctx, "kind = ? AND token = ? AND token_id = ?", string(asset.Kind), asset.Token.String(), asset.TokenId)
+}
+
+func (r *SQLiteRepository) FindBalancesByOwner(ctx context.Context, owner rollups.Address) ([]*wallet.Balance, error) {
+	return r.findBalances(ctx, "owner = ?", owner.String())
+}
+
+func (r *SQLiteRepository) SaveBalance(ctx context.Context, balance *wallet.Balance) error {
+	row := balanceRow(balance.Asset, balance.Owner)
+	if balance.Amount.Sign() == 0 {
+		if err := r.db(ctx).Where(row).Delete(&Balance{}).Error; err != nil {
+			return fmt.Errorf("failed to save balance: %w", err)
+		}
+		return nil
+	}
+	row.Amount = balance.Amount.String()
+	if err := r.db(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(row).Error; err != nil {
+		return fmt.Errorf("failed to save balance: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteRepository) findBalances(ctx context.Context, query string, args ...any) ([]*wallet.Balance, error) {
+	var rows []*Balance
+	if err := r.db(ctx).Where(query, args...).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to find balances: %w", err)
+	}
+	balances := make([]*wallet.Balance, len(rows))
+	for i, row := range rows {
+		balance, err := row.toWallet()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find balances: %w", err)
+		}
+		balances[i] = balance
+	}
+	return balances, nil
+}
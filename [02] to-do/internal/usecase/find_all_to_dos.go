@@ -1,6 +1,16 @@
 package usecase
 
-import "github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository"
+import (
+	"fmt"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/domain"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/query"
+)
+
+// toDoSortFields are the fields to-dos can be sorted by, the first being the
+// default.
+var toDoSortFields = []string{"id", "created_at", "updated_at", "title"}
 
 type FindToDoOutputDTO struct {
 	Id          uint   `json:"id"`
@@ -11,7 +21,9 @@ type FindToDoOutputDTO struct {
 	UpdatedAt   uint64 `json:"updated_at"`
 }
 
-type FindAllToDosOutputDTO []*FindToDoOutputDTO
+type FindAllToDosInputDTO = query.Query
+
+type FindAllToDosOutputDTO = query.Page[*FindToDoOutputDTO]
 
 type FindAllToDosUseCase struct {
 	ToDoRepository repository.ToDoRepository
@@ -23,12 +35,21 @@ func NewFindAllToDosUseCase(todoRepository repository.ToDoRepository) *FindAllTo
 	}
 }
 
-func (u *FindAllToDosUseCase) Execute() (*FindAllToDosOutputDTO, error) {
-	res, err := u.ToDoRepository.FindAllToDos()
+func (u *FindAllToDosUseCase) Execute(input *FindAllToDosInputDTO) (*FindAllToDosOutputDTO, error) {
+	q, err := input.Normalize(toDoSortFields...)
+	if err != nil {
+		return nil, err
+	}
+	switch q.State {
+	case "", domain.ToDoStateCompleted, domain.ToDoStatePending:
+	default:
+		return nil, fmt.Errorf("%w: unknown state %q", query.ErrInvalidQuery, q.State)
+	}
+	res, total, err := u.ToDoRepository.FindAllToDos(q)
 	if err != nil {
 		return nil, err
 	}
-	output := make(FindAllToDosOutputDTO, len(res))
+	output := make([]*FindToDoOutputDTO, len(res))
 	for i, todo := range res {
 		output[i] = &FindToDoOutputDTO{
 			Id:          todo.Id,
@@ -39,5 +60,5 @@ func (u *FindAllToDosUseCase) Execute() (*FindAllToDosOutputDTO, error) {
 			UpdatedAt:   todo.UpdatedAt,
 		}
 	}
-	return &output, nil
+	return query.NewPage(output, total, q, func(t *FindToDoOutputDTO) uint { return t.Id }), nil
 }
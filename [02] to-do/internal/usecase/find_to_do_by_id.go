@@ -0,0 +1,32 @@
+package usecase
+
+import "github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository"
+
+type FindToDoByIdInputDTO struct {
+	Id uint `json:"id" validate:"required"`
+}
+
+type FindToDoByIdUseCase struct {
+	ToDoRepository repository.ToDoRepository
+}
+
+func NewFindToDoByIdUseCase(todoRepository repository.ToDoRepository) *FindToDoByIdUseCase {
+	return &FindToDoByIdUseCase{
+		ToDoRepository: todoRepository,
+	}
+}
+
+func (u *FindToDoByIdUseCase) Execute(input *FindToDoByIdInputDTO) (*FindToDoOutputDTO, error) {
+	todo, err := u.ToDoRepository.FindToDoById(input.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &FindToDoOutputDTO{
+		Id:          todo.Id,
+		Title:       todo.Title,
+		Description: todo.Description,
+		Completed:   todo.Completed,
+		CreatedAt:   todo.CreatedAt,
+		UpdatedAt:   todo.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,153 @@
+// Package migrate applies versioned schema migrations to a GORM database and
+// records the applied versions in the schema_migrations table, so a persistent
+// drive can be upgraded in place by a newer build of the application.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidMigration = errors.New("invalid migration")
+	ErrUnknownVersion   = errors.New("database has a migration unknown to this build")
+)
+
+// Migration is one step of the schema. Up runs in the transaction that records
+// the step as applied, so a failed step leaves the database untouched.
+type Migration struct {
+	Version     uint
+	Description string
+	Up          func(tx *gorm.DB) error
+}
+
+// Status tells whether a migration has been applied to the database.
+type Status struct {
+	Version     uint   `json:"version"`
+	Description string `json:"description"`
+	Applied     bool   `json:"applied"`
+}
+
+type schemaMigration struct {
+	Version     uint   `gorm:"primaryKey;autoIncrement:false"`
+	Description string `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New returns a Migrator for db. Versions must be positive and unique; they are
+// applied in ascending order regardless of the order given.
+func New(db *gorm.DB, migrations ...Migration) (*Migrator, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+	for i, migration := range sorted {
+		if migration.Version == 0 {
+			return nil, fmt.Errorf("%w: version must be positive", ErrInvalidMigration)
+		}
+		if migration.Up == nil {
+			return nil, fmt.Errorf("%w: version %d has no up step", ErrInvalidMigration, migration.Version)
+		}
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("%w: duplicate version %d", ErrInvalidMigration, migration.Version)
+		}
+	}
+	return &Migrator{db: db, migrations: sorted}, nil
+}
+
+// Up applies the pending migrations, each in its own transaction. It refuses
+// to touch a database that has versions this build does not know about.
+func (m *Migrator) Up(ctx context.Context) error {
+	db := m.db.WithContext(ctx)
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	applied, err := m.applied(db)
+	if err != nil {
+		return err
+	}
+	if err := m.checkKnown(applied); err != nil {
+		return err
+	}
+	for _, migration := range m.migrations {
+		if applied[migration.Version] {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{
+				Version:     migration.Version,
+				Description: migration.Description,
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+	}
+	return nil
+}
+
+// Status lists every known migration and whether it has been applied. It does
+// not write to the database.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	db := m.db.WithContext(ctx)
+	applied := make(map[uint]bool)
+	if db.Migrator().HasTable(&schemaMigration{}) {
+		var err error
+		if applied, err = m.applied(db); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.checkKnown(applied); err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = Status{
+			Version:     migration.Version,
+			Description: migration.Description,
+			Applied:     applied[migration.Version],
+		}
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) applied(db *gorm.DB) (map[uint]bool, error) {
+	var rows []schemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	applied := make(map[uint]bool, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = true
+	}
+	return applied, nil
+}
+
+func (m *Migrator) checkKnown(applied map[uint]bool) error {
+	known := make(map[uint]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+	}
+	for version := range applied {
+		if !known[version] {
+			return fmt.Errorf("%w: version %d", ErrUnknownVersion, version)
+		}
+	}
+	return nil
+}
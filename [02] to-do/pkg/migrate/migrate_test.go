@@ -0,0 +1,131 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func exec(sql string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		return tx.Exec(sql).Error
+	}
+}
+
+func TestUp(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	v1 := Migration{Version: 1, Description: "create items", Up: exec("CREATE TABLE items (id integer PRIMARY KEY, name text)")}
+	v2 := Migration{Version: 2, Description: "rename name to title", Up: exec("ALTER TABLE items RENAME COLUMN name TO title")}
+
+	m, err := New(db, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec("INSERT INTO items (id, name) VALUES (1, 'a')").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	m, err = New(db, v2, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || !statuses[0].Applied || statuses[1].Applied {
+		t.Fatalf("statuses = %+v, want 1 applied and 2 pending", statuses)
+	}
+	for range 2 {
+		if err := m.Up(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var title string
+	if err := db.Raw("SELECT title FROM items WHERE id = 1").Scan(&title).Error; err != nil {
+		t.Fatal(err)
+	}
+	if title != "a" {
+		t.Errorf("title = %q, want %q", title, "a")
+	}
+}
+
+func TestUpRollsBackFailedMigration(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	m, err := New(db, Migration{Version: 1, Description: "fails", Up: func(tx *gorm.DB) error {
+		if err := tx.Exec("CREATE TABLE items (id integer PRIMARY KEY)").Error; err != nil {
+			return err
+		}
+		return errors.New("boom")
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(ctx); err == nil {
+		t.Fatal("Up succeeded, want error")
+	}
+	if db.Migrator().HasTable("items") {
+		t.Error("failed migration was not rolled back")
+	}
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statuses[0].Applied {
+		t.Error("failed migration recorded as applied")
+	}
+}
+
+func TestUnknownVersion(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	v1 := Migration{Version: 1, Description: "create items", Up: exec("CREATE TABLE items (id integer PRIMARY KEY)")}
+	v2 := Migration{Version: 2, Description: "create tags", Up: exec("CREATE TABLE tags (id integer PRIMARY KEY)")}
+
+	m, err := New(db, v1, v2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err = New(db, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(ctx); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("Up error = %v, want %v", err, ErrUnknownVersion)
+	}
+}
+
+func TestNewRejectsInvalidMigrations(t *testing.T) {
+	up := exec("SELECT 1")
+	for _, migrations := range [][]Migration{
+		{{Version: 0, Up: up}},
+		{{Version: 1}},
+		{{Version: 1, Up: up}, {Version: 1, Up: up}},
+	} {
+		if _, err := New(openDB(t), migrations...); !errors.Is(err, ErrInvalidMigration) {
+			t.Errorf("New(%+v) error = %v, want %v", migrations, err, ErrInvalidMigration)
+		}
+	}
+}
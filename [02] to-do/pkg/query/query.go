@@ -0,0 +1,130 @@
+// Package query describes how list queries are paged, filtered and sorted.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+var ErrInvalidQuery = errors.New("invalid query")
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+type Order string
+
+const (
+	Asc  Order = "asc"
+	Desc Order = "desc"
+)
+
+// Query pages, filters and sorts a list. A page starts either at Offset or,
+// when sorting by id, right after the item whose id is Cursor. State filters
+// on the state of the listed entity and From and To, in unix seconds, bound
+// its time column inclusively.
+type Query struct {
+	Limit  int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
+	Offset int    `json:"offset,omitempty" validate:"omitempty,min=0"`
+	Cursor uint   `json:"cursor,omitempty"`
+	Sort   string `json:"sort,omitempty"`
+	Order  Order  `json:"order,omitempty" validate:"omitempty,oneof=asc desc"`
+	State  string `json:"state,omitempty"`
+	From   int64  `json:"from,omitempty"`
+	To     int64  `json:"to,omitempty"`
+}
+
+// Normalize fills in the defaults of q and checks it against the fields a
+// list can be sorted by, the first of which is the default.
+func (q Query) Normalize(sortable ...string) (Query, error) {
+	switch {
+	case q.Limit == 0:
+		q.Limit = DefaultLimit
+	case q.Limit < 0 || q.Limit > MaxLimit:
+		return q, fmt.Errorf("%w: limit must be between 1 and %d, got %d", ErrInvalidQuery, MaxLimit, q.Limit)
+	}
+	if q.Offset < 0 {
+		return q, fmt.Errorf("%w: negative offset %d", ErrInvalidQuery, q.Offset)
+	}
+	if q.Sort == "" && len(sortable) > 0 {
+		q.Sort = sortable[0]
+	}
+	if !slices.Contains(sortable, q.Sort) {
+		return q, fmt.Errorf("%w: cannot sort by %q", ErrInvalidQuery, q.Sort)
+	}
+	switch q.Order {
+	case "":
+		q.Order = Asc
+	case Asc, Desc:
+	default:
+		return q, fmt.Errorf("%w: unknown order %q", ErrInvalidQuery, q.Order)
+	}
+	if q.Cursor != 0 && q.Sort != "id" {
+		return q, fmt.Errorf("%w: cursor requires sorting by id", ErrInvalidQuery)
+	}
+	if q.From != 0 && q.To != 0 && q.From > q.To {
+		return q, fmt.Errorf("%w: from %d is after to %d", ErrInvalidQuery, q.From, q.To)
+	}
+	return q, nil
+}
+
+// FromValues reads a query from URL query parameters such as
+// "limit=10&sort=created_at&order=desc".
+func FromValues(values url.Values) (Query, error) {
+	q := Query{
+		Sort:  values.Get("sort"),
+		Order: Order(values.Get("order")),
+		State: values.Get("state"),
+	}
+	var limit, offset, cursor int64
+	for name, dst := range map[string]*int64{
+		"limit":  &limit,
+		"offset": &offset,
+		"cursor": &cursor,
+		"from":   &q.From,
+		"to":     &q.To,
+	} {
+		value := values.Get(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return q, fmt.Errorf("%w: %s %q is not an integer", ErrInvalidQuery, name, value)
+		}
+		*dst = n
+	}
+	if cursor < 0 {
+		return q, fmt.Errorf("%w: negative cursor %d", ErrInvalidQuery, cursor)
+	}
+	q.Limit, q.Offset, q.Cursor = int(limit), int(offset), uint(cursor)
+	return q, nil
+}
+
+// Page is the envelope of a list response. Total counts every item matching
+// the filters of the query. NextCursor is set when sorting by id and more
+// items may follow.
+type Page[T any] struct {
+	Items      []T   `json:"items"`
+	Total      int64 `json:"total"`
+	Limit      int   `json:"limit"`
+	Offset     int   `json:"offset"`
+	NextCursor uint  `json:"next_cursor,omitempty"`
+}
+
+// NewPage wraps the items returned for the normalized query q, reading the
+// cursor of the last item with id.
+func NewPage[T any](items []T, total int64, q Query, id func(T) uint) *Page[T] {
+	page := &Page[T]{Items: items, Total: total, Limit: q.Limit, Offset: q.Offset}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	if q.Sort == "id" && len(items) == q.Limit && len(items) > 0 {
+		page.NextCursor = id(items[len(items)-1])
+	}
+	return page
+}
@@ -0,0 +1,218 @@
+package rollups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout      = 60 * time.Second
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 100 * time.Millisecond
+)
+
+// StatusError is returned when the rollup server answers with an unexpected status.
+type StatusError struct {
+	Endpoint   string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("rollups: %s returned status %d: %s", e.Endpoint, e.StatusCode, e.Body)
+}
+
+// Client talks to the rollup HTTP server of the Cartesi Machine.
+type Client struct {
+	baseURL      string
+	httpClient   *http.Client
+	timeout      time.Duration
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+type ClientOption func(*Client)
+
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout bounds every attempt made by the client, except finish: it
+// long-polls for the next request and is only bounded by its context.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetries sets how many times a report or an exception is retried, when the
+// request never reached the server or the server answered with a 5xx status,
+// and the backoff before the first retry, which doubles on every attempt. Other
+// requests, and other failures, are never retried.
+func WithRetries(maxRetries int, backoff time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryBackoff = backoff
+	}
+}
+
+func NewClient(baseURL string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		httpClient:   &http.Client{},
+		timeout:      defaultTimeout,
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewClientFromEnv builds a client for the server in ROLLUP_HTTP_SERVER_URL.
+func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
+	baseURL := os.Getenv("ROLLUP_HTTP_SERVER_URL")
+	if baseURL == "" {
+		return nil, errors.New("rollups: ROLLUP_HTTP_SERVER_URL is not set")
+	}
+	return NewClient(baseURL, opts...), nil
+}
+
+// Finish reports the status of the last request and waits for the next one. It
+// returns nil when the server has no pending request.
+func (c *Client) Finish(ctx context.Context, finish *FinishRequest) (*FinishResponse, error) {
+	status, body, err := c.post(ctx, "finish", finish)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusAccepted {
+		return nil, nil
+	}
+
+	var response FinishResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("rollups: failed to decode finish response: %w", err)
+	}
+	return &response, nil
+}
+
+// SendNotice sends a notice and returns its output index.
+func (c *Client) SendNotice(ctx context.Context, notice *NoticeRequest) (*IndexResponse, error) {
+	return c.postIndex(ctx, "notice", notice)
+}
+
+// SendVoucher sends a voucher and returns its output index.
+func (c *Client) SendVoucher(ctx context.Context, voucher *VoucherRequest) (*IndexResponse, error) {
+	return c.postIndex(ctx, "voucher", voucher)
+}
+
+func (c *Client) SendReport(ctx context.Context, report *ReportRequest) error {
+	_, _, err := c.post(ctx, "report", report)
+	return err
+}
+
+func (c *Client) SendException(ctx context.Context, exception *ExceptionRequest) error {
+	_, _, err := c.post(ctx, "exception", exception)
+	return err
+}
+
+func (c *Client) postIndex(ctx context.Context, endpoint string, request any) (*IndexResponse, error) {
+	_, body, err := c.post(ctx, endpoint, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var index IndexResponse
+	if err := json.Unmarshal(body, &index); err != nil {
+		return nil, fmt.Errorf("rollups: failed to decode %s index: %w", endpoint, err)
+	}
+	return &index, nil
+}
+
+// post sends request to endpoint and returns the status and body of any 2xx
+// response. Only reports and exceptions are retried, and only when the attempt
+// never reached the server or failed on the server's side: a resent finish
+// could accept or reject the next request unprocessed and a resent notice or
+// voucher would be emitted twice.
+func (c *Client) post(ctx context.Context, endpoint string, request any) (int, []byte, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return 0, nil, fmt.Errorf("rollups: failed to encode %s request: %w", endpoint, err)
+	}
+
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		status, body, err := c.do(ctx, endpoint, payload)
+		if err == nil || attempt >= c.maxRetries || !retryable(endpoint, err) {
+			return status, body, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) do(ctx context.Context, endpoint string, payload []byte) (int, []byte, error) {
+	if endpoint != "finish" && c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/"+endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, nil, &StatusError{
+			Endpoint:   endpoint,
+			StatusCode: res.StatusCode,
+			Body:       strings.TrimSpace(string(body)),
+		}
+	}
+	return res.StatusCode, body, nil
+}
+
+func retryable(endpoint string, err error) bool {
+	if endpoint != "report" && endpoint != "exception" {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	// A failed dial is the only transport error known to leave the request
+	// unsent.
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,274 @@
+package rollups
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var ErrInvalidDeposit = errors.New("invalid deposit")
+
+// Deposit is one of EtherDeposit, ERC20Deposit, ERC721Deposit or ERC1155Deposit.
+type Deposit interface {
+	isDeposit()
+}
+
+type EtherDeposit struct {
+	Sender Address
+	Value  *big.Int
+}
+
+type ERC20Deposit struct {
+	Token  Address
+	Sender Address
+	Value  *big.Int
+}
+
+type ERC721Deposit struct {
+	Token         Address
+	Sender        Address
+	TokenId       *big.Int
+	BaseLayerData []byte
+}
+
+// ERC1155Deposit is sent by the single or the batch portal. Single deposits
+// carry one id and one value.
+type ERC1155Deposit struct {
+	Token         Address
+	Sender        Address
+	TokenIds      []*big.Int
+	Values        []*big.Int
+	BaseLayerData []byte
+	Batch         bool
+}
+
+func (EtherDeposit) isDeposit()   {}
+func (ERC20Deposit) isDeposit()   {}
+func (ERC721Deposit) isDeposit()  {}
+func (ERC1155Deposit) isDeposit() {}
+
+// Portals holds the addresses of the portal contracts whose inputs are decoded
+// as deposits.
+type Portals struct {
+	EtherPortal         Address
+	ERC20Portal         Address
+	ERC721Portal        Address
+	ERC1155SinglePortal Address
+	ERC1155BatchPortal  Address
+}
+
+// DefaultPortals are the portals deployed by the Cartesi Rollups v2 contracts.
+var DefaultPortals = Portals{
+	EtherPortal:         mustHexToAddress("0xc70076a466789B595b50959cdc261227F0D70051"),
+	ERC20Portal:         mustHexToAddress("0xc700D6aDd016eECd59d989C028214Eaa0fCC0051"),
+	ERC721Portal:        mustHexToAddress("0xc700d52F5290e978e9CAe7D1E092935263b60051"),
+	ERC1155SinglePortal: mustHexToAddress("0xc700A261279aFC6F755A3a67D86ae43E2eBD0051"),
+	ERC1155BatchPortal:  mustHexToAddress("0xc700A2e5531E720a2434433b6ccf4c0eA2400051"),
+}
+
+func mustHexToAddress(s string) Address {
+	address, err := HexToAddress(s)
+	if err != nil {
+		panic(err)
+	}
+	return address
+}
+
+// Decode returns the deposit carried by payload and the execution layer data
+// that follows it. Inputs not sent by one of the portals are returned as they
+// are, with a nil deposit.
+func (p Portals) Decode(metadata Metadata, payload []byte) (Deposit, []byte, error) {
+	var (
+		deposit Deposit
+		data    []byte
+		err     error
+	)
+	switch metadata.MsgSender {
+	case p.EtherPortal:
+		deposit, data, err = decodeEtherDeposit(payload)
+	case p.ERC20Portal:
+		deposit, data, err = decodeERC20Deposit(payload)
+	case p.ERC721Portal:
+		deposit, data, err = decodeERC721Deposit(payload)
+	case p.ERC1155SinglePortal:
+		deposit, data, err = decodeERC1155SingleDeposit(payload)
+	case p.ERC1155BatchPortal:
+		deposit, data, err = decodeERC1155BatchDeposit(payload)
+	default:
+		return nil, payload, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidDeposit, err)
+	}
+	return deposit, data, nil
+}
+
+// decodeEtherDeposit decodes abi.encodePacked(sender, value, execLayerData).
+func decodeEtherDeposit(payload []byte) (Deposit, []byte, error) {
+	if len(payload) < AddressLength+wordLength {
+		return nil, nil, fmt.Errorf("ether deposit of %d bytes", len(payload))
+	}
+	return EtherDeposit{
+		Sender: Address(payload[:20]),
+		Value:  new(big.Int).SetBytes(payload[20:52]),
+	}, payload[52:], nil
+}
+
+// decodeERC20Deposit decodes abi.encodePacked(token, sender, value, execLayerData).
+func decodeERC20Deposit(payload []byte) (Deposit, []byte, error) {
+	if len(payload) < 2*AddressLength+wordLength {
+		return nil, nil, fmt.Errorf("ERC20 deposit of %d bytes", len(payload))
+	}
+	return ERC20Deposit{
+		Token:  Address(payload[:20]),
+		Sender: Address(payload[20:40]),
+		Value:  new(big.Int).SetBytes(payload[40:72]),
+	}, payload[72:], nil
+}
+
+// decodeERC721Deposit decodes
+// abi.encodePacked(token, sender, tokenId, abi.encode(baseLayerData, execLayerData)).
+func decodeERC721Deposit(payload []byte) (Deposit, []byte, error) {
+	if len(payload) < 2*AddressLength+wordLength {
+		return nil, nil, fmt.Errorf("ERC721 deposit of %d bytes", len(payload))
+	}
+	baseLayerData, execLayerData, err := decodeLayerData(payload[72:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("ERC721 deposit: %w", err)
+	}
+	return ERC721Deposit{
+		Token:         Address(payload[:20]),
+		Sender:        Address(payload[20:40]),
+		TokenId:       new(big.Int).SetBytes(payload[40:72]),
+		BaseLayerData: baseLayerData,
+	}, execLayerData, nil
+}
+
+// decodeERC1155SingleDeposit decodes
+// abi.encodePacked(token, sender, tokenId, value, abi.encode(baseLayerData, execLayerData)).
+func decodeERC1155SingleDeposit(payload []byte) (Deposit, []byte, error) {
+	if len(payload) < 2*AddressLength+2*wordLength {
+		return nil, nil, fmt.Errorf("ERC1155 single deposit of %d bytes", len(payload))
+	}
+	baseLayerData, execLayerData, err := decodeLayerData(payload[104:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("ERC1155 single deposit: %w", err)
+	}
+	return ERC1155Deposit{
+		Token:         Address(payload[:20]),
+		Sender:        Address(payload[20:40]),
+		TokenIds:      []*big.Int{new(big.Int).SetBytes(payload[40:72])},
+		Values:        []*big.Int{new(big.Int).SetBytes(payload[72:104])},
+		BaseLayerData: baseLayerData,
+	}, execLayerData, nil
+}
+
+// decodeERC1155BatchDeposit decodes
+// abi.encodePacked(token, sender, abi.encode(tokenIds, values, baseLayerData, execLayerData)).
+func decodeERC1155BatchDeposit(payload []byte) (Deposit, []byte, error) {
+	if len(payload) < 2*AddressLength {
+		return nil, nil, fmt.Errorf("ERC1155 batch deposit of %d bytes", len(payload))
+	}
+	data := abiData(payload[40:])
+	tokenIds, err := data.uint256Array(0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ERC1155 batch deposit ids: %w", err)
+	}
+	values, err := data.uint256Array(1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ERC1155 batch deposit values: %w", err)
+	}
+	if len(tokenIds) != len(values) {
+		return nil, nil, fmt.Errorf("ERC1155 batch deposit of %d ids and %d values", len(tokenIds), len(values))
+	}
+	baseLayerData, err := data.bytes(2)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ERC1155 batch deposit: %w", err)
+	}
+	execLayerData, err := data.bytes(3)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ERC1155 batch deposit: %w", err)
+	}
+	return ERC1155Deposit{
+		Token:         Address(payload[:20]),
+		Sender:        Address(payload[20:40]),
+		TokenIds:      tokenIds,
+		Values:        values,
+		BaseLayerData: baseLayerData,
+		Batch:         true,
+	}, execLayerData, nil
+}
+
+// decodeLayerData decodes abi.encode(baseLayerData, execLayerData).
+func decodeLayerData(payload []byte) ([]byte, []byte, error) {
+	data := abiData(payload)
+	baseLayerData, err := data.bytes(0)
+	if err != nil {
+		return nil, nil, err
+	}
+	execLayerData, err := data.bytes(1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return baseLayerData, execLayerData, nil
+}
+
+const wordLength = 32
+
+// abiData reads the dynamic arguments of a standard ABI encoding.
+type abiData []byte
+
+func (d abiData) word(offset int) (*big.Int, error) {
+	if offset < 0 || offset+wordLength > len(d) {
+		return nil, fmt.Errorf("word at %d out of %d bytes", offset, len(d))
+	}
+	return new(big.Int).SetBytes(d[offset : offset+wordLength]), nil
+}
+
+// int reads the word at offset as a length or offset that must fit in d.
+func (d abiData) int(offset int) (int, error) {
+	v, err := d.word(offset)
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsInt64() || v.Int64() > int64(len(d)) {
+		return 0, fmt.Errorf("value %s at %d out of %d bytes", v, offset, len(d))
+	}
+	return int(v.Int64()), nil
+}
+
+// bytes decodes the bytes argument at position i of the head.
+func (d abiData) bytes(i int) ([]byte, error) {
+	start, err := d.int(i * wordLength)
+	if err != nil {
+		return nil, err
+	}
+	length, err := d.int(start)
+	if err != nil {
+		return nil, err
+	}
+	start += wordLength
+	if start+length > len(d) {
+		return nil, fmt.Errorf("bytes of length %d at %d out of %d bytes", length, start, len(d))
+	}
+	return d[start : start+length], nil
+}
+
+// uint256Array decodes the uint256[] argument at position i of the head.
+func (d abiData) uint256Array(i int) ([]*big.Int, error) {
+	start, err := d.int(i * wordLength)
+	if err != nil {
+		return nil, err
+	}
+	length, err := d.int(start)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]*big.Int, length)
+	for j := range values {
+		if values[j], err = d.word(start + (j+1)*wordLength); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
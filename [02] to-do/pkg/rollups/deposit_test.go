@@ -0,0 +1,171 @@
+package rollups_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups/rollupstest"
+)
+
+var (
+	token  = rollups.Address{0x09}
+	sender = rollups.Address{0x01}
+)
+
+func word(v int64) []byte {
+	return big.NewInt(v).FillBytes(make([]byte, 32))
+}
+
+// encode ABI-encodes args, where []byte values are dynamic bytes and []int64
+// values are uint256 arrays.
+func encode(args ...any) []byte {
+	var head, tail []byte
+	for _, arg := range args {
+		head = append(head, word(int64(len(args)*32+len(tail)))...)
+		switch v := arg.(type) {
+		case []byte:
+			tail = append(tail, word(int64(len(v)))...)
+			tail = append(tail, v...)
+			if padding := len(v) % 32; padding != 0 {
+				tail = append(tail, make([]byte, 32-padding)...)
+			}
+		case []int64:
+			tail = append(tail, word(int64(len(v)))...)
+			for _, n := range v {
+				tail = append(tail, word(n)...)
+			}
+		}
+	}
+	return append(head, tail...)
+}
+
+func concat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+func bigs(values ...int64) []*big.Int {
+	result := make([]*big.Int, len(values))
+	for i, v := range values {
+		result[i] = big.NewInt(v)
+	}
+	return result
+}
+
+func TestDecodeDeposit(t *testing.T) {
+	portals := rollups.DefaultPortals
+	exec := []byte(`{"path":"createToDo"}`)
+
+	tests := []struct {
+		name    string
+		portal  rollups.Address
+		payload []byte
+		deposit rollups.Deposit
+		exec    []byte
+		err     bool
+	}{
+		{
+			name:    "ether",
+			portal:  portals.EtherPortal,
+			payload: concat(sender[:], word(100), exec),
+			deposit: rollups.EtherDeposit{Sender: sender, Value: big.NewInt(100)},
+			exec:    exec,
+		},
+		{
+			name:    "erc20",
+			portal:  portals.ERC20Portal,
+			payload: concat(token[:], sender[:], word(50)),
+			deposit: rollups.ERC20Deposit{Token: token, Sender: sender, Value: big.NewInt(50)},
+			exec:    []byte{},
+		},
+		{
+			name:    "erc721",
+			portal:  portals.ERC721Portal,
+			payload: concat(token[:], sender[:], word(7), encode([]byte("base"), exec)),
+			deposit: rollups.ERC721Deposit{Token: token, Sender: sender, TokenId: big.NewInt(7), BaseLayerData: []byte("base")},
+			exec:    exec,
+		},
+		{
+			name:    "erc1155 single",
+			portal:  portals.ERC1155SinglePortal,
+			payload: concat(token[:], sender[:], word(1), word(10), encode([]byte{}, exec)),
+			deposit: rollups.ERC1155Deposit{Token: token, Sender: sender, TokenIds: bigs(1), Values: bigs(10), BaseLayerData: []byte{}},
+			exec:    exec,
+		},
+		{
+			name:    "erc1155 batch",
+			portal:  portals.ERC1155BatchPortal,
+			payload: concat(token[:], sender[:], encode([]int64{1, 2}, []int64{10, 20}, []byte{}, exec)),
+			deposit: rollups.ERC1155Deposit{Token: token, Sender: sender, TokenIds: bigs(1, 2), Values: bigs(10, 20), BaseLayerData: []byte{}, Batch: true},
+			exec:    exec,
+		},
+		{
+			name:    "not a portal",
+			portal:  sender,
+			payload: exec,
+			exec:    exec,
+		},
+		{name: "short ether", portal: portals.EtherPortal, payload: sender[:], err: true},
+		{name: "short erc721", portal: portals.ERC721Portal, payload: concat(token[:], sender[:], word(7)), err: true},
+		{
+			name:    "batch length mismatch",
+			portal:  portals.ERC1155BatchPortal,
+			payload: concat(token[:], sender[:], encode([]int64{1, 2}, []int64{10}, []byte{}, []byte{})),
+			err:     true,
+		},
+		{
+			name:    "bytes out of range",
+			portal:  portals.ERC721Portal,
+			payload: concat(token[:], sender[:], word(7), word(64), word(1000)),
+			err:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deposit, data, err := portals.Decode(rollups.Metadata{MsgSender: tt.portal}, tt.payload)
+			if tt.err {
+				if !errors.Is(err, rollups.ErrInvalidDeposit) {
+					t.Fatalf("expected %v, got %v", rollups.ErrInvalidDeposit, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(deposit, tt.deposit) {
+				t.Fatalf("expected %+v, got %+v", tt.deposit, deposit)
+			}
+			if !bytes.Equal(data, tt.exec) {
+				t.Fatalf("expected exec layer data %q, got %q", tt.exec, data)
+			}
+		})
+	}
+}
+
+func TestRouterDeposit(t *testing.T) {
+	r := rollups.NewRouter()
+	srv := rollupstest.Run(t, r)
+	metadata := rollups.Metadata{MsgSender: rollups.DefaultPortals.EtherPortal}
+	payload := concat(sender[:], word(100), []byte("memo"))
+
+	if res := srv.Advance(payload, metadata); res.Status != "reject" {
+		t.Fatalf("expected deposit without handler to be rejected, got %q", res.Status)
+	}
+
+	r.HandleDeposit(func(ctx context.Context, client *rollups.Client, deposit rollups.Deposit, payload []byte, metadata rollups.Metadata) error {
+		ether, ok := deposit.(rollups.EtherDeposit)
+		if !ok || ether.Value.Int64() != 100 || string(payload) != "memo" {
+			return errors.New("unexpected deposit")
+		}
+		_, err := client.SendNotice(ctx, &rollups.NoticeRequest{Payload: payload})
+		return err
+	})
+	res := srv.Advance(payload, metadata)
+	if !res.Accepted() || len(res.Notices) != 1 || string(res.Notices[0]) != "memo" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+}
@@ -0,0 +1,165 @@
+package rollups
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrMissingHexPrefix = errors.New("hex string without 0x prefix")
+	ErrOddHexLength     = errors.New("hex string of odd length")
+	ErrInvalidHex       = errors.New("invalid hex string")
+	ErrInvalidHexSize   = errors.New("invalid hex size")
+)
+
+// DecodeHex decodes a 0x-prefixed hex string. "0x" decodes to an empty slice.
+func DecodeHex(s string) ([]byte, error) {
+	digits, ok := strings.CutPrefix(s, "0x")
+	if !ok {
+		digits, ok = strings.CutPrefix(s, "0X")
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrMissingHexPrefix, s)
+	}
+	if len(digits)%2 != 0 {
+		return nil, fmt.Errorf("%w: %q", ErrOddHexLength, s)
+	}
+	b, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidHex, s)
+	}
+	return b, nil
+}
+
+func EncodeHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// Payload is a byte slice sent to and received from the rollup server as a
+// 0x-prefixed hex string.
+type Payload []byte
+
+func (p Payload) Bytes() []byte {
+	return p
+}
+
+func (p Payload) String() string {
+	return EncodeHex(p)
+}
+
+func (p Payload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(EncodeHex(p))
+}
+
+func (p *Payload) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("payload: %w: %w", ErrInvalidHex, err)
+	}
+	b, err := DecodeHex(s)
+	if err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	*p = b
+	return nil
+}
+
+const AddressLength = 20
+
+// Address is a 20-byte address, such as Metadata.MsgSender.
+type Address [AddressLength]byte
+
+func HexToAddress(s string) (Address, error) {
+	var a Address
+	b, err := DecodeHex(s)
+	if err != nil {
+		return a, fmt.Errorf("address: %w", err)
+	}
+	if len(b) != AddressLength {
+		return a, fmt.Errorf("address: %w: %d bytes", ErrInvalidHexSize, len(b))
+	}
+	copy(a[:], b)
+	return a, nil
+}
+
+func (a Address) Bytes() []byte {
+	return a[:]
+}
+
+func (a Address) String() string {
+	return EncodeHex(a[:])
+}
+
+func (a Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+func (a *Address) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("address: %w: %w", ErrInvalidHex, err)
+	}
+	address, err := HexToAddress(s)
+	if err != nil {
+		return err
+	}
+	*a = address
+	return nil
+}
+
+// Uint256 is a big-endian 256-bit unsigned integer, such as Metadata.PrevRandao
+// or a voucher value.
+type Uint256 [32]byte
+
+// HexToUint256 parses a 0x-prefixed hex string of at most 32 bytes.
+func HexToUint256(s string) (Uint256, error) {
+	var u Uint256
+	b, err := DecodeHex(s)
+	if err != nil {
+		return u, fmt.Errorf("uint256: %w", err)
+	}
+	if len(b) > len(u) {
+		return u, fmt.Errorf("uint256: %w: %d bytes", ErrInvalidHexSize, len(b))
+	}
+	copy(u[len(u)-len(b):], b)
+	return u, nil
+}
+
+// NewUint256 converts v, which must fit in 256 bits and not be negative.
+func NewUint256(v *big.Int) (Uint256, error) {
+	var u Uint256
+	if v.Sign() < 0 || v.BitLen() > 256 {
+		return u, fmt.Errorf("uint256: %s out of range", v)
+	}
+	v.FillBytes(u[:])
+	return u, nil
+}
+
+func (u Uint256) Big() *big.Int {
+	return new(big.Int).SetBytes(u[:])
+}
+
+func (u Uint256) String() string {
+	return EncodeHex(u[:])
+}
+
+func (u Uint256) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+func (u *Uint256) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("uint256: %w: %w", ErrInvalidHex, err)
+	}
+	value, err := HexToUint256(s)
+	if err != nil {
+		return err
+	}
+	*u = value
+	return nil
+}
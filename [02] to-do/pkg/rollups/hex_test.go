@@ -0,0 +1,107 @@
+package rollups_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+)
+
+func TestPayloadJSON(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected rollups.Payload
+		err      error
+	}{
+		{input: `"0x68656c6c6f"`, expected: rollups.Payload("hello")},
+		{input: `"0X0aFF"`, expected: rollups.Payload{0x0a, 0xff}},
+		{input: `"0x"`, expected: rollups.Payload{}},
+		{input: `"68656c6c6f"`, err: rollups.ErrMissingHexPrefix},
+		{input: `"0x686"`, err: rollups.ErrOddHexLength},
+		{input: `"0xzz"`, err: rollups.ErrInvalidHex},
+		{input: `""`, err: rollups.ErrMissingHexPrefix},
+		{input: `42`, err: rollups.ErrInvalidHex},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var payload rollups.Payload
+			err := json.Unmarshal([]byte(tt.input), &payload)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(payload) != string(tt.expected) {
+				t.Fatalf("expected %x, got %x", tt.expected, payload)
+			}
+
+			encoded, err := json.Marshal(payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded rollups.Payload
+			if err := json.Unmarshal(encoded, &decoded); err != nil || string(decoded) != string(payload) {
+				t.Fatalf("failed to round trip %s: %x, %v", encoded, decoded, err)
+			}
+		})
+	}
+}
+
+func TestMetadataJSON(t *testing.T) {
+	input := `{
+		"chain_id": 31337,
+		"app_contract": "0xab7528bb862fb57e8a2bcd567a2e929a0be56a5e",
+		"msg_sender": "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266",
+		"input_index": 2,
+		"block_number": 10,
+		"block_timestamp": 1700000000,
+		"prev_randao": "0x01"
+	}`
+	var metadata rollups.Metadata
+	if err := json.Unmarshal([]byte(input), &metadata); err != nil {
+		t.Fatal(err)
+	}
+	if metadata.MsgSender.String() != "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266" {
+		t.Fatalf("unexpected msg_sender %s", metadata.MsgSender)
+	}
+	if metadata.PrevRandao.Big().Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("unexpected prev_randao %s", metadata.PrevRandao)
+	}
+
+	for _, invalid := range []string{
+		`{"msg_sender": "0xf39fd6e51aad88f6f4ce6ab8827279cfffb922"}`,
+		`{"app_contract": "f39fd6e51aad88f6f4ce6ab8827279cfffb92266"}`,
+		`{"prev_randao": "0xzz"}`,
+	} {
+		if err := json.Unmarshal([]byte(invalid), &metadata); err == nil {
+			t.Fatalf("expected %s to fail", invalid)
+		}
+	}
+}
+
+func TestUint256(t *testing.T) {
+	value, _ := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+	u, err := rollups.NewUint256(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Big().Cmp(value) != 0 {
+		t.Fatalf("expected %s, got %s", value, u.Big())
+	}
+
+	if _, err := rollups.NewUint256(new(big.Int).Add(value, big.NewInt(1))); err == nil {
+		t.Fatal("expected overflow to fail")
+	}
+	if _, err := rollups.NewUint256(big.NewInt(-1)); err == nil {
+		t.Fatal("expected negative value to fail")
+	}
+	if _, err := rollups.HexToUint256("0x0100000000000000000000000000000000000000000000000000000000000000ff"); !errors.Is(err, rollups.ErrInvalidHexSize) {
+		t.Fatalf("expected %v, got %v", rollups.ErrInvalidHexSize, err)
+	}
+}
@@ -0,0 +1,178 @@
+package rollups_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups/rollupstest"
+)
+
+type noticeApplication struct {
+	indexes []uint64
+}
+
+func (a *noticeApplication) Advance(ctx context.Context, client *rollups.Client, payload []byte, metadata rollups.Metadata) error {
+	switch string(payload) {
+	case "panic":
+		panic("boom")
+	case "reject":
+		return errors.New("rejected")
+	}
+	for range 2 {
+		res, err := client.SendNotice(ctx, &rollups.NoticeRequest{Payload: payload})
+		if err != nil {
+			return err
+		}
+		a.indexes = append(a.indexes, res.Index)
+	}
+	return nil
+}
+
+func (a *noticeApplication) Inspect(ctx context.Context, client *rollups.Client, payload []byte) error {
+	return client.SendReport(ctx, &rollups.ReportRequest{Payload: payload})
+}
+
+func TestRun(t *testing.T) {
+	app := &noticeApplication{}
+	srv := rollupstest.Run(t, app)
+
+	res := srv.Advance([]byte("hello"), rollups.Metadata{})
+	if !res.Accepted() || len(res.Notices) != 2 || string(res.Notices[1]) != "hello" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if len(app.indexes) != 2 || app.indexes[0] != 0 || app.indexes[1] != 1 {
+		t.Fatalf("unexpected notice indexes: %v", app.indexes)
+	}
+
+	if res := srv.Advance([]byte("reject"), rollups.Metadata{}); res.Status != "reject" {
+		t.Fatalf("expected reject, got %q", res.Status)
+	}
+
+	res = srv.Inspect([]byte("state"))
+	if !res.Accepted() || len(res.Reports) != 1 || string(res.Reports[0]) != "state" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+
+	res = srv.Advance([]byte("panic"), rollups.Metadata{})
+	if res.Status != "exception" || string(res.Exception) != "rollups: application panicked: boom" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+}
+
+func TestRunStopsOnCancel(t *testing.T) {
+	srv := rollupstest.NewServer()
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := srv.Client().Run(ctx, &noticeApplication{}); err != nil {
+		t.Fatalf("expected nil error after cancellation, got %v", err)
+	}
+}
+
+// flakyTransport fails the first dials attempts as if the server refused the
+// connection, and sends the others.
+type flakyTransport struct {
+	dials    int32
+	attempts atomic.Int32
+}
+
+func (t *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.attempts.Add(1) <= t.dials {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		dials    int32
+		failures int32
+		status   int
+		attempts int32
+		err      bool
+	}{
+		{name: "ok", endpoint: "report", status: http.StatusOK, attempts: 1},
+		{name: "refused report", endpoint: "report", dials: 2, status: http.StatusOK, attempts: 3},
+		{name: "refused exception", endpoint: "exception", dials: 1, status: http.StatusOK, attempts: 2},
+		{name: "exhausted", endpoint: "report", dials: 5, status: http.StatusOK, attempts: 3, err: true},
+		{name: "server error", endpoint: "report", failures: 1, status: http.StatusOK, attempts: 2},
+		{name: "server error exception", endpoint: "exception", failures: 2, status: http.StatusOK, attempts: 3},
+		{name: "server down", endpoint: "report", status: http.StatusServiceUnavailable, attempts: 3, err: true},
+		{name: "server error notice", endpoint: "notice", failures: 1, status: http.StatusOK, attempts: 1, err: true},
+		{name: "bad request", endpoint: "report", status: http.StatusBadRequest, attempts: 1, err: true},
+		{name: "too many requests", endpoint: "report", status: http.StatusTooManyRequests, attempts: 1, err: true},
+		{name: "refused notice", endpoint: "notice", dials: 1, status: http.StatusOK, attempts: 1, err: true},
+		{name: "refused voucher", endpoint: "voucher", dials: 1, status: http.StatusOK, attempts: 1, err: true},
+		{name: "refused finish", endpoint: "finish", dials: 1, status: http.StatusAccepted, attempts: 1, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var served atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if served.Add(1) <= tt.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"index":7}`))
+			}))
+			defer srv.Close()
+
+			transport := &flakyTransport{dials: tt.dials}
+			client := rollups.NewClient(srv.URL, rollups.WithHTTPClient(&http.Client{Transport: transport}), rollups.WithRetries(2, time.Millisecond))
+			ctx := context.Background()
+			var err error
+			switch tt.endpoint {
+			case "report":
+				err = client.SendReport(ctx, &rollups.ReportRequest{})
+			case "exception":
+				err = client.SendException(ctx, &rollups.ExceptionRequest{})
+			case "notice":
+				_, err = client.SendNotice(ctx, &rollups.NoticeRequest{})
+			case "voucher":
+				_, err = client.SendVoucher(ctx, &rollups.VoucherRequest{})
+			case "finish":
+				_, err = client.Finish(ctx, &rollups.FinishRequest{Status: "accept"})
+			}
+			if transport.attempts.Load() != tt.attempts {
+				t.Fatalf("expected %d attempts, got %d", tt.attempts, transport.attempts.Load())
+			}
+			if (err != nil) != tt.err {
+				t.Fatalf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestClientTimeoutSparesFinish(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+	client := rollups.NewClient(srv.URL, rollups.WithTimeout(20*time.Millisecond))
+
+	if _, err := client.Finish(context.Background(), &rollups.FinishRequest{Status: "accept"}); err != nil {
+		t.Fatalf("expected finish to outlast the timeout, got %v", err)
+	}
+	if err := client.SendReport(context.Background(), &rollups.ReportRequest{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the report to time out, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Finish(ctx, &rollups.FinishRequest{Status: "accept"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected finish to stop at the context deadline, got %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+// Package rollupstest provides an in-process stand-in for the rollup HTTP
+// server, so applications built on pkg/rollups can be tested without a node.
+package rollupstest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+)
+
+const (
+	// pollTimeout is how long /finish waits for a queued request before
+	// answering 202, like the long poll of the real server.
+	pollTimeout = 50 * time.Millisecond
+	waitTimeout = 5 * time.Second
+)
+
+var ErrTimeout = errors.New("rollupstest: request was not finished in time")
+
+type Voucher struct {
+	Destination rollups.Address
+	Value       rollups.Uint256
+	Payload     []byte
+}
+
+// Result holds the outputs of a request and the status it was finished with:
+// "accept", "reject" or "exception".
+type Result struct {
+	Status    string
+	Notices   [][]byte
+	Vouchers  []Voucher
+	Reports   [][]byte
+	Exception []byte
+	Err       error
+}
+
+func (r *Result) Accepted() bool {
+	return r.Status == "accept"
+}
+
+type pending struct {
+	request *rollups.FinishResponse
+	result  *Result
+	done    chan struct{}
+}
+
+// Server queues advance and inspect requests for the application polling
+// /finish and records the outputs it sends while processing each of them.
+type Server struct {
+	*httptest.Server
+
+	queue       chan *pending
+	mu          sync.Mutex
+	current     *pending
+	outputIndex uint64
+}
+
+func NewServer() *Server {
+	s := &Server{queue: make(chan *pending, 64)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /finish", s.handleFinish)
+	mux.HandleFunc("POST /notice", s.handleNotice)
+	mux.HandleFunc("POST /voucher", s.handleVoucher)
+	mux.HandleFunc("POST /report", s.handleReport)
+	mux.HandleFunc("POST /exception", s.handleException)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Client returns a client for s that does not retry.
+func (s *Server) Client() *rollups.Client {
+	return rollups.NewClient(s.URL, rollups.WithRetries(0, 0))
+}
+
+// Run starts app against a new server and stops both when t finishes. Run
+// returning early, such as after an exception, is logged on t.
+func Run(t testing.TB, app rollups.Application) *Server {
+	t.Helper()
+	s := NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Client().Run(ctx, app)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Logf("rollupstest: run stopped: %v", err)
+		}
+		s.Close()
+	})
+	return s
+}
+
+// Advance queues an advance request and waits until the application finishes it.
+func (s *Server) Advance(payload []byte, metadata rollups.Metadata) *Result {
+	data, _ := json.Marshal(rollups.AdvanceResponse{
+		Metadata: metadata,
+		Payload:  payload,
+	})
+	return s.send(&rollups.FinishResponse{Type: "advance_state", Data: data})
+}
+
+// Inspect queues an inspect request and waits until the application finishes it.
+func (s *Server) Inspect(payload []byte) *Result {
+	data, _ := json.Marshal(rollups.InspectResponse{
+		Payload: payload,
+	})
+	return s.send(&rollups.FinishResponse{Type: "inspect_state", Data: data})
+}
+
+func (s *Server) send(request *rollups.FinishResponse) *Result {
+	p := &pending{request: request, result: &Result{}, done: make(chan struct{})}
+	s.queue <- p
+	select {
+	case <-p.done:
+		return p.result
+	case <-time.After(waitTimeout):
+		return &Result{Err: ErrTimeout}
+	}
+}
+
+// finishCurrent records status on the request being processed. It must be
+// called with s.mu held.
+func (s *Server) finishCurrent(status string) {
+	if s.current == nil {
+		return
+	}
+	s.current.result.Status = status
+	close(s.current.done)
+	s.current = nil
+}
+
+func (s *Server) handleFinish(w http.ResponseWriter, r *http.Request) {
+	var finish rollups.FinishRequest
+	if err := json.NewDecoder(r.Body).Decode(&finish); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	s.finishCurrent(finish.Status)
+	s.mu.Unlock()
+
+	select {
+	case p := <-s.queue:
+		s.mu.Lock()
+		s.current = p
+		s.mu.Unlock()
+		writeJSON(w, p.request)
+	case <-time.After(pollTimeout):
+		w.WriteHeader(http.StatusAccepted)
+	case <-r.Context().Done():
+	}
+}
+
+func (s *Server) handleNotice(w http.ResponseWriter, r *http.Request) {
+	var notice rollups.NoticeRequest
+	s.record(w, r, &notice, func(result *Result) {
+		result.Notices = append(result.Notices, notice.Payload)
+	}, true)
+}
+
+func (s *Server) handleVoucher(w http.ResponseWriter, r *http.Request) {
+	var voucher rollups.VoucherRequest
+	s.record(w, r, &voucher, func(result *Result) {
+		result.Vouchers = append(result.Vouchers, Voucher{
+			Destination: voucher.Destination,
+			Value:       voucher.Value,
+			Payload:     voucher.Payload,
+		})
+	}, true)
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	var report rollups.ReportRequest
+	s.record(w, r, &report, func(result *Result) {
+		result.Reports = append(result.Reports, report.Payload)
+	}, false)
+}
+
+func (s *Server) handleException(w http.ResponseWriter, r *http.Request) {
+	var exception rollups.ExceptionRequest
+	s.record(w, r, &exception, func(result *Result) {
+		result.Exception = exception.Payload
+	}, false)
+
+	s.mu.Lock()
+	s.finishCurrent("exception")
+	s.mu.Unlock()
+}
+
+// record decodes the output in the body into v and stores it on the result of
+// the current request with add. Notices and vouchers are answered with their
+// output index.
+func (s *Server) record(w http.ResponseWriter, r *http.Request, v any, add func(*Result), indexed bool) {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current == nil {
+		http.Error(w, "no request is being processed", http.StatusBadRequest)
+		return
+	}
+	add(s.current.result)
+	if !indexed {
+		return
+	}
+	writeJSON(w, rollups.IndexResponse{Index: s.outputIndex})
+	s.outputIndex++
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
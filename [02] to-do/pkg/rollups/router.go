@@ -1,28 +1,82 @@
 package rollups
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
+	"strings"
 )
 
-type AdvanceHandlerFunc func(payload []byte, metadata Metadata) error
+// AdvanceHandlerFunc handles an advance input. Outputs are sent through client,
+// which returns the index of every notice and voucher.
+type AdvanceHandlerFunc func(ctx context.Context, client *Client, payload []byte, metadata Metadata) error
+
+// InspectHandlerFunc handles an inspect request. params holds the values of the
+// ":name" segments of the matched path and of the query string that may follow
+// it, as in "todo?limit=10", and results are sent as reports.
+type InspectHandlerFunc func(ctx context.Context, client *Client, params Params) error
+
+// DepositHandlerFunc handles an input sent by one of the portals. payload is
+// the execution layer data sent along with the deposit.
+type DepositHandlerFunc func(ctx context.Context, client *Client, deposit Deposit, payload []byte, metadata Metadata) error
+
+type Params map[string]string
 
 type Router struct {
+	Portals         Portals
+	DepositHandler  DepositHandlerFunc
 	AdvanceHandlers map[string]AdvanceHandlerFunc
+	InspectHandlers map[string]InspectHandlerFunc
+
+	// inspectRoutes holds the inspect patterns in registration order, so that
+	// lookups do not depend on map iteration.
+	inspectRoutes []string
 }
 
 func NewRouter() *Router {
 	return &Router{
+		Portals:         DefaultPortals,
 		AdvanceHandlers: make(map[string]AdvanceHandlerFunc),
+		InspectHandlers: make(map[string]InspectHandlerFunc),
 	}
 }
 
+// HandleDeposit registers the handler for inputs sent by r.Portals. Deposits
+// are rejected while no handler is registered.
+func (r *Router) HandleDeposit(handler DepositHandlerFunc) {
+	r.DepositHandler = handler
+}
+
 func (r *Router) HandleAdvance(path string, handler AdvanceHandlerFunc) {
 	r.AdvanceHandlers[path] = handler
 }
 
-func (r *Router) Advance(payload []byte, metadata Metadata) error {
+// HandleInspect registers handler for inspect paths such as "todo/completed" or
+// "todo/:id". When several patterns match, segments are compared left to right
+// and a static segment wins over a parameter; equal patterns go by registration.
+func (r *Router) HandleInspect(path string, handler InspectHandlerFunc) {
+	path = strings.Trim(path, "/")
+	if _, exists := r.InspectHandlers[path]; !exists {
+		r.inspectRoutes = append(r.inspectRoutes, path)
+	}
+	r.InspectHandlers[path] = handler
+}
+
+func (r *Router) Advance(ctx context.Context, client *Client, payload []byte, metadata Metadata) error {
+	deposit, payload, err := r.Portals.Decode(metadata, payload)
+	if err != nil {
+		return err
+	}
+	if deposit != nil {
+		log.Printf("Router: Deposit %T", deposit)
+		if r.DepositHandler == nil {
+			return fmt.Errorf("handler: no deposit handler for %T", deposit)
+		}
+		return r.DepositHandler(ctx, client, deposit, payload, metadata)
+	}
+
 	log.Println("Router: Advance", string(payload))
 	var input Input
 	if err := json.Unmarshal(payload, &input); err != nil {
@@ -32,8 +86,105 @@ func (r *Router) Advance(payload []byte, metadata Metadata) error {
 	if !ok {
 		return fmt.Errorf("handler: path not found: %s", input.Path)
 	}
-	if err := handler(input.Payload, metadata); err != nil {
+	if err := handler(ctx, client, input.Payload, metadata); err != nil {
 		return err
 	}
 	return nil
 }
+
+// Inspect routes the payload, a path such as "todo/1", to its handler.
+func (r *Router) Inspect(ctx context.Context, client *Client, payload []byte) error {
+	log.Println("Router: Inspect", string(payload))
+	path, rawQuery, _ := strings.Cut(string(payload), "?")
+	path = strings.Trim(path, "/")
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return fmt.Errorf("handler: invalid query %q: %w", rawQuery, err)
+	}
+	var (
+		best       string
+		bestParams Params
+	)
+	for _, pattern := range r.inspectRoutes {
+		// A path spelling out a pattern, as "todo/:id", is not a request for it.
+		if pattern == path && !isStatic(pattern) {
+			return fmt.Errorf("handler: path not found: %s", path)
+		}
+		params, ok := matchPath(pattern, path)
+		if ok && (bestParams == nil || moreSpecific(pattern, best)) {
+			best, bestParams = pattern, params
+		}
+	}
+	if bestParams == nil {
+		return fmt.Errorf("handler: path not found: %s", path)
+	}
+	return r.InspectHandlers[best](ctx, client, withQuery(bestParams, values))
+}
+
+// withQuery adds the first value of every query parameter to params, which
+// take precedence.
+func withQuery(params Params, values url.Values) Params {
+	for name := range values {
+		if _, ok := params[name]; !ok {
+			params[name] = values.Get(name)
+		}
+	}
+	return params
+}
+
+// Values returns params as URL query values.
+func (p Params) Values() url.Values {
+	values := make(url.Values, len(p))
+	for name, value := range p {
+		values.Set(name, value)
+	}
+	return values
+}
+
+func matchPath(pattern string, path string) (Params, bool) {
+	patternParts := strings.Split(pattern, "/")
+	pathParts := strings.Split(path, "/")
+	if len(patternParts) != len(pathParts) {
+		return nil, false
+	}
+
+	params := Params{}
+	for i, part := range patternParts {
+		if isParam(part) {
+			if pathParts[i] == "" {
+				return nil, false
+			}
+			params[part[1:]] = pathParts[i]
+		} else if part != pathParts[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func isParam(part string) bool {
+	return strings.HasPrefix(part, ":") && len(part) > 1
+}
+
+func isStatic(pattern string) bool {
+	for _, part := range strings.Split(pattern, "/") {
+		if isParam(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// moreSpecific reports whether pattern should win over other when both match
+// the same path: the first segment where they differ decides, and a static
+// segment beats a parameter.
+func moreSpecific(pattern string, other string) bool {
+	patternParts := strings.Split(pattern, "/")
+	otherParts := strings.Split(other, "/")
+	for i := 0; i < len(patternParts) && i < len(otherParts); i++ {
+		if isParam(patternParts[i]) != isParam(otherParts[i]) {
+			return !isParam(patternParts[i])
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package rollups_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+)
+
+func TestRouterInspectPicksTheMostSpecificPattern(t *testing.T) {
+	var (
+		matched string
+		got     rollups.Params
+	)
+	r := rollups.NewRouter()
+	// Registered least specific first so that order cannot decide.
+	for _, pattern := range []string{":kind/:id", ":kind/completed", "todo/:id", "todo/completed", "todo"} {
+		r.HandleInspect(pattern, func(ctx context.Context, client *rollups.Client, params rollups.Params) error {
+			matched, got = pattern, params
+			return nil
+		})
+	}
+
+	tests := []struct {
+		path    string
+		pattern string
+		params  rollups.Params
+	}{
+		{"todo", "todo", rollups.Params{}},
+		{"todo/completed", "todo/completed", rollups.Params{}},
+		{"/todo/7/", "todo/:id", rollups.Params{"id": "7"}},
+		{"todo/7?limit=2&id=9", "todo/:id", rollups.Params{"id": "7", "limit": "2"}},
+		{"note/completed", ":kind/completed", rollups.Params{"kind": "note"}},
+		{"note/7", ":kind/:id", rollups.Params{"kind": "note", "id": "7"}},
+	}
+	for _, tt := range tests {
+		// Map iteration order changes between runs; the answer must not.
+		for range 20 {
+			if err := r.Inspect(context.Background(), nil, []byte(tt.path)); err != nil {
+				t.Fatalf("Inspect(%q): %v", tt.path, err)
+			}
+			if matched != tt.pattern || !reflect.DeepEqual(got, tt.params) {
+				t.Fatalf("Inspect(%q) matched %q with %v, want %q with %v", tt.path, matched, got, tt.pattern, tt.params)
+			}
+		}
+	}
+
+	for _, path := range []string{"todo/:id", "todo/7/title", "todo//completed"} {
+		if err := r.Inspect(context.Background(), nil, []byte(path)); err == nil {
+			t.Errorf("Inspect(%q) = nil, want path not found", path)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package rollups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	minIdleBackoff = 100 * time.Millisecond
+	maxIdleBackoff = time.Second
+)
+
+// Application handles the requests received from the rollup server. Returning
+// an error rejects the request.
+type Application interface {
+	Advance(ctx context.Context, client *Client, payload []byte, metadata Metadata) error
+	Inspect(ctx context.Context, client *Client, payload []byte) error
+}
+
+// Run serves app with a client for the server in ROLLUP_HTTP_SERVER_URL.
+func Run(ctx context.Context, app Application) error {
+	client, err := NewClientFromEnv()
+	if err != nil {
+		return err
+	}
+	return client.Run(ctx, app)
+}
+
+// Run finishes every request with the result of app until ctx is cancelled,
+// which is not reported as an error. A panic in app is sent as an exception,
+// after which the machine is no longer usable, so Run returns.
+func (c *Client) Run(ctx context.Context, app Application) error {
+	finish := &FinishRequest{Status: "accept"}
+	idle := minIdleBackoff
+	for {
+		response, err := c.Finish(ctx, finish)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if response == nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(idle):
+			}
+			idle = min(2*idle, maxIdleBackoff)
+			continue
+		}
+		idle = minIdleBackoff
+
+		finish.Status = "accept"
+		if err := c.handle(ctx, app, response); err != nil {
+			if exception, ok := err.(*panicError); ok {
+				return c.raise(ctx, exception)
+			}
+			log.Printf("rollups: rejecting %s: %v", response.Type, err)
+			finish.Status = "reject"
+		}
+	}
+}
+
+func (c *Client) handle(ctx context.Context, app Application, response *FinishResponse) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &panicError{value: r}
+		}
+	}()
+
+	switch response.Type {
+	case "advance_state":
+		var data AdvanceResponse
+		if err := json.Unmarshal(response.Data, &data); err != nil {
+			return fmt.Errorf("failed to decode advance request: %w", err)
+		}
+		return app.Advance(ctx, c, data.Payload, data.Metadata)
+	case "inspect_state":
+		var data InspectResponse
+		if err := json.Unmarshal(response.Data, &data); err != nil {
+			return fmt.Errorf("failed to decode inspect request: %w", err)
+		}
+		return app.Inspect(ctx, c, data.Payload)
+	}
+	return fmt.Errorf("unknown request type: %s", response.Type)
+}
+
+func (c *Client) raise(ctx context.Context, exception *panicError) error {
+	if err := c.SendException(ctx, &ExceptionRequest{Payload: Payload(exception.Error())}); err != nil {
+		return fmt.Errorf("%w (failed to send exception: %v)", exception, err)
+	}
+	return exception
+}
+
+type panicError struct {
+	value any
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("rollups: application panicked: %v", e.value)
+}
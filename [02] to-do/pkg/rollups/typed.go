@@ -0,0 +1,74 @@
+package rollups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate is shared by every request so struct metadata is parsed only once.
+var validate = validator.New()
+
+type TypedAdvanceFunc[In, Out any] func(input *In, metadata Metadata) (Out, error)
+
+// HandleAdvanceTyped registers fn on r. The payload is decoded into In and
+// validated, and the result is sent as a notice in the "<event> - <json>"
+// envelope. An empty event leaves emitting outputs to fn.
+func HandleAdvanceTyped[In, Out any](r *Router, path string, event string, fn TypedAdvanceFunc[In, Out]) {
+	r.HandleAdvance(path, AdvanceTyped(event, fn))
+}
+
+func AdvanceTyped[In, Out any](event string, fn TypedAdvanceFunc[In, Out]) AdvanceHandlerFunc {
+	return func(ctx context.Context, client *Client, payload []byte, metadata Metadata) error {
+		var input In
+		if err := json.Unmarshal(payload, &input); err != nil {
+			return fmt.Errorf("failed to unmarshal input: %w", err)
+		}
+		if err := validate.Struct(input); err != nil {
+			return fmt.Errorf("failed to validate input: %w", err)
+		}
+
+		res, err := fn(&input, metadata)
+		if err != nil {
+			return err
+		}
+		if event == "" {
+			return nil
+		}
+
+		body, err := json.Marshal(res)
+		if err != nil {
+			return fmt.Errorf("failed to marshal response: %w", err)
+		}
+		_, err = client.SendNotice(ctx, &NoticeRequest{
+			Payload: Payload(fmt.Sprintf("%s - %s", event, body)),
+		})
+		return err
+	}
+}
+
+type TypedInspectFunc[Out any] func(params Params) (Out, error)
+
+// HandleInspectTyped registers fn on r and sends its result as a JSON report.
+func HandleInspectTyped[Out any](r *Router, path string, fn TypedInspectFunc[Out]) {
+	r.HandleInspect(path, InspectTyped(fn))
+}
+
+func InspectTyped[Out any](fn TypedInspectFunc[Out]) InspectHandlerFunc {
+	return func(ctx context.Context, client *Client, params Params) error {
+		res, err := fn(params)
+		if err != nil {
+			return err
+		}
+
+		body, err := json.Marshal(res)
+		if err != nil {
+			return fmt.Errorf("failed to marshal response: %w", err)
+		}
+		return client.SendReport(ctx, &ReportRequest{
+			Payload: body,
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package voucher
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	safeERC20TransferABI = mustParseABI(`[{
+		"type":"function",
+		"name":"safeTransfer",
+		"inputs":[
+			{"name":"token","type":"address"},
+			{"name":"to","type":"address"},
+			{"name":"value","type":"uint256"}
+		]
+	},
+	{
+		"type":"function",
+		"name":"safeTransferTargeted",
+		"inputs":[
+			{"name":"token","type":"address"},
+			{"name":"target","type":"address"},
+			{"name":"to","type":"address"},
+			{"name":"value","type":"uint256"}
+		]
+	}]`)
+
+	emergencyWithdrawABI = mustParseABI(`[{
+		"type":"function",
+		"name":"emergencyERC20Withdraw",
+		"inputs":[
+			{"name":"token","type":"address"},
+			{"name":"to","type":"address"}
+		]
+	},
+	{
+		"type":"function",
+		"name":"emergencyETHWithdraw",
+		"inputs":[
+			{"name":"to","type":"address"}
+		]
+	}]`)
+
+	// adminEmergencyWithdrawABI is the EmergencyWithdraw variant that checks the
+	// caller passed as its first argument.
+	adminEmergencyWithdrawABI = mustParseABI(`[{
+		"type":"function",
+		"name":"emergencyERC20Withdraw",
+		"inputs":[
+			{"name":"admin","type":"address"},
+			{"name":"token","type":"address"},
+			{"name":"to","type":"address"}
+		]
+	},
+	{
+		"type":"function",
+		"name":"emergencyETHWithdraw",
+		"inputs":[
+			{"name":"admin","type":"address"},
+			{"name":"to","type":"address"}
+		]
+	}]`)
+)
+
+// SafeERC20Transfer transfers value of token held by the application to to
+// through the SafeERC20Transfer library at library.
+func SafeERC20Transfer(library common.Address, token common.Address, to common.Address, value *big.Int) (DelegateCallVoucher, error) {
+	return newDelegateCallVoucher(library, safeERC20TransferABI, "safeTransfer", token, to, value)
+}
+
+// SafeERC20TransferTargeted is SafeERC20Transfer for libraries that also check
+// the transfer target.
+func SafeERC20TransferTargeted(library common.Address, token common.Address, target common.Address, to common.Address, value *big.Int) (DelegateCallVoucher, error) {
+	return newDelegateCallVoucher(library, safeERC20TransferABI, "safeTransferTargeted", token, target, to, value)
+}
+
+// EmergencyERC20Withdraw moves the whole token balance of the application to to.
+func EmergencyERC20Withdraw(library common.Address, token common.Address, to common.Address) (DelegateCallVoucher, error) {
+	return newDelegateCallVoucher(library, emergencyWithdrawABI, "emergencyERC20Withdraw", token, to)
+}
+
+// EmergencyETHWithdraw moves the whole Ether balance of the application to to.
+func EmergencyETHWithdraw(library common.Address, to common.Address) (DelegateCallVoucher, error) {
+	return newDelegateCallVoucher(library, emergencyWithdrawABI, "emergencyETHWithdraw", to)
+}
+
+// AdminEmergencyERC20Withdraw is EmergencyERC20Withdraw for libraries that only
+// accept requests from admin.
+func AdminEmergencyERC20Withdraw(library common.Address, admin common.Address, token common.Address, to common.Address) (DelegateCallVoucher, error) {
+	return newDelegateCallVoucher(library, adminEmergencyWithdrawABI, "emergencyERC20Withdraw", admin, token, to)
+}
+
+// AdminEmergencyETHWithdraw is EmergencyETHWithdraw for libraries that only
+// accept requests from admin.
+func AdminEmergencyETHWithdraw(library common.Address, admin common.Address, to common.Address) (DelegateCallVoucher, error) {
+	return newDelegateCallVoucher(library, adminEmergencyWithdrawABI, "emergencyETHWithdraw", admin, to)
+}
@@ -0,0 +1,104 @@
+package voucher
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	erc20ABI = mustParseABI(`[{
+		"type":"function",
+		"name":"transfer",
+		"inputs":[
+			{"name":"to","type":"address"},
+			{"name":"value","type":"uint256"}
+		]
+	}]`)
+
+	erc721ABI = mustParseABI(`[{
+		"type":"function",
+		"name":"safeMint",
+		"inputs":[
+			{"name":"to","type":"address"},
+			{"name":"uri","type":"string"}
+		]
+	},
+	{
+		"type":"function",
+		"name":"safeTransferFrom",
+		"inputs":[
+			{"name":"from","type":"address"},
+			{"name":"to","type":"address"},
+			{"name":"tokenId","type":"uint256"}
+		]
+	}]`)
+
+	erc1155ABI = mustParseABI(`[{
+		"type":"function",
+		"name":"safeTransferFrom",
+		"inputs":[
+			{"name":"from","type":"address"},
+			{"name":"to","type":"address"},
+			{"name":"id","type":"uint256"},
+			{"name":"value","type":"uint256"},
+			{"name":"data","type":"bytes"}
+		]
+	},
+	{
+		"type":"function",
+		"name":"safeBatchTransferFrom",
+		"inputs":[
+			{"name":"from","type":"address"},
+			{"name":"to","type":"address"},
+			{"name":"ids","type":"uint256[]"},
+			{"name":"values","type":"uint256[]"},
+			{"name":"data","type":"bytes"}
+		]
+	}]`)
+
+	deployerABI = mustParseABI(`[{
+		"type":"function",
+		"name":"deploy",
+		"inputs":[
+			{"name":"_code","type":"bytes"}
+		]
+	}]`)
+)
+
+// ERC20Transfer transfers amount of token held by the application to to.
+func ERC20Transfer(token common.Address, to common.Address, amount *big.Int) (Voucher, error) {
+	return newVoucher(token, erc20ABI, "transfer", to, amount)
+}
+
+// ERC721SafeMint mints a token with metadata uri to to. The application must be
+// allowed to mint on token.
+func ERC721SafeMint(token common.Address, to common.Address, uri string) (Voucher, error) {
+	return newVoucher(token, erc721ABI, "safeMint", to, uri)
+}
+
+// ERC721SafeTransferFrom transfers tokenId of token from from to to.
+func ERC721SafeTransferFrom(token common.Address, from common.Address, to common.Address, tokenId *big.Int) (Voucher, error) {
+	return newVoucher(token, erc721ABI, "safeTransferFrom", from, to, tokenId)
+}
+
+// ERC1155SafeTransferFrom transfers value units of id of token from from to to.
+func ERC1155SafeTransferFrom(token common.Address, from common.Address, to common.Address, id *big.Int, value *big.Int, data []byte) (Voucher, error) {
+	if data == nil {
+		data = []byte{}
+	}
+	return newVoucher(token, erc1155ABI, "safeTransferFrom", from, to, id, value, data)
+}
+
+// ERC1155SafeBatchTransferFrom transfers values[i] units of ids[i] of token from from to to.
+func ERC1155SafeBatchTransferFrom(token common.Address, from common.Address, to common.Address, ids []*big.Int, values []*big.Int, data []byte) (Voucher, error) {
+	if data == nil {
+		data = []byte{}
+	}
+	return newVoucher(token, erc1155ABI, "safeBatchTransferFrom", from, to, ids, values, data)
+}
+
+// Deploy asks the deployer contract to create a contract from bytecode.
+func Deploy(deployer common.Address, bytecode []byte) (Voucher, error) {
+	return newVoucher(deployer, deployerABI, "deploy", bytecode)
+}
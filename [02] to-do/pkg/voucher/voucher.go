@@ -0,0 +1,73 @@
+// Package voucher builds the vouchers and delegate call vouchers of the common
+// base layer targets. Every ABI is parsed once, when the package is loaded.
+package voucher
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rollmelette/rollmelette"
+)
+
+// Voucher is a call executed by the application contract on the base layer.
+type Voucher struct {
+	Destination common.Address
+	Value       *big.Int
+	Payload     []byte
+}
+
+// Emit sends the voucher and returns its output index.
+func (v Voucher) Emit(env rollmelette.Env) int {
+	value := v.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	return env.Voucher(v.Destination, value, v.Payload)
+}
+
+// DelegateCallVoucher is a call the application contract delegates to a
+// library contract, running its code in the application's context.
+type DelegateCallVoucher struct {
+	Destination common.Address
+	Payload     []byte
+}
+
+// Emit sends the delegate call voucher and returns its output index.
+func (v DelegateCallVoucher) Emit(env rollmelette.Env) int {
+	return env.DelegateCallVoucher(v.Destination, v.Payload)
+}
+
+func mustParseABI(abiJSON string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(fmt.Sprintf("voucher: invalid ABI: %v", err))
+	}
+	return parsed
+}
+
+func pack(contract abi.ABI, method string, args ...any) ([]byte, error) {
+	payload, err := contract.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+	return payload, nil
+}
+
+func newVoucher(destination common.Address, contract abi.ABI, method string, args ...any) (Voucher, error) {
+	payload, err := pack(contract, method, args...)
+	if err != nil {
+		return Voucher{}, err
+	}
+	return Voucher{Destination: destination, Value: big.NewInt(0), Payload: payload}, nil
+}
+
+func newDelegateCallVoucher(destination common.Address, contract abi.ABI, method string, args ...any) (DelegateCallVoucher, error) {
+	payload, err := pack(contract, method, args...)
+	if err != nil {
+		return DelegateCallVoucher{}, err
+	}
+	return DelegateCallVoucher{Destination: destination, Payload: payload}, nil
+}
@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+)
+
+// OperationInput is the payload of the withdraw and transfer routes. Value is
+// a decimal amount for Ether and ERC20 tokens and TokenId a decimal id for
+// ERC721 tokens.
+type OperationInput struct {
+	Kind    Kind            `json:"kind"`
+	Token   rollups.Address `json:"token"`
+	To      rollups.Address `json:"to"`
+	Value   string          `json:"value"`
+	TokenId string          `json:"token_id"`
+}
+
+type BalanceOutput struct {
+	Kind    Kind            `json:"kind"`
+	Token   rollups.Address `json:"token"`
+	TokenId string          `json:"token_id,omitempty"`
+	Amount  string          `json:"amount"`
+}
+
+// Register wires the wallet into r: deposits are credited, "withdraw" and
+// "transfer" move the assets of msg_sender and "wallet/:owner" reports the
+// balances of owner.
+func (w *Wallet) Register(r *rollups.Router) {
+	r.HandleDeposit(w.HandleDeposit)
+	r.HandleAdvance("withdraw", w.HandleWithdraw)
+	r.HandleAdvance("transfer", w.HandleTransfer)
+	r.HandleInspect("wallet/:owner", w.HandleBalances)
+}
+
+func (w *Wallet) HandleDeposit(ctx context.Context, client *rollups.Client, deposit rollups.Deposit, payload []byte, metadata rollups.Metadata) error {
+	return w.Deposit(ctx, deposit)
+}
+
+func (w *Wallet) HandleWithdraw(ctx context.Context, client *rollups.Client, payload []byte, metadata rollups.Metadata) error {
+	input, amount, err := decodeOperation(payload)
+	if err != nil {
+		return err
+	}
+	owner := metadata.MsgSender
+	switch input.Kind {
+	case Ether:
+		_, err = w.EtherWithdraw(ctx, client, owner, amount)
+	case ERC20:
+		_, err = w.ERC20Withdraw(ctx, client, input.Token, owner, amount)
+	case ERC721:
+		_, err = w.ERC721Withdraw(ctx, client, metadata.AppContract, input.Token, owner, amount)
+	}
+	return err
+}
+
+func (w *Wallet) HandleTransfer(ctx context.Context, client *rollups.Client, payload []byte, metadata rollups.Metadata) error {
+	input, amount, err := decodeOperation(payload)
+	if err != nil {
+		return err
+	}
+	owner := metadata.MsgSender
+	switch input.Kind {
+	case Ether:
+		return w.EtherTransfer(ctx, owner, input.To, amount)
+	case ERC20:
+		return w.ERC20Transfer(ctx, input.Token, owner, input.To, amount)
+	case ERC721:
+		return w.ERC721Transfer(ctx, input.Token, owner, input.To, amount)
+	}
+	return nil
+}
+
+func (w *Wallet) HandleBalances(ctx context.Context, client *rollups.Client, params rollups.Params) error {
+	owner, err := rollups.HexToAddress(params["owner"])
+	if err != nil {
+		return err
+	}
+	balances, err := w.BalancesOf(ctx, owner)
+	if err != nil {
+		return err
+	}
+	output := make([]*BalanceOutput, len(balances))
+	for i, balance := range balances {
+		output[i] = &BalanceOutput{
+			Kind:    balance.Kind,
+			Token:   balance.Token,
+			TokenId: balance.TokenId,
+			Amount:  balance.Amount.String(),
+		}
+	}
+	body, err := json.Marshal(output)
+	if err != nil {
+		return fmt.Errorf("failed to marshal balances: %w", err)
+	}
+	return client.SendReport(ctx, &rollups.ReportRequest{Payload: body})
+}
+
+// decodeOperation decodes payload and returns the value, or the token id for
+// ERC721 tokens, it operates on.
+func decodeOperation(payload []byte) (*OperationInput, *big.Int, error) {
+	var input OperationInput
+	if err := json.Unmarshal(payload, &input); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+	amount := input.Value
+	switch input.Kind {
+	case Ether, ERC20:
+	case ERC721:
+		amount = input.TokenId
+	default:
+		return nil, nil, fmt.Errorf("unknown asset kind: %q", input.Kind)
+	}
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() < 0 || value.BitLen() > 256 {
+		return nil, nil, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	return &input, value, nil
+}
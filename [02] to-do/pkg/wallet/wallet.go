@@ -0,0 +1,288 @@
+// Package wallet keeps the Ether, ERC20 and ERC721 balances deposited in the
+// application through the portals.
+package wallet
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/voucher"
+)
+
+var (
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrNotOwner            = errors.New("not the token owner")
+	ErrInvalidAmount       = errors.New("invalid amount")
+	ErrUnsupportedDeposit  = errors.New("unsupported deposit")
+)
+
+type Kind string
+
+const (
+	Ether  Kind = "ether"
+	ERC20  Kind = "erc20"
+	ERC721 Kind = "erc721"
+)
+
+// Asset identifies what a balance is made of. Token is zero for Ether and
+// TokenId, in decimal, is only set for ERC721 tokens.
+type Asset struct {
+	Kind    Kind
+	Token   rollups.Address
+	TokenId string
+}
+
+// Balance is the amount of an asset held by an owner. ERC721 tokens are held
+// with an amount of one.
+type Balance struct {
+	Asset
+	Owner  rollups.Address
+	Amount *big.Int
+}
+
+// Store persists balances. FindBalance returns zero for unknown balances and
+// SaveBalance deletes balances saved with a zero amount.
+type Store interface {
+	FindBalance(ctx context.Context, asset Asset, owner rollups.Address) (*big.Int, error)
+	FindBalancesByAsset(ctx context.Context, asset Asset) ([]*Balance, error)
+	FindBalancesByOwner(ctx context.Context, owner rollups.Address) ([]*Balance, error)
+	SaveBalance(ctx context.Context, balance *Balance) error
+	// RunInTransaction runs fn in a transaction that the methods above join
+	// when called with the context given to fn. It commits only when fn
+	// returns nil.
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Wallet struct {
+	store Store
+}
+
+func New(store Store) *Wallet {
+	return &Wallet{store: store}
+}
+
+func etherAsset() Asset {
+	return Asset{Kind: Ether}
+}
+
+func erc20Asset(token rollups.Address) Asset {
+	return Asset{Kind: ERC20, Token: token}
+}
+
+func erc721Asset(token rollups.Address, tokenId *big.Int) Asset {
+	return Asset{Kind: ERC721, Token: token, TokenId: tokenId.String()}
+}
+
+// Deposit credits a deposit decoded by rollups.Portals.
+func (w *Wallet) Deposit(ctx context.Context, deposit rollups.Deposit) error {
+	switch d := deposit.(type) {
+	case rollups.EtherDeposit:
+		return w.credit(ctx, etherAsset(), d.Sender, d.Value)
+	case rollups.ERC20Deposit:
+		return w.credit(ctx, erc20Asset(d.Token), d.Sender, d.Value)
+	case rollups.ERC721Deposit:
+		return w.store.SaveBalance(ctx, &Balance{Asset: erc721Asset(d.Token, d.TokenId), Owner: d.Sender, Amount: big.NewInt(1)})
+	}
+	return fmt.Errorf("%w: %T", ErrUnsupportedDeposit, deposit)
+}
+
+func (w *Wallet) EtherBalanceOf(ctx context.Context, owner rollups.Address) (*big.Int, error) {
+	return w.store.FindBalance(ctx, etherAsset(), owner)
+}
+
+func (w *Wallet) ERC20BalanceOf(ctx context.Context, token rollups.Address, owner rollups.Address) (*big.Int, error) {
+	return w.store.FindBalance(ctx, erc20Asset(token), owner)
+}
+
+// ERC721OwnerOf returns the owner of tokenId and whether the application holds it.
+func (w *Wallet) ERC721OwnerOf(ctx context.Context, token rollups.Address, tokenId *big.Int) (rollups.Address, bool, error) {
+	balances, err := w.store.FindBalancesByAsset(ctx, erc721Asset(token, tokenId))
+	if err != nil || len(balances) == 0 {
+		return rollups.Address{}, false, err
+	}
+	return balances[0].Owner, true, nil
+}
+
+// BalancesOf returns every balance held by owner, sorted by asset.
+func (w *Wallet) BalancesOf(ctx context.Context, owner rollups.Address) ([]*Balance, error) {
+	balances, err := w.store.FindBalancesByOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(balances, func(a, b *Balance) int {
+		return cmp.Or(
+			cmp.Compare(a.Kind, b.Kind),
+			bytes.Compare(a.Token[:], b.Token[:]),
+			cmp.Compare(len(a.TokenId), len(b.TokenId)),
+			cmp.Compare(a.TokenId, b.TokenId),
+		)
+	})
+	return balances, nil
+}
+
+func (w *Wallet) EtherTransfer(ctx context.Context, src rollups.Address, dst rollups.Address, value *big.Int) error {
+	return w.transfer(ctx, etherAsset(), src, dst, value)
+}
+
+func (w *Wallet) ERC20Transfer(ctx context.Context, token rollups.Address, src rollups.Address, dst rollups.Address, value *big.Int) error {
+	return w.transfer(ctx, erc20Asset(token), src, dst, value)
+}
+
+func (w *Wallet) ERC721Transfer(ctx context.Context, token rollups.Address, src rollups.Address, dst rollups.Address, tokenId *big.Int) error {
+	return w.store.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := w.checkOwner(ctx, token, src, tokenId); err != nil {
+			return err
+		}
+		asset := erc721Asset(token, tokenId)
+		if err := w.store.SaveBalance(ctx, &Balance{Asset: asset, Owner: src, Amount: new(big.Int)}); err != nil {
+			return err
+		}
+		return w.store.SaveBalance(ctx, &Balance{Asset: asset, Owner: dst, Amount: big.NewInt(1)})
+	})
+}
+
+// EtherWithdraw debits value from owner and sends it back with a voucher,
+// returning the voucher index.
+func (w *Wallet) EtherWithdraw(ctx context.Context, client *rollups.Client, owner rollups.Address, value *big.Int) (uint64, error) {
+	return w.withdraw(ctx, client, etherAsset(), owner, value, func() (voucher.Voucher, error) {
+		return voucher.Voucher{Destination: common.Address(owner), Value: value}, nil
+	})
+}
+
+// ERC20Withdraw debits value of token from owner and sends a transfer voucher,
+// returning the voucher index.
+func (w *Wallet) ERC20Withdraw(ctx context.Context, client *rollups.Client, token rollups.Address, owner rollups.Address, value *big.Int) (uint64, error) {
+	return w.withdraw(ctx, client, erc20Asset(token), owner, value, func() (voucher.Voucher, error) {
+		return voucher.ERC20Transfer(common.Address(token), common.Address(owner), value)
+	})
+}
+
+// ERC721Withdraw sends tokenId from the application at appContract back to
+// its owner, returning the voucher index.
+func (w *Wallet) ERC721Withdraw(ctx context.Context, client *rollups.Client, appContract rollups.Address, token rollups.Address, owner rollups.Address, tokenId *big.Int) (uint64, error) {
+	var index uint64
+	err := w.store.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := w.checkOwner(ctx, token, owner, tokenId); err != nil {
+			return err
+		}
+		var err error
+		index, err = w.withdraw(ctx, client, erc721Asset(token, tokenId), owner, big.NewInt(1), func() (voucher.Voucher, error) {
+			return voucher.ERC721SafeTransferFrom(common.Address(token), common.Address(appContract), common.Address(owner), tokenId)
+		})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return index, nil
+}
+
+// credit reads and writes the balance in one transaction, as debit does.
+func (w *Wallet) credit(ctx context.Context, asset Asset, owner rollups.Address, value *big.Int) error {
+	if value.Sign() < 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidAmount, value)
+	}
+	return w.store.RunInTransaction(ctx, func(ctx context.Context) error {
+		balance, err := w.store.FindBalance(ctx, asset, owner)
+		if err != nil {
+			return err
+		}
+		return w.store.SaveBalance(ctx, &Balance{Asset: asset, Owner: owner, Amount: balance.Add(balance, value)})
+	})
+}
+
+func (w *Wallet) debit(ctx context.Context, asset Asset, owner rollups.Address, value *big.Int) error {
+	return w.store.RunInTransaction(ctx, func(ctx context.Context) error {
+		balance, err := w.checkBalance(ctx, asset, owner, value)
+		if err != nil {
+			return err
+		}
+		return w.store.SaveBalance(ctx, &Balance{Asset: asset, Owner: owner, Amount: balance.Sub(balance, value)})
+	})
+}
+
+// transfer debits src and credits dst in one transaction, so a failure in
+// between leaves both balances untouched.
+func (w *Wallet) transfer(ctx context.Context, asset Asset, src rollups.Address, dst rollups.Address, value *big.Int) error {
+	return w.store.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := w.debit(ctx, asset, src, value); err != nil {
+			return err
+		}
+		return w.credit(ctx, asset, dst, value)
+	})
+}
+
+// withdraw checks the balance, sends the voucher made by build and debits owner
+// in one transaction. build only runs once value is known to be in range. A
+// failure rejects the input, which discards the voucher too.
+func (w *Wallet) withdraw(ctx context.Context, client *rollups.Client, asset Asset, owner rollups.Address, value *big.Int, build func() (voucher.Voucher, error)) (uint64, error) {
+	var index uint64
+	err := w.store.RunInTransaction(ctx, func(ctx context.Context) error {
+		if _, err := w.checkBalance(ctx, asset, owner, value); err != nil {
+			return err
+		}
+		request, err := voucherRequest(build)
+		if err != nil {
+			return err
+		}
+		res, err := client.SendVoucher(ctx, request)
+		if err != nil {
+			return err
+		}
+		index = res.Index
+		return w.debit(ctx, asset, owner, value)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return index, nil
+}
+
+func voucherRequest(build func() (voucher.Voucher, error)) (*rollups.VoucherRequest, error) {
+	v, err := build()
+	if err != nil {
+		return nil, err
+	}
+	value := v.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	amount, err := rollups.NewUint256(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+	}
+	return &rollups.VoucherRequest{Destination: rollups.Address(v.Destination), Value: amount, Payload: v.Payload}, nil
+}
+
+// checkBalance returns the balance of owner when it covers value.
+func (w *Wallet) checkBalance(ctx context.Context, asset Asset, owner rollups.Address, value *big.Int) (*big.Int, error) {
+	if value.Sign() <= 0 || value.BitLen() > 256 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAmount, value)
+	}
+	balance, err := w.store.FindBalance(ctx, asset, owner)
+	if err != nil {
+		return nil, err
+	}
+	if balance.Cmp(value) < 0 {
+		return nil, fmt.Errorf("%w: %s has %s %s", ErrInsufficientBalance, owner, balance, asset.Kind)
+	}
+	return balance, nil
+}
+
+func (w *Wallet) checkOwner(ctx context.Context, token rollups.Address, owner rollups.Address, tokenId *big.Int) error {
+	current, ok, err := w.ERC721OwnerOf(ctx, token, tokenId)
+	if err != nil {
+		return err
+	}
+	if !ok || current != owner {
+		return fmt.Errorf("%w: %s does not own token %s of %s", ErrNotOwner, owner, tokenId, token)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package wallet_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/internal/infra/repository/factory"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/rollups/rollupstest"
+	"github.com/henriquemarlon/cartesi-golang-series/to-do/pkg/wallet"
+)
+
+var (
+	app    = rollups.Address{0xab}
+	token  = rollups.Address{0x09}
+	alice  = rollups.Address{0x01}
+	bob    = rollups.Address{0x02}
+	portal = rollups.DefaultPortals
+)
+
+func word(v int64) []byte {
+	return big.NewInt(v).FillBytes(make([]byte, 32))
+}
+
+func addressWord(address rollups.Address) []byte {
+	return append(make([]byte, 12), address[:]...)
+}
+
+func connections(t *testing.T) map[string]string {
+	return map[string]string{
+		"memory": "memory://",
+		"sqlite": "sqlite://" + filepath.Join(t.TempDir(), "wallet.db"),
+	}
+}
+
+func setupServer(t *testing.T, conn string) (*rollupstest.Server, repository.Repository) {
+	repo, err := factory.NewRepositoryFromConnectionString(context.Background(), conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	r := rollups.NewRouter()
+	wallet.New(repo).Register(r)
+	return rollupstest.Run(t, r), repo
+}
+
+func advance(t *testing.T, srv *rollupstest.Server, sender rollups.Address, payload []byte, accepted bool) *rollupstest.Result {
+	t.Helper()
+	res := srv.Advance(payload, rollups.Metadata{AppContract: app, MsgSender: sender})
+	if res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	if res.Accepted() != accepted {
+		t.Fatalf("expected accepted to be %v for %s, got %q", accepted, payload, res.Status)
+	}
+	return res
+}
+
+func operation(path string, input wallet.OperationInput) []byte {
+	payload, _ := json.Marshal(input)
+	return []byte(`{"path":"` + path + `","payload":` + string(payload) + `}`)
+}
+
+func balances(t *testing.T, srv *rollupstest.Server, owner rollups.Address) []wallet.BalanceOutput {
+	t.Helper()
+	res := srv.Inspect([]byte("wallet/" + owner.String()))
+	if !res.Accepted() || len(res.Reports) != 1 {
+		t.Fatalf("unexpected inspect result: %+v", res)
+	}
+	var output []wallet.BalanceOutput
+	if err := json.Unmarshal(res.Reports[0], &output); err != nil {
+		t.Fatal(err)
+	}
+	return output
+}
+
+func TestWallet(t *testing.T) {
+	for name, conn := range connections(t) {
+		t.Run(name, func(t *testing.T) {
+			srv, _ := setupServer(t, conn)
+
+			advance(t, srv, portal.EtherPortal, append(alice[:], word(100)...), true)
+			advance(t, srv, portal.ERC20Portal, bytes.Join([][]byte{token[:], alice[:], word(50)}, nil), true)
+			advance(t, srv, portal.ERC721Portal, bytes.Join([][]byte{token[:], alice[:], word(7), word(64), word(96), word(0), word(0)}, nil), true)
+
+			advance(t, srv, alice, operation("transfer", wallet.OperationInput{Kind: wallet.Ether, To: bob, Value: "30"}), true)
+			advance(t, srv, alice, operation("transfer", wallet.OperationInput{Kind: wallet.ERC20, Token: token, To: bob, Value: "51"}), false)
+			advance(t, srv, alice, operation("transfer", wallet.OperationInput{Kind: wallet.ERC721, Token: token, To: bob, TokenId: "7"}), true)
+			advance(t, srv, alice, operation("transfer", wallet.OperationInput{Kind: wallet.Ether, To: bob, Value: "-1"}), false)
+
+			expected := []wallet.BalanceOutput{
+				{Kind: wallet.ERC20, Token: token, Amount: "50"},
+				{Kind: wallet.Ether, Amount: "70"},
+			}
+			if output := balances(t, srv, alice); !equalBalances(output, expected) {
+				t.Fatalf("expected %+v, got %+v", expected, output)
+			}
+
+			res := advance(t, srv, alice, operation("withdraw", wallet.OperationInput{Kind: wallet.Ether, Value: "70"}), true)
+			if len(res.Vouchers) != 1 || res.Vouchers[0].Destination != alice || res.Vouchers[0].Value.Big().Int64() != 70 || len(res.Vouchers[0].Payload) != 0 {
+				t.Fatalf("unexpected ether voucher: %+v", res.Vouchers)
+			}
+
+			res = advance(t, srv, alice, operation("withdraw", wallet.OperationInput{Kind: wallet.ERC20, Token: token, Value: "20"}), true)
+			transfer := bytes.Join([][]byte{{0xa9, 0x05, 0x9c, 0xbb}, addressWord(alice), word(20)}, nil)
+			if len(res.Vouchers) != 1 || res.Vouchers[0].Destination != token || !bytes.Equal(res.Vouchers[0].Payload, transfer) {
+				t.Fatalf("unexpected ERC20 voucher: %+v", res.Vouchers)
+			}
+
+			advance(t, srv, alice, operation("withdraw", wallet.OperationInput{Kind: wallet.ERC721, Token: token, TokenId: "7"}), false)
+			res = advance(t, srv, bob, operation("withdraw", wallet.OperationInput{Kind: wallet.ERC721, Token: token, TokenId: "7"}), true)
+			safeTransferFrom := bytes.Join([][]byte{{0x42, 0x84, 0x2e, 0x0e}, addressWord(app), addressWord(bob), word(7)}, nil)
+			if len(res.Vouchers) != 1 || !bytes.Equal(res.Vouchers[0].Payload, safeTransferFrom) {
+				t.Fatalf("unexpected ERC721 voucher: %+v", res.Vouchers)
+			}
+
+			expected = []wallet.BalanceOutput{{Kind: wallet.ERC20, Token: token, Amount: "30"}}
+			if output := balances(t, srv, alice); !equalBalances(output, expected) {
+				t.Fatalf("expected %+v, got %+v", expected, output)
+			}
+			expected = []wallet.BalanceOutput{{Kind: wallet.Ether, Amount: "30"}}
+			if output := balances(t, srv, bob); !equalBalances(output, expected) {
+				t.Fatalf("expected %+v, got %+v", expected, output)
+			}
+		})
+	}
+}
+
+func TestWalletPersistence(t *testing.T) {
+	conn := "sqlite://" + filepath.Join(t.TempDir(), "wallet.db")
+	repo, err := factory.NewRepositoryFromConnectionString(context.Background(), conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wallet.New(repo).Deposit(context.Background(), rollups.EtherDeposit{Sender: alice, Value: big.NewInt(5)}); err != nil {
+		t.Fatal(err)
+	}
+	repo.Close()
+
+	repo, err = factory.NewRepositoryFromConnectionString(context.Background(), conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+	balance, err := wallet.New(repo).EtherBalanceOf(context.Background(), alice)
+	if err != nil || balance.Int64() != 5 {
+		t.Fatalf("expected balance of 5 after reopening, got %v, %v", balance, err)
+	}
+}
+
+func TestWalletRejectsOutOfRangeValues(t *testing.T) {
+	srv, repo := setupServer(t, "memory://")
+	advance(t, srv, portal.ERC20Portal, bytes.Join([][]byte{token[:], alice[:], word(50)}, nil), true)
+
+	huge := "1" + strings.Repeat("0", 90)
+	for _, input := range []wallet.OperationInput{
+		{Kind: wallet.ERC20, Token: token, Value: huge},
+		{Kind: wallet.ERC20, Token: token, Value: "-1"},
+		{Kind: wallet.ERC721, Token: token, TokenId: huge},
+	} {
+		res := advance(t, srv, alice, operation("withdraw", input), false)
+		if res.Status != "reject" {
+			t.Fatalf("expected %+v to be rejected, got %q", input, res.Status)
+		}
+	}
+
+	value, _ := new(big.Int).SetString(huge, 10)
+	if _, err := wallet.New(repo).ERC20Withdraw(context.Background(), nil, token, alice, value); !errors.Is(err, wallet.ErrInvalidAmount) {
+		t.Fatalf("expected %v, got %v", wallet.ErrInvalidAmount, err)
+	}
+}
+
+// failingStore fails to save the balances of bob, the receiving end of the
+// transfers below.
+type failingStore struct {
+	wallet.Store
+}
+
+var errSave = errors.New("save failed")
+
+func (s failingStore) SaveBalance(ctx context.Context, balance *wallet.Balance) error {
+	if balance.Owner == bob {
+		return errSave
+	}
+	return s.Store.SaveBalance(ctx, balance)
+}
+
+func TestWalletTransferIsAtomic(t *testing.T) {
+	for name, conn := range connections(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			repo, err := factory.NewRepositoryFromConnectionString(ctx, conn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer repo.Close()
+			w := wallet.New(failingStore{repo})
+			if err := w.Deposit(ctx, rollups.EtherDeposit{Sender: alice, Value: big.NewInt(100)}); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Deposit(ctx, rollups.ERC721Deposit{Token: token, Sender: alice, TokenId: big.NewInt(7)}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := w.EtherTransfer(ctx, alice, bob, big.NewInt(30)); !errors.Is(err, errSave) {
+				t.Fatalf("expected the credit to fail, got %v", err)
+			}
+			if balance, err := w.EtherBalanceOf(ctx, alice); err != nil || balance.Int64() != 100 {
+				t.Fatalf("expected the debit to be rolled back, got %v, %v", balance, err)
+			}
+
+			if err := w.ERC721Transfer(ctx, token, alice, bob, big.NewInt(7)); !errors.Is(err, errSave) {
+				t.Fatalf("expected the credit to fail, got %v", err)
+			}
+			if owner, ok, err := w.ERC721OwnerOf(ctx, token, big.NewInt(7)); err != nil || !ok || owner != alice {
+				t.Fatalf("expected alice to keep the token, got %v, %v, %v", owner, ok, err)
+			}
+		})
+	}
+}
+
+func equalBalances(a, b []wallet.BalanceOutput) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package root
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository/factory"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Manages the database schema migrations",
+	}
+	migrateStatusCmd = &cobra.Command{
+		Use:          "status",
+		Short:        "Lists the schema migrations and whether they have been applied",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         migrateStatus,
+	}
+)
+
+func init() {
+	migrateCmd.AddCommand(migrateStatusCmd)
+	Cmd.AddCommand(migrateCmd)
+}
+
+func migrateStatus(cmd *cobra.Command, args []string) error {
+	statuses, err := factory.MigrationStatusFromConnectionString(cmd.Context(), databaseConn())
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tSTATE\tDESCRIPTION")
+	for _, status := range statuses {
+		state := "pending"
+		if status.Applied {
+			state = "applied"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, state, status.Description)
+	}
+	return w.Flush()
+}
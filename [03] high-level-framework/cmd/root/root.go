@@ -5,13 +5,17 @@ import (
 	"log/slog"
 	"os"
 
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/cartesi/handler/advance"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/cartesi/handler/inspect"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/cartesi/middleware"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository/factory"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/query"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/router"
 	"github.com/rollmelette/rollmelette"
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 )
 
 const (
@@ -20,6 +24,7 @@ const (
 
 var (
 	useMemoryDB bool
+	databaseURL string
 	Cmd         = &cobra.Command{
 		Use:   "voting-" + CMD_NAME,
 		Short: "Runs Voting Rollup",
@@ -35,16 +40,17 @@ func init() {
 		false,
 		"Use in-memory SQLite database instead of persistent",
 	)
+	Cmd.PersistentFlags().StringVar(
+		&databaseURL,
+		"database",
+		"",
+		"Database connection string, such as kv:///mnt/data/voting.kv, overriding --memory-db",
+	)
 }
 
 func run(cmd *cobra.Command, args []string) {
 	ctx := context.Background()
-	repo, err := factory.NewRepositoryFromConnectionString(
-		map[bool]string{
-			true:  "sqlite://:memory:",
-			false: "sqlite:///mnt/data/voting.db",
-		}[useMemoryDB],
-	)
+	repo, err := factory.NewRepositoryFromConnectionString(databaseConn())
 	if err != nil {
 		slog.Error("Failed to setup database", "error", err, "type", map[bool]string{true: "in-memory", false: "persistent"}[useMemoryDB])
 		os.Exit(1)
@@ -60,9 +66,21 @@ func run(cmd *cobra.Command, args []string) {
 	}
 }
 
+func databaseConn() string {
+	if databaseURL != "" {
+		return databaseURL
+	}
+	return map[bool]string{
+		true:  "sqlite://:memory:",
+		false: "sqlite:///mnt/data/voting.db",
+	}[useMemoryDB]
+}
+
 func NewVotingSystem(repo repository.Repository) *router.Router {
+	clock := middleware.NewClock(repo)
+
 	votingAdvanceHandlers := advance.NewVotingAdvanceHandlers(repo)
-	votingInspectHandlers := inspect.NewVotingInspectHandlers(repo, repo)
+	votingInspectHandlers := inspect.NewVotingInspectHandlers(repo, repo, clock)
 
 	voterAdvanceHandlers := advance.NewVoterAdvanceHandlers(repo)
 	voterInspectHandlers := inspect.NewVoterInspectHandlers(repo)
@@ -70,39 +88,64 @@ func NewVotingSystem(repo repository.Repository) *router.Router {
 	votingOptionAdvanceHandlers := advance.NewVotingOptionAdvanceHandlers(repo, repo)
 	votingOptionInspectHandlers := inspect.NewVotingOptionInspectHandlers(repo)
 
+	registerErrorCodes()
+
 	r := router.NewRouter()
 	r.Use(router.LoggingMiddleware)
 	r.Use(router.ErrorHandlingMiddleware)
+	r.Use(middleware.NewTransactionMiddleware(repo))
+	r.Use(clock.Middleware())
 
 	votingGroup := r.Group("voting")
 	{
-		votingGroup.HandleAdvance("create", votingAdvanceHandlers.CreateVoting)
-		votingGroup.HandleAdvance("delete", votingAdvanceHandlers.DeleteVoting)
-		votingGroup.HandleAdvance("vote", votingAdvanceHandlers.Vote)
-		votingGroup.HandleAdvance("update-status", votingAdvanceHandlers.UpdateStatus)
-
-		votingGroup.HandleInspect("", votingInspectHandlers.FindAllVotings)
-		votingGroup.HandleInspect("id", votingInspectHandlers.FindVotingByID)
-		votingGroup.HandleInspect("active", votingInspectHandlers.FindAllActiveVotings)
-		votingGroup.HandleInspect("results", votingInspectHandlers.GetResults)
+		router.HandleAdvanceTyped(votingGroup, "create", "voting created", votingAdvanceHandlers.CreateVoting)
+		router.HandleAdvanceTyped(votingGroup, "delete", "voting deleted", votingAdvanceHandlers.DeleteVoting)
+		router.HandleAdvanceTyped(votingGroup, "vote", "vote registered", votingAdvanceHandlers.Vote)
+
+		router.HandleInspectTyped(votingGroup, "", votingInspectHandlers.FindAllVotings)
+		router.HandleInspectTyped(votingGroup, "id", votingInspectHandlers.FindVotingByID)
+		router.HandleInspectTyped(votingGroup, "active", votingInspectHandlers.FindAllActiveVotings)
+		router.HandleInspectTyped(votingGroup, "results", votingInspectHandlers.GetResults)
+		router.HandleInspectTyped(votingGroup, ":id", votingInspectHandlers.FindVotingByID)
+		router.HandleInspectTyped(votingGroup, ":id/results", votingInspectHandlers.GetResults)
+		router.HandleInspectTyped(votingGroup, ":voting_id/options", votingOptionInspectHandlers.FindAllOptionsByVotingID)
 	}
 
 	voterGroup := r.Group("voter")
 	{
-		voterGroup.HandleAdvance("create", voterAdvanceHandlers.CreateVoter)
-		voterGroup.HandleAdvance("delete", voterAdvanceHandlers.DeleteVoter)
+		router.HandleAdvanceTyped(voterGroup, "create", "voter created", voterAdvanceHandlers.CreateVoter)
+		router.HandleAdvanceTyped(voterGroup, "delete", "voter deleted", voterAdvanceHandlers.DeleteVoter)
 
-		voterGroup.HandleInspect("id", voterInspectHandlers.FindVoterByID)
-		voterGroup.HandleInspect("address", voterInspectHandlers.FindVoterByAddress)
+		router.HandleInspectTyped(voterGroup, "id", voterInspectHandlers.FindVoterByID)
+		router.HandleInspectTyped(voterGroup, "address", voterInspectHandlers.FindVoterByAddress)
+		router.HandleInspectTyped(voterGroup, ":id", voterInspectHandlers.FindVoterByID)
+		router.HandleInspectTyped(voterGroup, "address/:address", voterInspectHandlers.FindVoterByAddress)
 	}
 
 	votingOptionGroup := r.Group("voting-option")
 	{
-		votingOptionGroup.HandleAdvance("create", votingOptionAdvanceHandlers.CreateVotingOption)
-		votingOptionGroup.HandleAdvance("delete", votingOptionAdvanceHandlers.DeleteVotingOption)
+		router.HandleAdvanceTyped(votingOptionGroup, "create", "voting option created", votingOptionAdvanceHandlers.CreateVotingOption)
+		router.HandleAdvanceTyped(votingOptionGroup, "delete", "voting option deleted", votingOptionAdvanceHandlers.DeleteVotingOption)
 
-		votingOptionGroup.HandleInspect("id", votingOptionInspectHandlers.FindVotingOptionByID)
-		votingOptionGroup.HandleInspect("voting", votingOptionInspectHandlers.FindAllOptionsByVotingID)
+		router.HandleInspectTyped(votingOptionGroup, "id", votingOptionInspectHandlers.FindVotingOptionByID)
+		router.HandleInspectTyped(votingOptionGroup, "voting", votingOptionInspectHandlers.FindAllOptionsByVotingID)
+		router.HandleInspectTyped(votingOptionGroup, ":id", votingOptionInspectHandlers.FindVotingOptionByID)
 	}
 	return r
 }
+
+func registerErrorCodes() {
+	router.RegisterErrorCode(gorm.ErrRecordNotFound, "not_found")
+	router.RegisterErrorCode(domain.ErrUnauthorized, "unauthorized")
+	router.RegisterErrorCode(domain.ErrInvalidVoting, "invalid_voting")
+	router.RegisterErrorCode(domain.ErrVotingNotFound, "voting_not_found")
+	router.RegisterErrorCode(domain.ErrVotingClosed, "voting_closed")
+	router.RegisterErrorCode(domain.ErrVotingPending, "voting_pending")
+	router.RegisterErrorCode(domain.ErrAlreadyVoted, "already_voted")
+	router.RegisterErrorCode(domain.ErrInvalidVoter, "invalid_voter")
+	router.RegisterErrorCode(domain.ErrVoterNotFound, "voter_not_found")
+	router.RegisterErrorCode(domain.ErrInvalidVotingOption, "invalid_voting_option")
+	router.RegisterErrorCode(domain.ErrOptionNotFound, "voting_option_not_found")
+	router.RegisterErrorCode(domain.ErrInvalidOption, "invalid_option")
+	router.RegisterErrorCode(query.ErrInvalidQuery, "invalid_query")
+}
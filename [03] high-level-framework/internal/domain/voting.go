@@ -9,16 +9,20 @@ import (
 )
 
 var (
-	ErrInvalidVoting = errors.New("invalid voting")
-	ErrVotingClosed  = errors.New("voting is closed")
-	ErrAlreadyVoted  = errors.New("voter has already voted in this voting")
+	ErrInvalidVoting  = errors.New("invalid voting")
+	ErrVotingNotFound = errors.New("voting not found")
+	ErrVotingClosed   = errors.New("voting is closed")
+	ErrVotingPending  = errors.New("voting has not started")
+	ErrAlreadyVoted   = errors.New("voter has already voted in this voting")
+	ErrUnauthorized   = errors.New("unauthorized")
 )
 
 type VotingStatus string
 
 const (
-	VotingStatusOpen   VotingStatus = "open"
-	VotingStatusClosed VotingStatus = "closed"
+	VotingStatusPending VotingStatus = "pending"
+	VotingStatusOpen    VotingStatus = "open"
+	VotingStatusClosed  VotingStatus = "closed"
 )
 
 type Voting struct {
@@ -27,25 +31,49 @@ type Voting struct {
 	Creator   Address         `gorm:"not null"`
 	StartDate time.Time       `gorm:"not null;index"`
 	EndDate   time.Time       `gorm:"not null;index"`
-	Status    VotingStatus    `gorm:"not null;type:string;default:'open'"`
+	Status    VotingStatus    `gorm:"not null;type:string;default:'pending'"`
 	Options   []*VotingOption `gorm:"foreignKey:VotingID"`
 }
 
-func NewVoting(title string, Creator Address, startDate, endDate time.Time) (*Voting, error) {
+// NewVoting creates a voting at now, the timestamp of the block of the input
+// creating it.
+func NewVoting(title string, Creator Address, startDate, endDate time.Time, now time.Time) (*Voting, error) {
 	voting := &Voting{
 		Title:     title,
 		Creator:   Creator,
 		StartDate: startDate,
 		EndDate:   endDate,
-		Status:    VotingStatusOpen,
 		Options:   make([]*VotingOption, 0),
 	}
-	if err := voting.validate(); err != nil {
+	voting.Status = voting.StatusAt(now)
+	if err := voting.validate(now); err != nil {
 		return nil, err
 	}
 	return voting, nil
 }
 
+// StatusAt returns the status of v at now: pending before its start date,
+// open until its end date and closed from then on.
+func (v *Voting) StatusAt(now time.Time) VotingStatus {
+	switch {
+	case now.Before(v.StartDate):
+		return VotingStatusPending
+	case now.Before(v.EndDate):
+		return VotingStatusOpen
+	}
+	return VotingStatusClosed
+}
+
+// Refresh brings the status of v up to now, reporting whether it changed.
+func (v *Voting) Refresh(now time.Time) bool {
+	status := v.StatusAt(now)
+	if status == v.Status {
+		return false
+	}
+	v.Status = status
+	return true
+}
+
 func (v *Voting) GetStartDateUnix() int64 {
 	return v.StartDate.Unix()
 }
@@ -54,20 +82,20 @@ func (v *Voting) GetEndDateUnix() int64 {
 	return v.EndDate.Unix()
 }
 
-func (v *Voting) validate() error {
+func (v *Voting) validate(now time.Time) error {
 	if v.Title == "" {
 		return fmt.Errorf("%w: title cannot be empty", ErrInvalidVoting)
 	}
 	if v.StartDate.After(v.EndDate) {
 		return fmt.Errorf("%w: start date must be before end date", ErrInvalidVoting)
 	}
-	if v.StartDate.Before(time.Now()) {
+	if v.StartDate.Before(now) {
 		return fmt.Errorf("%w: start date must be in the future", ErrInvalidVoting)
 	}
 	if v.Creator == (Address{}) {
 		return fmt.Errorf("%w: Creator cannot be empty", ErrInvalidVoting)
 	}
-	if v.Status != VotingStatusOpen && v.Status != VotingStatusClosed {
+	if v.Status != VotingStatusPending && v.Status != VotingStatusOpen && v.Status != VotingStatusClosed {
 		return fmt.Errorf("%w: invalid status", ErrInvalidVoting)
 	}
 	return nil
@@ -2,12 +2,11 @@ package advance
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/usecase/voter"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/router"
 	"github.com/rollmelette/rollmelette"
 )
 
@@ -21,42 +20,20 @@ func NewVoterAdvanceHandlers(voterRepository repository.VoterRepository) *VoterA
 	}
 }
 
-func (h *VoterAdvanceHandlers) CreateVoter(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	ctx := context.Background()
+func (h *VoterAdvanceHandlers) CreateVoter(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *router.Empty) (*voter.CreateVoterOutputDTO, error) {
 	createVoter := voter.NewCreateVoterUseCase(h.VoterRepository)
 	res, err := createVoter.Execute(ctx, &metadata)
 	if err != nil {
-		return fmt.Errorf("failed to create voter: %w", err)
+		return nil, fmt.Errorf("failed to create voter: %w", err)
 	}
-	voterBytes, err := json.Marshal(res)
-	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
-	}
-	env.Notice(append([]byte("voter created - "), voterBytes...))
-	return nil
+	return res, nil
 }
 
-func (h *VoterAdvanceHandlers) DeleteVoter(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input voter.DeleteVoterInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *VoterAdvanceHandlers) DeleteVoter(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *voter.DeleteVoterInputDTO) (*voter.DeleteVoterOutputDTO, error) {
 	deleteVoter := voter.NewDeleteVoterUseCase(h.VoterRepository)
-	res, err := deleteVoter.Execute(ctx, &input, &metadata)
-	if err != nil {
-		return fmt.Errorf("failed to delete voter: %w", err)
-	}
-	voterBytes, err := json.Marshal(res)
+	res, err := deleteVoter.Execute(ctx, input, &metadata)
 	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
+		return nil, fmt.Errorf("failed to delete voter: %w", err)
 	}
-	env.Notice(append([]byte("voter deleted - "), voterBytes...))
-	return nil
+	return res, nil
 }
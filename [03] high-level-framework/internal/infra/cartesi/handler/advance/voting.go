@@ -2,10 +2,8 @@ package advance
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/usecase/voting"
 	"github.com/rollmelette/rollmelette"
@@ -27,84 +25,27 @@ func NewVotingAdvanceHandlers(repo repository.Repository) *VotingAdvanceHandlers
 	}
 }
 
-func (h *VotingAdvanceHandlers) CreateVoting(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input voting.CreateVotingInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
-	res, err := h.CreateVotingUseCase.Execute(ctx, &input, &metadata)
-	if err != nil {
-		return fmt.Errorf("failed to create voting: %w", err)
-	}
-	votingBytes, err := json.Marshal(res)
+func (h *VotingAdvanceHandlers) CreateVoting(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *voting.CreateVotingInputDTO) (*voting.CreateVotingOutputDTO, error) {
+	res, err := h.CreateVotingUseCase.Execute(ctx, input, &metadata)
 	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
+		return nil, fmt.Errorf("failed to create voting: %w", err)
 	}
-	env.Notice(append([]byte("voting created - "), votingBytes...))
-	return nil
+	return res, nil
 }
 
-func (h *VotingAdvanceHandlers) DeleteVoting(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input voting.DeleteVotingInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
-	res, err := h.DeleteVotingUseCase.Execute(ctx, &input, &metadata)
+func (h *VotingAdvanceHandlers) DeleteVoting(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *voting.DeleteVotingInputDTO) (*voting.DeleteVotingOutputDTO, error) {
+	res, err := h.DeleteVotingUseCase.Execute(ctx, input, &metadata)
 	if err != nil {
-		return fmt.Errorf("failed to delete voting: %w", err)
+		return nil, fmt.Errorf("failed to delete voting: %w", err)
 	}
-	votingBytes, err := json.Marshal(res)
-	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
-	}
-	env.Notice(append([]byte("voting deleted - "), votingBytes...))
-	return nil
+	return res, nil
 }
 
-func (h *VotingAdvanceHandlers) Vote(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input voting.VoteInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	res, err := h.VoteUseCase.Execute(input, &metadata)
+func (h *VotingAdvanceHandlers) Vote(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *voting.VoteInputDTO) (*voting.VoteOutputDTO, error) {
+	res, err := h.VoteUseCase.Execute(ctx, *input, &metadata)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	voteBytes, err := json.Marshal(res)
-	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
-	}
-	env.Notice(append([]byte("vote registered - "), voteBytes...))
-	return nil
-}
-
-func (h *VotingAdvanceHandlers) UpdateStatus(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	ctx := context.Background()
-	updateVotingStatus := voting.NewUpdateVotingStatusUseCase(h.VotingRepository)
-	if err := updateVotingStatus.Execute(ctx); err != nil {
-		return fmt.Errorf("failed to update voting status: %w", err)
-	}
-	env.Notice([]byte("voting status updated"))
-	return nil
+	return res, nil
 }
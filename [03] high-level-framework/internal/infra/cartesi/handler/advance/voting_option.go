@@ -2,10 +2,8 @@ package advance
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/usecase/voting_option"
 	"github.com/rollmelette/rollmelette"
@@ -23,52 +21,20 @@ func NewVotingOptionAdvanceHandlers(votingRepository repository.VotingRepository
 	}
 }
 
-func (h *VotingOptionAdvanceHandlers) CreateVotingOption(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input voting_option.CreateVotingOptionInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *VotingOptionAdvanceHandlers) CreateVotingOption(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *voting_option.CreateVotingOptionInputDTO) (*voting_option.CreateVotingOptionOutputDTO, error) {
 	createVotingOption := voting_option.NewCreateVotingOptionUseCase(h.VotingRepository, h.VotingOptionRepository)
-	res, err := createVotingOption.Execute(ctx, &input, &metadata)
-	if err != nil {
-		return err
-	}
-	votingOptionBytes, err := json.Marshal(res)
+	res, err := createVotingOption.Execute(ctx, input, &metadata)
 	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
+		return nil, err
 	}
-	env.Notice(append([]byte("voting option created - "), votingOptionBytes...))
-	return nil
+	return res, nil
 }
 
-func (h *VotingOptionAdvanceHandlers) DeleteVotingOption(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input voting_option.DeleteVotingOptionInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *VotingOptionAdvanceHandlers) DeleteVotingOption(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *voting_option.DeleteVotingOptionInputDTO) (*voting_option.DeleteVotingOptionOutputDTO, error) {
 	deleteVotingOption := voting_option.NewDeleteVotingOptionUseCase(h.VotingOptionRepository)
-	res, err := deleteVotingOption.Execute(ctx, &input, &metadata)
-	if err != nil {
-		return fmt.Errorf("failed to delete voting option: %w", err)
-	}
-	votingOptionBytes, err := json.Marshal(res)
+	res, err := deleteVotingOption.Execute(ctx, input, &metadata)
 	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
+		return nil, fmt.Errorf("failed to delete voting option: %w", err)
 	}
-	env.Notice(append([]byte("voting option deleted - "), votingOptionBytes...))
-	return nil
+	return res, nil
 }
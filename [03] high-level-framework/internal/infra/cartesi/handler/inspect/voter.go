@@ -2,10 +2,8 @@ package inspect
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/usecase/voter"
 	"github.com/rollmelette/rollmelette"
@@ -21,52 +19,20 @@ func NewVoterInspectHandlers(voterRepository repository.VoterRepository) *VoterI
 	}
 }
 
-func (h *VoterInspectHandlers) FindVoterByID(env rollmelette.EnvInspector, payload []byte) error {
-	var input voter.FindVoterByIDInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *VoterInspectHandlers) FindVoterByID(ctx context.Context, env rollmelette.EnvInspector, input *voter.FindVoterByIDInputDTO) (*voter.FindVoterByIDOutputDTO, error) {
 	findVoterByID := voter.NewFindVoterByIDUseCase(h.VoterRepository)
-	voterRes, err := findVoterByID.Execute(ctx, &input)
-	if err != nil {
-		return fmt.Errorf("failed to find voter by id: %w", err)
-	}
-	voterBytes, err := json.Marshal(voterRes)
+	voterRes, err := findVoterByID.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal voter: %w", err)
+		return nil, fmt.Errorf("failed to find voter by id: %w", err)
 	}
-	env.Report(voterBytes)
-	return nil
+	return voterRes, nil
 }
 
-func (h *VoterInspectHandlers) FindVoterByAddress(env rollmelette.EnvInspector, payload []byte) error {
-	var input voter.FindVoterByAddressInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *VoterInspectHandlers) FindVoterByAddress(ctx context.Context, env rollmelette.EnvInspector, input *voter.FindVoterByAddressInputDTO) (*voter.FindVoterByAddressOutputDTO, error) {
 	findVoterByAddress := voter.NewFindVoterByAddressUseCase(h.VoterRepository)
-	voterRes, err := findVoterByAddress.Execute(ctx, &input)
-	if err != nil {
-		return fmt.Errorf("failed to find voter by address: %w", err)
-	}
-	voterBytes, err := json.Marshal(voterRes)
+	voterRes, err := findVoterByAddress.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal voter: %w", err)
+		return nil, fmt.Errorf("failed to find voter by address: %w", err)
 	}
-	env.Report(voterBytes)
-	return nil
+	return voterRes, nil
 }
@@ -2,130 +2,87 @@ package inspect
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/go-playground/validator/v10"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/cartesi/middleware"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/usecase/voting"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/router"
 	"github.com/rollmelette/rollmelette"
 )
 
 type VotingInspectHandlers struct {
 	VotingRepository       repository.VotingRepository
 	VotingOptionRepository repository.VotingOptionRepository
+	Clock                  *middleware.Clock
 }
 
-func NewVotingInspectHandlers(votingRepository repository.VotingRepository, votingOptionRepository repository.VotingOptionRepository) *VotingInspectHandlers {
+func NewVotingInspectHandlers(votingRepository repository.VotingRepository, votingOptionRepository repository.VotingOptionRepository, clock *middleware.Clock) *VotingInspectHandlers {
 	return &VotingInspectHandlers{
 		VotingRepository:       votingRepository,
 		VotingOptionRepository: votingOptionRepository,
+		Clock:                  clock,
 	}
 }
 
-func (h *VotingInspectHandlers) FindAllVotings(env rollmelette.EnvInspector, payload []byte) error {
-	ctx := context.Background()
+func (h *VotingInspectHandlers) FindAllVotings(ctx context.Context, env rollmelette.EnvInspector, input *voting.FindAllVotingsInputDTO) (*voting.FindAllVotingsOutputDTO, error) {
 	findAllVotings := voting.NewFindAllVotingsUseCase(h.VotingRepository)
-	votings, err := findAllVotings.Execute(ctx)
+	now, err := h.Clock.Now(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to find all votings: %w", err)
+		return nil, err
 	}
-	votingsBytes, err := json.Marshal(votings)
+	votings, err := findAllVotings.Execute(ctx, input, now)
 	if err != nil {
-		return fmt.Errorf("failed to marshal votings: %w", err)
+		return nil, fmt.Errorf("failed to find all votings: %w", err)
 	}
-	env.Report(votingsBytes)
-	return nil
+	return votings, nil
 }
 
-func (h *VotingInspectHandlers) FindVotingByID(env rollmelette.EnvInspector, payload []byte) error {
-	var input voting.FindVotingByIDInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *VotingInspectHandlers) FindVotingByID(ctx context.Context, env rollmelette.EnvInspector, input *voting.FindVotingByIDInputDTO) (*voting.FindVotingByIDOutputDTO, error) {
 	findVotingByID := voting.NewFindVotingByIDUseCase(h.VotingRepository)
-	votingRes, err := findVotingByID.Execute(ctx, &input)
+	now, err := h.Clock.Now(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to find voting by id: %w", err)
+		return nil, err
 	}
-	votingBytes, err := json.Marshal(votingRes)
+	votingRes, err := findVotingByID.Execute(ctx, input, now)
 	if err != nil {
-		return fmt.Errorf("failed to marshal voting: %w", err)
+		return nil, fmt.Errorf("failed to find voting by id: %w", err)
 	}
-	env.Report(votingBytes)
-	return nil
+	return votingRes, nil
 }
 
-func (h *VotingInspectHandlers) FindAllActiveVotings(env rollmelette.EnvInspector, payload []byte) error {
-	ctx := context.Background()
+func (h *VotingInspectHandlers) FindAllActiveVotings(ctx context.Context, env rollmelette.EnvInspector, input *router.Empty) ([]*voting.FindAllActiveVotingsOutputDTO, error) {
 	findAllActiveVotings := voting.NewFindAllActiveVotingsUseCase(h.VotingRepository)
-	votings, err := findAllActiveVotings.Execute(ctx)
+	now, err := h.Clock.Now(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to find all active votings: %w", err)
+		return nil, err
 	}
-	votingsBytes, err := json.Marshal(votings)
+	votings, err := findAllActiveVotings.Execute(ctx, now)
 	if err != nil {
-		return fmt.Errorf("failed to marshal votings: %w", err)
+		return nil, fmt.Errorf("failed to find all active votings: %w", err)
 	}
-	env.Report(votingsBytes)
-	return nil
+	return votings, nil
 }
 
-func (h *VotingInspectHandlers) GetVotingResults(env rollmelette.EnvInspector, payload []byte) error {
-	var input voting.GetVotingResultsInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *VotingInspectHandlers) GetVotingResults(ctx context.Context, env rollmelette.EnvInspector, input *voting.GetVotingResultsInputDTO) (*voting.GetVotingResultsOutputDTO, error) {
 	getResults := voting.NewGetVotingResultsUseCase(h.VotingOptionRepository)
-	results, err := getResults.Execute(ctx, &input)
+	results, err := getResults.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to get voting results: %w", err)
+		return nil, fmt.Errorf("failed to get voting results: %w", err)
 	}
-	resultsBytes, err := json.Marshal(results)
-	if err != nil {
-		return fmt.Errorf("failed to marshal results: %w", err)
-	}
-	env.Report(resultsBytes)
-	return nil
+	return results, nil
 }
 
-func (h *VotingInspectHandlers) GetResults(env rollmelette.EnvInspector, payload []byte) error {
-	var input voting.GetResultsInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *VotingInspectHandlers) GetResults(ctx context.Context, env rollmelette.EnvInspector, input *voting.GetResultsInputDTO) (*voting.GetResultsOutputDTO, error) {
 	getResults := voting.NewGetResultsUseCase(h.VotingRepository)
-	result, err := getResults.Execute(ctx, &input)
+	now, err := h.Clock.Now(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get voting results: %w", err)
+		return nil, err
 	}
-
-	resultBytes, err := json.Marshal(result)
+	result, err := getResults.Execute(ctx, input, now)
 	if err != nil {
-		return fmt.Errorf("failed to marshal result: %w", err)
+		return nil, fmt.Errorf("failed to get voting results: %w", err)
 	}
 
-	env.Report(resultBytes)
-	return nil
+	return result, nil
 }
@@ -2,10 +2,8 @@ package inspect
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/usecase/voting_option"
 	"github.com/rollmelette/rollmelette"
@@ -21,52 +19,20 @@ func NewVotingOptionInspectHandlers(votingOptionRepository repository.VotingOpti
 	}
 }
 
-func (h *VotingOptionInspectHandlers) FindVotingOptionByID(env rollmelette.EnvInspector, payload []byte) error {
-	var input voting_option.FindVotingOptionByIDInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *VotingOptionInspectHandlers) FindVotingOptionByID(ctx context.Context, env rollmelette.EnvInspector, input *voting_option.FindVotingOptionByIDInputDTO) (*voting_option.FindVotingOptionByIDOutputDTO, error) {
 	findVotingOptionByID := voting_option.NewFindVotingOptionByIDUseCase(h.VotingOptionRepository)
-	votingOptionRes, err := findVotingOptionByID.Execute(ctx, &input)
-	if err != nil {
-		return fmt.Errorf("failed to find voting option by id: %w", err)
-	}
-	votingOptionBytes, err := json.Marshal(votingOptionRes)
+	votingOptionRes, err := findVotingOptionByID.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal voting option: %w", err)
+		return nil, fmt.Errorf("failed to find voting option by id: %w", err)
 	}
-	env.Report(votingOptionBytes)
-	return nil
+	return votingOptionRes, nil
 }
 
-func (h *VotingOptionInspectHandlers) FindAllOptionsByVotingID(env rollmelette.EnvInspector, payload []byte) error {
-	var input voting_option.FindAllOptionsByVotingIDInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *VotingOptionInspectHandlers) FindAllOptionsByVotingID(ctx context.Context, env rollmelette.EnvInspector, input *voting_option.FindAllOptionsByVotingIDInputDTO) ([]*voting_option.FindAllOptionsByVotingIDOutputDTO, error) {
 	findAllOptionsByVotingID := voting_option.NewFindAllOptionsByVotingIDUseCase(h.VotingOptionRepository)
-	options, err := findAllOptionsByVotingID.Execute(ctx, &input)
-	if err != nil {
-		return fmt.Errorf("failed to find all options by voting id: %w", err)
-	}
-	optionsBytes, err := json.Marshal(options)
+	options, err := findAllOptionsByVotingID.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal options: %w", err)
+		return nil, fmt.Errorf("failed to find all options by voting id: %w", err)
 	}
-	env.Report(optionsBytes)
-	return nil
+	return options, nil
 }
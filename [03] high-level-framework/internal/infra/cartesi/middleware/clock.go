@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/router"
+	"github.com/rollmelette/rollmelette"
+)
+
+// Clock tells time by the block timestamp of the inputs, so every node sees
+// the same votings open and close. Inspect requests carry no metadata and are
+// answered at the timestamp of the last advance, which is stored so that it
+// outlives a restart.
+type Clock struct {
+	repo repository.ClockRepository
+	last atomic.Int64
+}
+
+func NewClock(repo repository.ClockRepository) *Clock {
+	return &Clock{repo: repo}
+}
+
+// Now returns the latest block timestamp seen, or the zero unix time before
+// the first advance. After a restart it is read back from the repository.
+func (c *Clock) Now(ctx context.Context) (time.Time, error) {
+	if last := c.last.Load(); last != 0 {
+		return time.Unix(last, 0), nil
+	}
+	last, err := c.repo.FindLastBlockTimestamp(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	c.last.CompareAndSwap(0, last)
+	return time.Unix(last, 0), nil
+}
+
+// Middleware records the block timestamp of every advance.
+func (c *Clock) Middleware() router.Middleware {
+	return router.Middleware{
+		Name: "clock",
+		Advance: func(next router.AdvanceHandlerFunc) router.AdvanceHandlerFunc {
+			return func(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
+				now, err := c.Now(ctx)
+				if err != nil {
+					return err
+				}
+				if metadata.BlockTimestamp > now.Unix() {
+					if err := c.repo.SaveLastBlockTimestamp(ctx, metadata.BlockTimestamp); err != nil {
+						return err
+					}
+					c.last.Store(metadata.BlockTimestamp)
+				}
+				return next(ctx, env, metadata, deposit, payload)
+			}
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/router"
+	"github.com/rollmelette/rollmelette"
+)
+
+// NewTransactionMiddleware runs every advance input in a database transaction,
+// committed when the input is accepted and rolled back when it is rejected, so
+// the database is reverted along with the rollmelette wallet.
+func NewTransactionMiddleware(transactor repository.Transactor) router.Middleware {
+	return router.Middleware{
+		Name: "transaction",
+		Advance: func(next router.AdvanceHandlerFunc) router.AdvanceHandlerFunc {
+			return func(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
+				return transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+					return next(ctx, env, metadata, deposit, payload)
+				})
+			}
+		},
+	}
+}
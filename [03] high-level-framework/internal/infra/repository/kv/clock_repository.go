@@ -0,0 +1,30 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	clockBucket           = []byte("clock")
+	lastBlockTimestampKey = []byte("last_block_timestamp")
+)
+
+func (r *KVRepository) FindLastBlockTimestamp(ctx context.Context) (int64, error) {
+	var timestamp int64
+	err := r.view(ctx, func(tx *bolt.Tx) error {
+		if data := tx.Bucket(clockBucket).Get(lastBlockTimestampKey); data != nil {
+			timestamp = int64(binary.BigEndian.Uint64(data))
+		}
+		return nil
+	})
+	return timestamp, err
+}
+
+func (r *KVRepository) SaveLastBlockTimestamp(ctx context.Context, timestamp int64) error {
+	return r.update(ctx, func(tx *bolt.Tx) error {
+		return tx.Bucket(clockBucket).Put(lastBlockTimestampKey, binary.BigEndian.AppendUint64(nil, uint64(timestamp)))
+	})
+}
@@ -0,0 +1,23 @@
+package kv_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository/kv"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository/repositorytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestConformance(t *testing.T) {
+	dir := t.TempDir()
+	var n int
+	suite.Run(t, &repositorytest.Suite{
+		Open: func() (repository.Repository, error) {
+			n++
+			return kv.NewKVRepository("kv://" + filepath.Join(dir, fmt.Sprintf("%d.kv", n)))
+		},
+	})
+}
@@ -0,0 +1,72 @@
+// Package kv implements the repository on bbolt, an embedded key-value store
+// written in pure Go, so the application can run without CGO.
+package kv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+type KVRepository struct {
+	Db *bolt.DB
+}
+
+func (r *KVRepository) Close() error {
+	return r.Db.Close()
+}
+
+func NewKVRepository(conn string) (*KVRepository, error) {
+	db, err := bolt.Open(strings.TrimPrefix(conn, "kv://"), 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, names := range [][][]byte{votings.buckets(), options.buckets(), voters.buckets(), {clockBucket}} {
+			for _, name := range names {
+				if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+					return fmt.Errorf("failed to create bucket %s: %w", name, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &KVRepository{Db: db}, nil
+}
+
+type txKey struct{}
+
+// RunInTransaction calls fn with a context carrying a read-write transaction,
+// so every repository method called with it joins the transaction. The
+// transaction is committed when fn returns nil and rolled back otherwise.
+func (r *KVRepository) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.update(ctx, func(tx *bolt.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// view runs fn in the transaction carried by ctx, or in a read-only one
+// outside of it.
+func (r *KVRepository) view(ctx context.Context, fn func(tx *bolt.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*bolt.Tx); ok {
+		return fn(tx)
+	}
+	return r.Db.View(fn)
+}
+
+// update runs fn in the transaction carried by ctx, or in a read-write one
+// outside of it. bbolt allows a single writer, so a nested update would block.
+func (r *KVRepository) update(ctx context.Context, fn func(tx *bolt.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*bolt.Tx); ok {
+		return fn(tx)
+	}
+	return r.Db.Update(fn)
+}
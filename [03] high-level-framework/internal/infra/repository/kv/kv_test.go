@@ -0,0 +1,133 @@
+package kv
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
+	. "github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/query"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+var (
+	creator = HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	voterA  = HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+)
+
+func TestKVSuite(t *testing.T) {
+	suite.Run(t, new(KVSuite))
+}
+
+type KVSuite struct {
+	suite.Suite
+	ctx  context.Context
+	path string
+	repo *KVRepository
+	now  time.Time
+}
+
+func (s *KVSuite) SetupTest() {
+	s.path = "kv://" + filepath.Join(s.T().TempDir(), "voting.kv")
+	repo, err := NewKVRepository(s.path)
+	s.Require().NoError(err)
+	s.ctx = context.Background()
+	s.repo = repo
+	s.now = time.Unix(1000, 0)
+}
+
+func (s *KVSuite) TearDownTest() {
+	s.Require().NoError(s.repo.Close())
+}
+
+func (s *KVSuite) createVoting(title string, start, end int64) *domain.Voting {
+	voting, err := domain.NewVoting(title, creator, time.Unix(start, 0), time.Unix(end, 0), s.now)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.CreateVoting(s.ctx, voting))
+	return voting
+}
+
+func (s *KVSuite) TestMissingRecordsAreNotFound() {
+	_, err := s.repo.FindVotingByID(s.ctx, 1)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	_, err = s.repo.FindOptionByID(s.ctx, 1)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	_, err = s.repo.FindVoterByAddress(s.ctx, voterA)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *KVSuite) TestVotingLoadsItsOptions() {
+	voting := s.createVoting("first", 1100, 1200)
+	other := s.createVoting("second", 1100, 1200)
+	for _, votingID := range []int{voting.ID, voting.ID, other.ID} {
+		option, err := domain.NewVotingOption(votingID)
+		s.Require().NoError(err)
+		s.Require().NoError(s.repo.CreateOption(s.ctx, option))
+	}
+
+	found, err := s.repo.FindVotingByID(s.ctx, voting.ID)
+	s.Require().NoError(err)
+	s.Len(found.Options, 2)
+
+	options, err := s.repo.FindAllOptionsByVotingID(s.ctx, other.ID)
+	s.Require().NoError(err)
+	s.Require().Len(options, 1)
+	s.Equal("second", options[0].Voting.Title)
+}
+
+func (s *KVSuite) TestVotingsFilterByStatusAtNow() {
+	s.createVoting("b", 1100, 1200)
+	s.createVoting("a", 1300, 1400)
+
+	now := time.Unix(1150, 0)
+	open, total, err := s.repo.FindAllVotings(s.ctx, query.Query{Limit: 10, Sort: "id", Order: query.Asc, State: string(domain.VotingStatusOpen)}, now)
+	s.Require().NoError(err)
+	s.Equal(int64(1), total)
+	s.Equal("b", open[0].Title)
+
+	byTitle, total, err := s.repo.FindAllVotings(s.ctx, query.Query{Limit: 10, Sort: "title", Order: query.Asc}, now)
+	s.Require().NoError(err)
+	s.Equal(int64(2), total)
+	s.Equal("a", byTitle[0].Title)
+
+	active, err := s.repo.FindAllActiveVotings(s.ctx, now)
+	s.Require().NoError(err)
+	s.Len(active, 1)
+}
+
+func (s *KVSuite) TestVoterAddressIsUniqueAndVotesAreTracked() {
+	voter, err := domain.NewVoter(voterA)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.CreateVoter(s.ctx, voter))
+	duplicate, err := domain.NewVoter(voterA)
+	s.Require().NoError(err)
+	s.Error(s.repo.CreateVoter(s.ctx, duplicate))
+
+	voting := s.createVoting("first", 1100, 1200)
+	option, err := domain.NewVotingOption(voting.ID)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.CreateOption(s.ctx, option))
+
+	voted, err := s.repo.HasVoted(s.ctx, voter.ID, voting.ID)
+	s.Require().NoError(err)
+	s.False(voted)
+
+	s.Require().NoError(s.repo.IncrementVoteCount(s.ctx, option.ID, voter.ID))
+	voted, err = s.repo.HasVoted(s.ctx, voter.ID, voting.ID)
+	s.Require().NoError(err)
+	s.True(voted)
+	option, err = s.repo.FindOptionByID(s.ctx, option.ID)
+	s.Require().NoError(err)
+	s.Equal(1, option.VoteCount)
+}
+
+func (s *KVSuite) TestSavedIdsAreNotReused() {
+	s.Require().NoError(s.repo.UpdateVoter(s.ctx, &domain.Voter{ID: 5, Address: voterA}))
+	voter, err := domain.NewVoter(creator)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.CreateVoter(s.ctx, voter))
+	s.Equal(6, voter.ID)
+}
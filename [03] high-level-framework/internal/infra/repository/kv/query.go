@@ -0,0 +1,59 @@
+package kv
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/query"
+)
+
+// columns describes how findPage reads the records of a table: their id, the
+// time column From and To bound, and how every sortable column compares.
+type columns[T any] struct {
+	id   func(*T) int
+	time func(*T) time.Time
+	sort map[string]func(a, b *T) int
+}
+
+// findPage returns the page of records selected by the normalized query q and
+// how many records match its filters. records are expected to be already
+// filtered by state and in id order.
+func findPage[T any](records []*T, q query.Query, cols columns[T]) ([]*T, int64) {
+	records = slices.DeleteFunc(records, func(v *T) bool {
+		t := cols.time(v).Unix()
+		return (q.From != 0 && t < q.From) || (q.To != 0 && t > q.To)
+	})
+	total := int64(len(records))
+
+	desc := q.Order == query.Desc
+	if q.Cursor != 0 {
+		records = slices.DeleteFunc(records, func(v *T) bool {
+			if desc {
+				return cols.id(v) >= int(q.Cursor)
+			}
+			return cols.id(v) <= int(q.Cursor)
+		})
+	}
+	compare := cols.sort[q.Sort]
+	slices.SortStableFunc(records, func(a, b *T) int {
+		c := compare(a, b)
+		if c == 0 {
+			c = cmp.Compare(cols.id(a), cols.id(b))
+		}
+		if desc {
+			return -c
+		}
+		return c
+	})
+
+	start := min(q.Offset, len(records))
+	end := min(start+q.Limit, len(records))
+	return records[start:end], total
+}
+
+func orderedColumn[T any, V cmp.Ordered](field func(*T) V) func(a, b *T) int {
+	return func(a, b *T) int {
+		return cmp.Compare(field(a), field(b))
+	}
+}
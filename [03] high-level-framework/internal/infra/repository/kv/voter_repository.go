@@ -0,0 +1,107 @@
+package kv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
+	. "github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/custom_type"
+	bolt "go.etcd.io/bbolt"
+)
+
+var errDuplicateAddress = errors.New("address already registered")
+
+var (
+	votersByAddress = &index[domain.Voter]{
+		name: []byte("voters_by_address"),
+		key:  func(v *domain.Voter) []byte { return v.Address[:] },
+	}
+	voters = &table[domain.Voter]{
+		name:    []byte("voters"),
+		id:      func(v *domain.Voter) int { return v.ID },
+		setID:   func(v *domain.Voter, id int) { v.ID = id },
+		indexes: []*index[domain.Voter]{votersByAddress},
+	}
+)
+
+func (r *KVRepository) CreateVoter(ctx context.Context, voter *domain.Voter) error {
+	return r.update(ctx, func(tx *bolt.Tx) error {
+		// Addresses are unique, as the unique index of the SQLite backend
+		// enforces.
+		existing, err := voters.lookup(tx, votersByAddress, voter.Address[:])
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return errDuplicateAddress
+		}
+		return voters.insert(tx, voter)
+	})
+}
+
+func (r *KVRepository) FindVoterByID(ctx context.Context, id int) (*domain.Voter, error) {
+	var voter *domain.Voter
+	err := r.view(ctx, func(tx *bolt.Tx) (err error) {
+		voter, err = voters.get(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return voter, nil
+}
+
+func (r *KVRepository) FindVoterByAddress(ctx context.Context, address Address) (*domain.Voter, error) {
+	var voter *domain.Voter
+	err := r.view(ctx, func(tx *bolt.Tx) error {
+		records, err := voters.lookup(tx, votersByAddress, address[:])
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return errNotFound
+		}
+		voter = records[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return voter, nil
+}
+
+func (r *KVRepository) UpdateVoter(ctx context.Context, voter *domain.Voter) error {
+	return r.update(ctx, func(tx *bolt.Tx) error {
+		return voters.save(tx, voter)
+	})
+}
+
+func (r *KVRepository) DeleteVoter(ctx context.Context, id int) error {
+	return r.update(ctx, func(tx *bolt.Tx) error {
+		voter, err := voters.get(tx, id)
+		if errors.Is(err, errNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return voters.delete(tx, voter)
+	})
+}
+
+func (r *KVRepository) HasVoted(ctx context.Context, voterID, votingID int) (bool, error) {
+	var voted bool
+	err := r.view(ctx, func(tx *bolt.Tx) error {
+		records, err := options.lookup(tx, optionsByVoting, itob(votingID))
+		if err != nil {
+			return err
+		}
+		for _, option := range records {
+			if option.VoterID == voterID {
+				voted = true
+			}
+		}
+		return nil
+	})
+	return voted, err
+}
@@ -0,0 +1,119 @@
+package kv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	optionsByVoting = &index[domain.VotingOption]{
+		name: []byte("voting_options_by_voting"),
+		key:  func(o *domain.VotingOption) []byte { return itob(o.VotingID) },
+	}
+	options = &table[domain.VotingOption]{
+		name:    []byte("voting_options"),
+		id:      func(o *domain.VotingOption) int { return o.ID },
+		setID:   func(o *domain.VotingOption, id int) { o.ID = id },
+		indexes: []*index[domain.VotingOption]{optionsByVoting},
+	}
+)
+
+func (r *KVRepository) CreateOption(ctx context.Context, option *domain.VotingOption) error {
+	// The voting is stored on its own, so keep it out of the option record.
+	record := *option
+	record.Voting = nil
+	if err := r.update(ctx, func(tx *bolt.Tx) error {
+		return options.insert(tx, &record)
+	}); err != nil {
+		return err
+	}
+	option.ID = record.ID
+	return nil
+}
+
+func (r *KVRepository) FindOptionByID(ctx context.Context, id int) (*domain.VotingOption, error) {
+	var option *domain.VotingOption
+	err := r.view(ctx, func(tx *bolt.Tx) (err error) {
+		if option, err = options.get(tx, id); err != nil {
+			return err
+		}
+		return loadVoting(tx, option)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return option, nil
+}
+
+func (r *KVRepository) FindAllOptionsByVotingID(ctx context.Context, votingID int) ([]*domain.VotingOption, error) {
+	var result []*domain.VotingOption
+	err := r.view(ctx, func(tx *bolt.Tx) (err error) {
+		if result, err = options.lookup(tx, optionsByVoting, itob(votingID)); err != nil {
+			return err
+		}
+		for _, option := range result {
+			if err := loadVoting(tx, option); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *KVRepository) UpdateOption(ctx context.Context, option *domain.VotingOption) error {
+	record := *option
+	record.Voting = nil
+	return r.update(ctx, func(tx *bolt.Tx) error {
+		return options.save(tx, &record)
+	})
+}
+
+func (r *KVRepository) DeleteOption(ctx context.Context, id int) error {
+	return r.update(ctx, func(tx *bolt.Tx) error {
+		option, err := options.get(tx, id)
+		if errors.Is(err, errNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return options.delete(tx, option)
+	})
+}
+
+func (r *KVRepository) IncrementVoteCount(ctx context.Context, id int, voterID int) error {
+	return r.update(ctx, func(tx *bolt.Tx) error {
+		old, err := options.get(tx, id)
+		if errors.Is(err, errNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		record := *old
+		record.VoteCount++
+		record.VoterID = voterID
+		return options.put(tx, &record, old)
+	})
+}
+
+// loadVoting sets the voting of option, leaving it nil when the voting has been
+// deleted, as a preload of the SQLite backend does.
+func loadVoting(tx *bolt.Tx, option *domain.VotingOption) error {
+	voting, err := votings.get(tx, option.VotingID)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	option.Voting = voting
+	return nil
+}
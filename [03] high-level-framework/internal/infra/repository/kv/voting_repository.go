@@ -0,0 +1,119 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/query"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	votings = &table[domain.Voting]{
+		name:  []byte("votings"),
+		id:    func(v *domain.Voting) int { return v.ID },
+		setID: func(v *domain.Voting, id int) { v.ID = id },
+	}
+	votingColumns = columns[domain.Voting]{
+		id:   func(v *domain.Voting) int { return v.ID },
+		time: func(v *domain.Voting) time.Time { return v.StartDate },
+		sort: map[string]func(a, b *domain.Voting) int{
+			"id":         orderedColumn(func(v *domain.Voting) int { return v.ID }),
+			"start_date": orderedColumn(func(v *domain.Voting) int64 { return v.StartDate.UnixNano() }),
+			"end_date":   orderedColumn(func(v *domain.Voting) int64 { return v.EndDate.UnixNano() }),
+			"title":      orderedColumn(func(v *domain.Voting) string { return v.Title }),
+		},
+	}
+)
+
+func (r *KVRepository) CreateVoting(ctx context.Context, voting *domain.Voting) error {
+	// Options are stored on their own, so keep them out of the voting record.
+	record := *voting
+	record.Options = nil
+	if record.Status == "" {
+		record.Status = domain.VotingStatusPending
+	}
+	if err := r.update(ctx, func(tx *bolt.Tx) error {
+		return votings.insert(tx, &record)
+	}); err != nil {
+		return err
+	}
+	voting.ID = record.ID
+	return nil
+}
+
+func (r *KVRepository) FindVotingByID(ctx context.Context, id int) (*domain.Voting, error) {
+	var voting *domain.Voting
+	err := r.view(ctx, func(tx *bolt.Tx) (err error) {
+		if voting, err = votings.get(tx, id); err != nil {
+			return err
+		}
+		voting.Options, err = options.lookup(tx, optionsByVoting, itob(id))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return voting, nil
+}
+
+func (r *KVRepository) FindAllVotings(ctx context.Context, q query.Query, now time.Time) ([]*domain.Voting, int64, error) {
+	var page []*domain.Voting
+	var total int64
+	err := r.view(ctx, func(tx *bolt.Tx) error {
+		records, err := votings.all(tx)
+		if err != nil {
+			return err
+		}
+		if q.State != "" {
+			records = slices.DeleteFunc(records, func(v *domain.Voting) bool {
+				return v.StatusAt(now) != domain.VotingStatus(q.State)
+			})
+		}
+		page, total = findPage(records, q, votingColumns)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return page, total, nil
+}
+
+// UpdateVoting replaces the stored voting, leaving its options untouched.
+func (r *KVRepository) UpdateVoting(ctx context.Context, voting *domain.Voting) error {
+	record := *voting
+	record.Options = nil
+	return r.update(ctx, func(tx *bolt.Tx) error {
+		return votings.save(tx, &record)
+	})
+}
+
+func (r *KVRepository) DeleteVoting(ctx context.Context, id int) error {
+	return r.update(ctx, func(tx *bolt.Tx) error {
+		voting, err := votings.get(tx, id)
+		if errors.Is(err, errNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return votings.delete(tx, voting)
+	})
+}
+
+func (r *KVRepository) FindAllActiveVotings(ctx context.Context, now time.Time) ([]*domain.Voting, error) {
+	var result []*domain.Voting
+	err := r.view(ctx, func(tx *bolt.Tx) (err error) {
+		result, err = votings.all(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(result, func(v *domain.Voting) bool {
+		return v.StatusAt(now) != domain.VotingStatusOpen
+	}), nil
+}
@@ -1,40 +1,62 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
 	. "github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/query"
 )
 
 type VotingRepository interface {
-	CreateVoting(voting *domain.Voting) error
-	FindVotingByID(id int) (*domain.Voting, error)
-	FindAllVotings() ([]*domain.Voting, error)
-	UpdateVoting(voting *domain.Voting) error
-	DeleteVoting(id int) error
-	FindAllActiveVotings() ([]*domain.Voting, error)
+	CreateVoting(ctx context.Context, voting *domain.Voting) error
+	FindVotingByID(ctx context.Context, id int) (*domain.Voting, error)
+	// FindAllVotings expects a normalized query, filtering by the status at now
+	// and by start date.
+	FindAllVotings(ctx context.Context, q query.Query, now time.Time) ([]*domain.Voting, int64, error)
+	UpdateVoting(ctx context.Context, voting *domain.Voting) error
+	DeleteVoting(ctx context.Context, id int) error
+	FindAllActiveVotings(ctx context.Context, now time.Time) ([]*domain.Voting, error)
 }
 
 type VotingOptionRepository interface {
-	CreateOption(option *domain.VotingOption) error
-	FindOptionByID(id int) (*domain.VotingOption, error)
-	FindAllOptionsByVotingID(votingID int) ([]*domain.VotingOption, error)
-	UpdateOption(option *domain.VotingOption) error
-	DeleteOption(id int) error
-	IncrementVoteCount(id int, voterID int) error
+	CreateOption(ctx context.Context, option *domain.VotingOption) error
+	FindOptionByID(ctx context.Context, id int) (*domain.VotingOption, error)
+	FindAllOptionsByVotingID(ctx context.Context, votingID int) ([]*domain.VotingOption, error)
+	UpdateOption(ctx context.Context, option *domain.VotingOption) error
+	DeleteOption(ctx context.Context, id int) error
+	IncrementVoteCount(ctx context.Context, id int, voterID int) error
 }
 
 type VoterRepository interface {
-	CreateVoter(voter *domain.Voter) error
-	FindVoterByID(id int) (*domain.Voter, error)
-	FindVoterByAddress(address Address) (*domain.Voter, error)
-	UpdateVoter(voter *domain.Voter) error
-	DeleteVoter(id int) error
-	HasVoted(voterID, votingID int) (bool, error)
+	CreateVoter(ctx context.Context, voter *domain.Voter) error
+	FindVoterByID(ctx context.Context, id int) (*domain.Voter, error)
+	FindVoterByAddress(ctx context.Context, address Address) (*domain.Voter, error)
+	UpdateVoter(ctx context.Context, voter *domain.Voter) error
+	DeleteVoter(ctx context.Context, id int) error
+	HasVoted(ctx context.Context, voterID, votingID int) (bool, error)
+}
+
+// ClockRepository keeps the latest block timestamp seen, so the time inspect
+// requests are answered at survives a restart.
+type ClockRepository interface {
+	// FindLastBlockTimestamp returns zero before the first timestamp is saved.
+	FindLastBlockTimestamp(ctx context.Context) (int64, error)
+	SaveLastBlockTimestamp(ctx context.Context, timestamp int64) error
+}
+
+// Transactor runs fn in a transaction that repository methods join when
+// called with the context given to fn. It commits only when fn returns nil.
+type Transactor interface {
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Repository interface {
+	Transactor
 	VotingRepository
 	VotingOptionRepository
 	VoterRepository
+	ClockRepository
 	Close() error
 }
@@ -0,0 +1,287 @@
+// Package repositorytest checks that an implementation of repository.Repository
+// behaves like the others, so the application can switch backends without
+// noticing. Each backend runs it from its own tests.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
+	. "github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/query"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+var (
+	creator = HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	alice   = HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	bob     = HexToAddress("0x90F79bf6EB2c4f870365E51660A8a5D1B0a4E5d0")
+)
+
+// Suite runs the conformance tests, each against a new and empty repository
+// returned by Open. Run it with suite.Run.
+type Suite struct {
+	suite.Suite
+	Open func() (repository.Repository, error)
+
+	ctx  context.Context
+	repo repository.Repository
+	now  time.Time
+}
+
+func (s *Suite) SetupTest() {
+	repo, err := s.Open()
+	s.Require().NoError(err)
+	s.ctx = context.Background()
+	s.repo = repo
+	s.now = time.Unix(1_000_000, 0)
+}
+
+func (s *Suite) TearDownTest() {
+	s.Require().NoError(s.repo.Close())
+}
+
+func (s *Suite) createVoting(title string, start, end int64) *domain.Voting {
+	voting, err := domain.NewVoting(title, creator, time.Unix(start, 0), time.Unix(end, 0), s.now)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.CreateVoting(s.ctx, voting))
+	return voting
+}
+
+func (s *Suite) createOption(votingID int) *domain.VotingOption {
+	option, err := domain.NewVotingOption(votingID)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.CreateOption(s.ctx, option))
+	return option
+}
+
+func (s *Suite) createVoter(address Address) *domain.Voter {
+	voter, err := domain.NewVoter(address)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.CreateVoter(s.ctx, voter))
+	return voter
+}
+
+func votingIDs(votings []*domain.Voting) []int {
+	ids := make([]int, len(votings))
+	for i, voting := range votings {
+		ids[i] = voting.ID
+	}
+	return ids
+}
+
+func (s *Suite) TestCreateAssignsIncreasingIds() {
+	first := s.createVoting("first", 1_000_100, 1_000_200)
+	second := s.createVoting("second", 1_000_100, 1_000_200)
+	s.NotZero(first.ID)
+	s.Greater(second.ID, first.ID)
+
+	found, err := s.repo.FindVotingByID(s.ctx, second.ID)
+	s.Require().NoError(err)
+	s.Equal("second", found.Title)
+	s.Equal(creator, found.Creator)
+	s.True(found.StartDate.Equal(time.Unix(1_000_100, 0)))
+	s.True(found.EndDate.Equal(time.Unix(1_000_200, 0)))
+	s.Equal(domain.VotingStatusPending, found.Status)
+	s.Empty(found.Options)
+}
+
+func (s *Suite) TestMissingRecordsAreNotFound() {
+	_, err := s.repo.FindVotingByID(s.ctx, 42)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	_, err = s.repo.FindOptionByID(s.ctx, 42)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	_, err = s.repo.FindVoterByID(s.ctx, 42)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	_, err = s.repo.FindVoterByAddress(s.ctx, alice)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *Suite) TestUpdateAndDeleteVoting() {
+	voting := s.createVoting("title", 1_000_100, 1_000_200)
+	voting.Status = domain.VotingStatusOpen
+	s.Require().NoError(s.repo.UpdateVoting(s.ctx, voting))
+
+	found, err := s.repo.FindVotingByID(s.ctx, voting.ID)
+	s.Require().NoError(err)
+	s.Equal(domain.VotingStatusOpen, found.Status)
+
+	s.Require().NoError(s.repo.DeleteVoting(s.ctx, voting.ID))
+	_, err = s.repo.FindVotingByID(s.ctx, voting.ID)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *Suite) TestFindAllVotingsSortsAndPages() {
+	b := s.createVoting("b", 1_000_300, 1_000_400)
+	a := s.createVoting("a", 1_000_100, 1_000_200)
+	c := s.createVoting("c", 1_000_200, 1_000_500)
+
+	tests := []struct {
+		name string
+		q    query.Query
+		want []int
+	}{
+		{"by id", query.Query{Sort: "id"}, []int{b.ID, a.ID, c.ID}},
+		{"by title descending", query.Query{Sort: "title", Order: query.Desc}, []int{c.ID, b.ID, a.ID}},
+		{"by start date", query.Query{Sort: "start_date"}, []int{a.ID, c.ID, b.ID}},
+		{"by end date, paged", query.Query{Sort: "end_date", Limit: 2, Offset: 1}, []int{b.ID, c.ID}},
+		{"after cursor", query.Query{Sort: "id", Cursor: uint(b.ID)}, []int{a.ID, c.ID}},
+	}
+	for _, tt := range tests {
+		q, err := tt.q.Normalize("id", "start_date", "end_date", "title")
+		s.Require().NoError(err)
+		votings, total, err := s.repo.FindAllVotings(s.ctx, q, s.now)
+		s.Require().NoError(err, tt.name)
+		s.Equal(tt.want, votingIDs(votings), tt.name)
+		s.Equal(int64(3), total, tt.name)
+	}
+}
+
+func (s *Suite) TestFindAllVotingsFilters() {
+	first := s.createVoting("first", 1_000_100, 1_000_200)
+	second := s.createVoting("second", 1_000_150, 1_000_300)
+	third := s.createVoting("third", 1_000_400, 1_000_500)
+	now := time.Unix(1_000_200, 0)
+
+	tests := []struct {
+		name string
+		q    query.Query
+		want []int
+	}{
+		{"pending", query.Query{State: string(domain.VotingStatusPending)}, []int{third.ID}},
+		{"open", query.Query{State: string(domain.VotingStatusOpen)}, []int{second.ID}},
+		{"closed at the end date", query.Query{State: string(domain.VotingStatusClosed)}, []int{first.ID}},
+		{"starting from, inclusive", query.Query{From: 1_000_150}, []int{second.ID, third.ID}},
+		{"starting until, inclusive", query.Query{To: 1_000_150}, []int{first.ID, second.ID}},
+	}
+	for _, tt := range tests {
+		q, err := tt.q.Normalize("id")
+		s.Require().NoError(err)
+		votings, total, err := s.repo.FindAllVotings(s.ctx, q, now)
+		s.Require().NoError(err, tt.name)
+		s.Equal(tt.want, votingIDs(votings), tt.name)
+		s.Equal(int64(len(tt.want)), total, tt.name)
+	}
+
+	active, err := s.repo.FindAllActiveVotings(s.ctx, now)
+	s.Require().NoError(err)
+	s.Equal([]int{second.ID}, votingIDs(active))
+}
+
+func (s *Suite) TestOptionsBelongToTheirVoting() {
+	voting := s.createVoting("voting", 1_000_100, 1_000_200)
+	other := s.createVoting("other", 1_000_100, 1_000_200)
+	first := s.createOption(voting.ID)
+	second := s.createOption(voting.ID)
+	s.createOption(other.ID)
+
+	found, err := s.repo.FindVotingByID(s.ctx, voting.ID)
+	s.Require().NoError(err)
+	s.Len(found.Options, 2)
+
+	options, err := s.repo.FindAllOptionsByVotingID(s.ctx, voting.ID)
+	s.Require().NoError(err)
+	s.Require().Len(options, 2)
+	s.Equal(first.ID, options[0].ID)
+	s.Equal(second.ID, options[1].ID)
+
+	option, err := s.repo.FindOptionByID(s.ctx, second.ID)
+	s.Require().NoError(err)
+	s.Equal(voting.ID, option.VotingID)
+	s.Require().NotNil(option.Voting)
+	s.Equal("voting", option.Voting.Title)
+
+	s.Require().NoError(s.repo.DeleteOption(s.ctx, first.ID))
+	options, err = s.repo.FindAllOptionsByVotingID(s.ctx, voting.ID)
+	s.Require().NoError(err)
+	s.Len(options, 1)
+}
+
+func (s *Suite) TestVotesAreCounted() {
+	voting := s.createVoting("voting", 1_000_100, 1_000_200)
+	option := s.createOption(voting.ID)
+	voter := s.createVoter(alice)
+
+	voted, err := s.repo.HasVoted(s.ctx, voter.ID, voting.ID)
+	s.Require().NoError(err)
+	s.False(voted)
+
+	s.Require().NoError(s.repo.IncrementVoteCount(s.ctx, option.ID, voter.ID))
+	s.Require().NoError(s.repo.IncrementVoteCount(s.ctx, option.ID, voter.ID))
+
+	found, err := s.repo.FindOptionByID(s.ctx, option.ID)
+	s.Require().NoError(err)
+	s.Equal(2, found.VoteCount)
+	s.Equal(voter.ID, found.VoterID)
+
+	voted, err = s.repo.HasVoted(s.ctx, voter.ID, voting.ID)
+	s.Require().NoError(err)
+	s.True(voted)
+}
+
+func (s *Suite) TestVoters() {
+	voter := s.createVoter(alice)
+	s.NotZero(voter.ID)
+
+	duplicate, err := domain.NewVoter(alice)
+	s.Require().NoError(err)
+	s.Error(s.repo.CreateVoter(s.ctx, duplicate))
+
+	found, err := s.repo.FindVoterByAddress(s.ctx, alice)
+	s.Require().NoError(err)
+	s.Equal(voter.ID, found.ID)
+
+	voter.Address = bob
+	s.Require().NoError(s.repo.UpdateVoter(s.ctx, voter))
+	found, err = s.repo.FindVoterByID(s.ctx, voter.ID)
+	s.Require().NoError(err)
+	s.Equal(bob, found.Address)
+	_, err = s.repo.FindVoterByAddress(s.ctx, alice)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	s.Require().NoError(s.repo.DeleteVoter(s.ctx, voter.ID))
+	_, err = s.repo.FindVoterByID(s.ctx, voter.ID)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *Suite) TestLastBlockTimestamp() {
+	timestamp, err := s.repo.FindLastBlockTimestamp(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(timestamp)
+
+	s.Require().NoError(s.repo.SaveLastBlockTimestamp(s.ctx, 1_000))
+	s.Require().NoError(s.repo.SaveLastBlockTimestamp(s.ctx, 2_000))
+	timestamp, err = s.repo.FindLastBlockTimestamp(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(2_000), timestamp)
+}
+
+func (s *Suite) TestTransactions() {
+	errRejected := errors.New("rejected")
+	createVoter := func(ctx context.Context) {
+		voter, err := domain.NewVoter(alice)
+		s.Require().NoError(err)
+		s.Require().NoError(s.repo.CreateVoter(ctx, voter))
+	}
+
+	err := s.repo.RunInTransaction(s.ctx, func(ctx context.Context) error {
+		createVoter(ctx)
+		_, err := s.repo.FindVoterByAddress(ctx, alice)
+		s.NoError(err, "a transaction sees its own writes")
+		return errRejected
+	})
+	s.ErrorIs(err, errRejected)
+	_, err = s.repo.FindVoterByAddress(s.ctx, alice)
+	s.ErrorIs(err, gorm.ErrRecordNotFound, "a failed transaction is rolled back")
+
+	s.Require().NoError(s.repo.RunInTransaction(s.ctx, func(ctx context.Context) error {
+		createVoter(ctx)
+		return nil
+	}))
+	_, err = s.repo.FindVoterByAddress(s.ctx, alice)
+	s.NoError(err, "a successful transaction is committed")
+}
@@ -0,0 +1,21 @@
+package sqlite
+
+import (
+	"context"
+)
+
+func (r *SQLiteRepository) FindLastBlockTimestamp(ctx context.Context) (int64, error) {
+	var timestamps []int64
+	err := r.db(ctx).Raw("SELECT last_block_timestamp FROM clock WHERE id = 1").Scan(&timestamps).Error
+	if err != nil || len(timestamps) == 0 {
+		return 0, err
+	}
+	return timestamps[0], nil
+}
+
+func (r *SQLiteRepository) SaveLastBlockTimestamp(ctx context.Context, timestamp int64) error {
+	return r.db(ctx).Exec(
+		"INSERT INTO clock (id, last_block_timestamp) VALUES (1, ?) ON CONFLICT (id) DO UPDATE SET last_block_timestamp = excluded.last_block_timestamp",
+		timestamp,
+	).Error
+}
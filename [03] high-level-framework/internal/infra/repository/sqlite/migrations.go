@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+// migrations is the schema history of the database. Steps are append-only:
+// a deployed drive may already be at any of these versions, so change the
+// schema by adding a new version rather than editing an old one.
+var migrations = []migrate.Migration{
+	{
+		// Matches the schema AutoMigrate created before migrations were
+		// versioned, so existing drives adopt it unchanged.
+		Version:     1,
+		Description: "create votings, voting options and voters",
+		Up: execAll(
+			"CREATE TABLE IF NOT EXISTS `votings` (`id` integer PRIMARY KEY AUTOINCREMENT,`title` text NOT NULL,`creator` text NOT NULL,`start_date` datetime NOT NULL,`end_date` datetime NOT NULL,`status` text NOT NULL DEFAULT \"pending\")",
+			"CREATE INDEX IF NOT EXISTS `idx_votings_end_date` ON `votings`(`end_date`)",
+			"CREATE INDEX IF NOT EXISTS `idx_votings_start_date` ON `votings`(`start_date`)",
+			"CREATE TABLE IF NOT EXISTS `voting_options` (`id` integer PRIMARY KEY AUTOINCREMENT,`voting_id` integer NOT NULL,`voter_id` integer NOT NULL,`vote_count` integer NOT NULL DEFAULT 0,CONSTRAINT `fk_votings_options` FOREIGN KEY (`voting_id`) REFERENCES `votings`(`id`))",
+			"CREATE INDEX IF NOT EXISTS `idx_voting_options_voter_id` ON `voting_options`(`voter_id`)",
+			"CREATE INDEX IF NOT EXISTS `idx_voting_options_voting_id` ON `voting_options`(`voting_id`)",
+			"CREATE TABLE IF NOT EXISTS `voters` (`id` integer PRIMARY KEY AUTOINCREMENT,`address` text NOT NULL)",
+			"CREATE UNIQUE INDEX IF NOT EXISTS `idx_voters_address` ON `voters`(`address`)",
+		),
+	},
+	{
+		Version:     2,
+		Description: "create clock",
+		Up:          execAll("CREATE TABLE `clock` (`id` integer PRIMARY KEY,`last_block_timestamp` integer NOT NULL)"),
+	},
+}
+
+func execAll(statements ...string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
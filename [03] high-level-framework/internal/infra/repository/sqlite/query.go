@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// findPage loads into dest the page of db selected by the normalized query q,
+// filtering by time range on timeColumn, and returns how many rows match the
+// filters. Callers filter by q.State themselves, as no stored column holds the
+// status of a voting at the time of the request.
+func findPage(db *gorm.DB, q query.Query, dest any, timeColumn string) (int64, error) {
+	db = db.Model(dest)
+	if q.From != 0 {
+		db = db.Where(clause.Gte{Column: clause.Column{Name: timeColumn}, Value: time.Unix(q.From, 0)})
+	}
+	if q.To != 0 {
+		db = db.Where(clause.Lte{Column: clause.Column{Name: timeColumn}, Value: time.Unix(q.To, 0)})
+	}
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count: %w", err)
+	}
+
+	desc := q.Order == query.Desc
+	if q.Cursor != 0 {
+		if desc {
+			db = db.Where(clause.Lt{Column: clause.Column{Name: "id"}, Value: q.Cursor})
+		} else {
+			db = db.Where(clause.Gt{Column: clause.Column{Name: "id"}, Value: q.Cursor})
+		}
+	}
+	err := db.
+		Order(clause.OrderByColumn{Column: clause.Column{Name: q.Sort}, Desc: desc}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: desc}).
+		Limit(q.Limit).
+		Offset(q.Offset).
+		Find(dest).Error
+	return total, err
+}
@@ -1,6 +1,7 @@
 package sqlite
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -11,15 +12,15 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
-	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/migrate"
 )
 
 type SQLiteRepository struct {
-	db *gorm.DB
+	Db *gorm.DB
 }
 
 func (r *SQLiteRepository) Close() error {
-	sqlDB, err := r.db.DB()
+	sqlDB, err := r.Db.DB()
 	if err != nil {
 		return fmt.Errorf("failed to get database instance: %w", err)
 	}
@@ -27,8 +28,48 @@ func (r *SQLiteRepository) Close() error {
 }
 
 func NewSQLiteRepository(conn string) (*SQLiteRepository, error) {
+	db, err := open(strings.TrimPrefix(conn, "sqlite://"))
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := migrate.New(db, migrations...)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return &SQLiteRepository{Db: db}, nil
+}
+
+// MigrationStatus reports which migrations have been applied to the database
+// at conn, without migrating it. The database file must already exist, since
+// opening a missing one would create it.
+func MigrationStatus(ctx context.Context, conn string) ([]migrate.Status, error) {
 	dbPath := strings.TrimPrefix(conn, "sqlite://")
+	file, _, _ := strings.Cut(dbPath, "?")
+	if _, err := os.Stat(file); err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db, err := open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	repo := &SQLiteRepository{Db: db}
+	defer repo.Close()
+	db = db.Session(&gorm.Session{Logger: db.Logger.LogMode(logger.Silent)})
+
+	migrator, err := migrate.New(db, migrations...)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Status(ctx)
+}
 
+func open(dbPath string) (*gorm.DB, error) {
 	newLogger := logger.New(
 		log.New(os.Stdout, "\r\n", log.LstdFlags),
 		logger.Config{
@@ -39,21 +80,7 @@ func NewSQLiteRepository(conn string) (*SQLiteRepository, error) {
 		},
 	)
 
-	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{
+	return gorm.Open(sqlite.Open(dbPath), &gorm.Config{
 		Logger: newLogger,
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	err = db.AutoMigrate(
-		&domain.Voting{},
-		&domain.VotingOption{},
-		&domain.Voter{},
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return &SQLiteRepository{db: db}, nil
 }
@@ -0,0 +1,42 @@
+package sqlite_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository/repositorytest"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository/sqlite"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestConformance(t *testing.T) {
+	suite.Run(t, &repositorytest.Suite{
+		Open: func() (repository.Repository, error) {
+			return sqlite.NewSQLiteRepository("sqlite://:memory:")
+		},
+	})
+}
+
+func TestMigrationStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	_, err := sqlite.MigrationStatus(context.Background(), "sqlite://"+path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist, "status must not create the database")
+
+	repo, err := sqlite.NewSQLiteRepository("sqlite://" + path)
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+
+	statuses, err := sqlite.MigrationStatus(context.Background(), "sqlite://"+path)
+	require.NoError(t, err)
+	require.NotEmpty(t, statuses)
+	for _, status := range statuses {
+		require.True(t, status.Applied, status.Description)
+	}
+}
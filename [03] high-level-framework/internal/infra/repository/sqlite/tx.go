@@ -0,0 +1,26 @@
+package sqlite
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// RunInTransaction calls fn with a context carrying a database transaction, so
+// every repository method called with it joins the transaction. The transaction
+// is committed when fn returns nil and rolled back otherwise.
+func (r *SQLiteRepository) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.db(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// db returns the transaction carried by ctx, or the database outside of one.
+func (r *SQLiteRepository) db(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.Db.WithContext(ctx)
+}
@@ -1,43 +1,44 @@
 package sqlite
 
 import (
+	"context"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
 	. "github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/custom_type"
 )
 
-func (r *SQLiteRepository) CreateVoter(voter *domain.Voter) error {
-	return r.db.Create(voter).Error
+func (r *SQLiteRepository) CreateVoter(ctx context.Context, voter *domain.Voter) error {
+	return r.db(ctx).Create(voter).Error
 }
 
-func (r *SQLiteRepository) FindVoterByID(id int) (*domain.Voter, error) {
+func (r *SQLiteRepository) FindVoterByID(ctx context.Context, id int) (*domain.Voter, error) {
 	var voter domain.Voter
-	err := r.db.First(&voter, id).Error
+	err := r.db(ctx).First(&voter, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &voter, nil
 }
 
-func (r *SQLiteRepository) FindVoterByAddress(address Address) (*domain.Voter, error) {
+func (r *SQLiteRepository) FindVoterByAddress(ctx context.Context, address Address) (*domain.Voter, error) {
 	var voter domain.Voter
-	err := r.db.Where("address = ?", address).First(&voter).Error
+	err := r.db(ctx).Where("address = ?", address).First(&voter).Error
 	if err != nil {
 		return nil, err
 	}
 	return &voter, nil
 }
 
-func (r *SQLiteRepository) UpdateVoter(voter *domain.Voter) error {
-	return r.db.Save(voter).Error
+func (r *SQLiteRepository) UpdateVoter(ctx context.Context, voter *domain.Voter) error {
+	return r.db(ctx).Save(voter).Error
 }
 
-func (r *SQLiteRepository) DeleteVoter(id int) error {
-	return r.db.Delete(&domain.Voter{}, id).Error
+func (r *SQLiteRepository) DeleteVoter(ctx context.Context, id int) error {
+	return r.db(ctx).Delete(&domain.Voter{}, id).Error
 }
 
-func (r *SQLiteRepository) HasVoted(voterID, votingID int) (bool, error) {
+func (r *SQLiteRepository) HasVoted(ctx context.Context, voterID, votingID int) (bool, error) {
 	var count int64
-	err := r.db.Model(&domain.VotingOption{}).
+	err := r.db(ctx).Model(&domain.VotingOption{}).
 		Where("voting_id = ? AND voter_id = ?", votingID, voterID).
 		Count(&count).Error
 	if err != nil {
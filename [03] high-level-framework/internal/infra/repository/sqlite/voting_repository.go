@@ -1,42 +1,55 @@
 package sqlite
 
 import (
+	"context"
+	"time"
+
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/query"
 )
 
-func (r *SQLiteRepository) CreateVoting(voting *domain.Voting) error {
-	return r.db.Create(voting).Error
+func (r *SQLiteRepository) CreateVoting(ctx context.Context, voting *domain.Voting) error {
+	return r.db(ctx).Create(voting).Error
 }
 
-func (r *SQLiteRepository) FindVotingByID(id int) (*domain.Voting, error) {
+func (r *SQLiteRepository) FindVotingByID(ctx context.Context, id int) (*domain.Voting, error) {
 	var voting domain.Voting
-	err := r.db.Preload("Options").First(&voting, id).Error
+	err := r.db(ctx).Preload("Options").First(&voting, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &voting, nil
 }
 
-func (r *SQLiteRepository) FindAllVotings() ([]*domain.Voting, error) {
+func (r *SQLiteRepository) FindAllVotings(ctx context.Context, q query.Query, now time.Time) ([]*domain.Voting, int64, error) {
 	var votings []*domain.Voting
-	err := r.db.Find(&votings).Error
+	db := r.db(ctx)
+	switch domain.VotingStatus(q.State) {
+	case domain.VotingStatusPending:
+		db = db.Where("start_date > ?", now)
+	case domain.VotingStatusOpen:
+		db = db.Where("start_date <= ? AND end_date > ?", now, now)
+	case domain.VotingStatusClosed:
+		db = db.Where("end_date <= ?", now)
+	}
+	total, err := findPage(db, q, &votings, "start_date")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return votings, nil
+	return votings, total, nil
 }
 
-func (r *SQLiteRepository) UpdateVoting(voting *domain.Voting) error {
-	return r.db.Save(voting).Error
+func (r *SQLiteRepository) UpdateVoting(ctx context.Context, voting *domain.Voting) error {
+	return r.db(ctx).Save(voting).Error
 }
 
-func (r *SQLiteRepository) DeleteVoting(id int) error {
-	return r.db.Delete(&domain.Voting{}, id).Error
+func (r *SQLiteRepository) DeleteVoting(ctx context.Context, id int) error {
+	return r.db(ctx).Delete(&domain.Voting{}, id).Error
 }
 
-func (r *SQLiteRepository) FindAllActiveVotings() ([]*domain.Voting, error) {
+func (r *SQLiteRepository) FindAllActiveVotings(ctx context.Context, now time.Time) ([]*domain.Voting, error) {
 	var votings []*domain.Voting
-	err := r.db.Where("status = ?", domain.VotingStatusOpen).Find(&votings).Error
+	err := r.db(ctx).Where("start_date <= ? AND end_date > ?", now, now).Find(&votings).Error
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +27,7 @@ func (uc *CreateVoterUseCase) Execute(ctx context.Context, metadata *rollmelette
 	if err != nil {
 		return nil, err
 	}
-	err = uc.VoterRepository.CreateVoter(voter)
+	err = uc.VoterRepository.CreateVoter(ctx, voter)
 	if err != nil {
 		return nil, err
 	}
@@ -2,11 +2,11 @@ package voter
 
 import (
 	"context"
-	"errors"
 
 	. "github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/custom_type"
 	"github.com/rollmelette/rollmelette"
 
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 )
 
@@ -27,14 +27,14 @@ func NewDeleteVoterUseCase(voterRepository repository.VoterRepository) *DeleteVo
 }
 
 func (uc *DeleteVoterUseCase) Execute(ctx context.Context, input *DeleteVoterInputDTO, metadata *rollmelette.Metadata) (*DeleteVoterOutputDTO, error) {
-	voter, err := uc.VoterRepository.FindVoterByID(input.Id)
+	voter, err := uc.VoterRepository.FindVoterByID(ctx, input.Id)
 	if err != nil {
 		return &DeleteVoterOutputDTO{Success: false}, err
 	}
 	if voter.Address != Address(metadata.MsgSender) {
-		return &DeleteVoterOutputDTO{Success: false}, errors.New("unauthorized")
+		return &DeleteVoterOutputDTO{Success: false}, domain.ErrUnauthorized
 	}
-	err = uc.VoterRepository.DeleteVoter(input.Id)
+	err = uc.VoterRepository.DeleteVoter(ctx, input.Id)
 	if err != nil {
 		return &DeleteVoterOutputDTO{Success: false}, err
 	}
@@ -25,7 +25,7 @@ func NewFindVoterByAddressUseCase(voterRepository repository.VoterRepository) *F
 }
 
 func (uc *FindVoterByAddressUseCase) Execute(ctx context.Context, input *FindVoterByAddressInputDTO) (*FindVoterByAddressOutputDTO, error) {
-	voter, err := uc.VoterRepository.FindVoterByAddress(input.Address)
+	voter, err := uc.VoterRepository.FindVoterByAddress(ctx, input.Address)
 	if err != nil {
 		return nil, err
 	}
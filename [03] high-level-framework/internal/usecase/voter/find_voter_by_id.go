@@ -25,7 +25,7 @@ func NewFindVoterByIDUseCase(voterRepository repository.VoterRepository) *FindVo
 }
 
 func (uc *FindVoterByIDUseCase) Execute(ctx context.Context, input *FindVoterByIDInputDTO) (*FindVoterByIDOutputDTO, error) {
-	voter, err := uc.VoterRepository.FindVoterByID(input.Id)
+	voter, err := uc.VoterRepository.FindVoterByID(ctx, input.Id)
 	if err != nil {
 		return nil, err
 	}
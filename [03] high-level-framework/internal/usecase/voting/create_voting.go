@@ -36,11 +36,11 @@ func NewCreateVotingUseCase(votingRepository repository.VotingRepository) *Creat
 func (uc *CreateVotingUseCase) Execute(ctx context.Context, input *CreateVotingInputDTO, metadata *rollmelette.Metadata) (*CreateVotingOutputDTO, error) {
 	startDate := time.Unix(input.StartDate, 0)
 	endDate := time.Unix(input.EndDate, 0)
-	voting, err := domain.NewVoting(input.Title, Address(metadata.MsgSender), startDate, endDate)
+	voting, err := domain.NewVoting(input.Title, Address(metadata.MsgSender), startDate, endDate, time.Unix(metadata.BlockTimestamp, 0))
 	if err != nil {
 		return nil, err
 	}
-	err = uc.VotingRepository.CreateVoting(voting)
+	err = uc.VotingRepository.CreateVoting(ctx, voting)
 	if err != nil {
 		return nil, err
 	}
@@ -2,8 +2,8 @@ package voting
 
 import (
 	"context"
-	"errors"
 
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 	. "github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/custom_type"
 	"github.com/rollmelette/rollmelette"
@@ -26,14 +26,14 @@ func NewDeleteVotingUseCase(votingRepository repository.VotingRepository) *Delet
 }
 
 func (uc *DeleteVotingUseCase) Execute(ctx context.Context, input *DeleteVotingInputDTO, metadata *rollmelette.Metadata) (*DeleteVotingOutputDTO, error) {
-	voting, err := uc.VotingRepository.FindVotingByID(input.Id)
+	voting, err := uc.VotingRepository.FindVotingByID(ctx, input.Id)
 	if err != nil {
 		return &DeleteVotingOutputDTO{Success: false}, err
 	}
 	if voting.Creator != Address(metadata.MsgSender) {
-		return &DeleteVotingOutputDTO{Success: false}, errors.New("unauthorized")
+		return &DeleteVotingOutputDTO{Success: false}, domain.ErrUnauthorized
 	}
-	err = uc.VotingRepository.DeleteVoting(input.Id)
+	err = uc.VotingRepository.DeleteVoting(ctx, input.Id)
 	if err != nil {
 		return &DeleteVotingOutputDTO{Success: false}, err
 	}
@@ -2,6 +2,7 @@ package voting
 
 import (
 	"context"
+	"time"
 
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 )
@@ -22,8 +23,8 @@ func NewFindAllActiveVotingsUseCase(votingRepository repository.VotingRepository
 	return &FindAllActiveVotingsUseCase{VotingRepository: votingRepository}
 }
 
-func (uc *FindAllActiveVotingsUseCase) Execute(ctx context.Context) ([]*FindAllActiveVotingsOutputDTO, error) {
-	votings, err := uc.VotingRepository.FindAllActiveVotings()
+func (uc *FindAllActiveVotingsUseCase) Execute(ctx context.Context, now time.Time) ([]*FindAllActiveVotingsOutputDTO, error) {
+	votings, err := uc.VotingRepository.FindAllActiveVotings(ctx, now)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +33,7 @@ func (uc *FindAllActiveVotingsUseCase) Execute(ctx context.Context) ([]*FindAllA
 		output = append(output, &FindAllActiveVotingsOutputDTO{
 			Id:        v.ID,
 			Title:     v.Title,
-			Status:    string(v.Status),
+			Status:    string(v.StatusAt(now)),
 			StartDate: v.GetStartDateUnix(),
 			EndDate:   v.GetEndDateUnix(),
 		})
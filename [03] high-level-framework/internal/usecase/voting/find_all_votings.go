@@ -2,11 +2,22 @@ package voting
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/query"
 )
 
-type FindAllVotingsOutputDTO struct {
+// votingSortFields are the columns votings can be sorted by, the first being
+// the default. The status is left out: it depends on the block time of the
+// request, and the stored column is only brought up to date by votes.
+var votingSortFields = []string{"id", "start_date", "end_date", "title"}
+
+type FindAllVotingsInputDTO = query.Query
+
+type FindAllVotingsItemDTO struct {
 	Id        int    `json:"id"`
 	Title     string `json:"title"`
 	Status    string `json:"status"`
@@ -14,6 +25,8 @@ type FindAllVotingsOutputDTO struct {
 	EndDate   int64  `json:"end_date"`
 }
 
+type FindAllVotingsOutputDTO = query.Page[*FindAllVotingsItemDTO]
+
 type FindAllVotingsUseCase struct {
 	VotingRepository repository.VotingRepository
 }
@@ -22,20 +35,29 @@ func NewFindAllVotingsUseCase(votingRepository repository.VotingRepository) *Fin
 	return &FindAllVotingsUseCase{VotingRepository: votingRepository}
 }
 
-func (uc *FindAllVotingsUseCase) Execute(ctx context.Context) ([]*FindAllVotingsOutputDTO, error) {
-	votings, err := uc.VotingRepository.FindAllVotings()
+func (uc *FindAllVotingsUseCase) Execute(ctx context.Context, input *FindAllVotingsInputDTO, now time.Time) (*FindAllVotingsOutputDTO, error) {
+	q, err := input.Normalize(votingSortFields...)
+	if err != nil {
+		return nil, err
+	}
+	switch domain.VotingStatus(q.State) {
+	case "", domain.VotingStatusPending, domain.VotingStatusOpen, domain.VotingStatusClosed:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", query.ErrInvalidQuery, q.State)
+	}
+	votings, total, err := uc.VotingRepository.FindAllVotings(ctx, q, now)
 	if err != nil {
 		return nil, err
 	}
-	var output []*FindAllVotingsOutputDTO
-	for _, v := range votings {
-		output = append(output, &FindAllVotingsOutputDTO{
+	output := make([]*FindAllVotingsItemDTO, len(votings))
+	for i, v := range votings {
+		output[i] = &FindAllVotingsItemDTO{
 			Id:        v.ID,
 			Title:     v.Title,
-			Status:    string(v.Status),
+			Status:    string(v.StatusAt(now)),
 			StartDate: v.GetStartDateUnix(),
 			EndDate:   v.GetEndDateUnix(),
-		})
+		}
 	}
-	return output, nil
+	return query.NewPage(output, total, q, func(v *FindAllVotingsItemDTO) uint { return uint(v.Id) }), nil
 }
@@ -2,6 +2,7 @@ package voting
 
 import (
 	"context"
+	"time"
 
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 )
@@ -26,11 +27,12 @@ func NewFindVotingByIDUseCase(votingRepository repository.VotingRepository) *Fin
 	return &FindVotingByIDUseCase{VotingRepository: votingRepository}
 }
 
-func (uc *FindVotingByIDUseCase) Execute(ctx context.Context, input *FindVotingByIDInputDTO) (*FindVotingByIDOutputDTO, error) {
-	voting, err := uc.VotingRepository.FindVotingByID(input.Id)
+func (uc *FindVotingByIDUseCase) Execute(ctx context.Context, input *FindVotingByIDInputDTO, now time.Time) (*FindVotingByIDOutputDTO, error) {
+	voting, err := uc.VotingRepository.FindVotingByID(ctx, input.Id)
 	if err != nil {
 		return nil, err
 	}
+	voting.Refresh(now)
 	return &FindVotingByIDOutputDTO{
 		Id:        voting.ID,
 		Title:     voting.Title,
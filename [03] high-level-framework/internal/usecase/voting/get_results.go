@@ -2,6 +2,7 @@ package voting
 
 import (
 	"context"
+	"time"
 
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 )
@@ -36,8 +37,8 @@ func NewGetResultsUseCase(votingRepository repository.VotingRepository) *GetResu
 	}
 }
 
-func (u *GetResultsUseCase) Execute(ctx context.Context, input *GetResultsInputDTO) (*GetResultsOutputDTO, error) {
-	voting, err := u.votingRepository.FindVotingByID(input.ID)
+func (u *GetResultsUseCase) Execute(ctx context.Context, input *GetResultsInputDTO, now time.Time) (*GetResultsOutputDTO, error) {
+	voting, err := u.votingRepository.FindVotingByID(ctx, input.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +46,7 @@ func (u *GetResultsUseCase) Execute(ctx context.Context, input *GetResultsInputD
 	result := &GetResultsOutputDTO{
 		ID:      voting.ID,
 		Title:   voting.Title,
-		Status:  string(voting.Status),
+		Status:  string(voting.StatusAt(now)),
 		Options: make([]OptionResultDTO, 0),
 	}
 
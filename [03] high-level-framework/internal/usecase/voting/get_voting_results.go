@@ -29,7 +29,7 @@ func NewGetVotingResultsUseCase(optionRepo repository.VotingOptionRepository) *G
 }
 
 func (uc *GetVotingResultsUseCase) Execute(ctx context.Context, input *GetVotingResultsInputDTO) (*GetVotingResultsOutputDTO, error) {
-	options, err := uc.VotingOptionRepository.FindAllOptionsByVotingID(input.VotingID)
+	options, err := uc.VotingOptionRepository.FindAllOptionsByVotingID(ctx, input.VotingID)
 	if err != nil {
 		return nil, err
 	}
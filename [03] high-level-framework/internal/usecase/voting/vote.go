@@ -1,7 +1,9 @@
 package voting
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
@@ -39,22 +41,26 @@ func NewVoteUseCase(
 	}
 }
 
-func (u *VoteUseCase) Execute(input VoteInputDTO, metadata *rollmelette.Metadata) (*VoteOutputDTO, error) {
-	voting, err := u.VotingRepository.FindVotingByID(input.VotingID)
+func (u *VoteUseCase) Execute(ctx context.Context, input VoteInputDTO, metadata *rollmelette.Metadata) (*VoteOutputDTO, error) {
+	voting, err := u.VotingRepository.FindVotingByID(ctx, input.VotingID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find voting: %w", err)
 	}
 
-	if voting.Status != domain.VotingStatusOpen {
+	now := time.Unix(metadata.BlockTimestamp, 0)
+	switch voting.StatusAt(now) {
+	case domain.VotingStatusPending:
+		return nil, domain.ErrVotingPending
+	case domain.VotingStatusClosed:
 		return nil, domain.ErrVotingClosed
 	}
 
-	voter, err := u.VoterRepository.FindVoterByAddress(Address(metadata.MsgSender))
+	voter, err := u.VoterRepository.FindVoterByAddress(ctx, Address(metadata.MsgSender))
 	if err != nil {
 		return nil, domain.ErrVoterNotFound
 	}
 
-	hasVoted, err := u.VoterRepository.HasVoted(voter.ID, voting.ID)
+	hasVoted, err := u.VoterRepository.HasVoted(ctx, voter.ID, voting.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if voter has voted: %w", err)
 	}
@@ -62,7 +68,7 @@ func (u *VoteUseCase) Execute(input VoteInputDTO, metadata *rollmelette.Metadata
 		return nil, domain.ErrAlreadyVoted
 	}
 
-	option, err := u.VotingOptionRepository.FindOptionByID(input.OptionID)
+	option, err := u.VotingOptionRepository.FindOptionByID(ctx, input.OptionID)
 	if err != nil {
 		return nil, domain.ErrOptionNotFound
 	}
@@ -71,7 +77,15 @@ func (u *VoteUseCase) Execute(input VoteInputDTO, metadata *rollmelette.Metadata
 		return nil, domain.ErrInvalidOption
 	}
 
-	err = u.VotingOptionRepository.IncrementVoteCount(option.ID, voter.ID)
+	// The stored status is only brought up to date once the vote is accepted,
+	// so that a rejected input leaves the database untouched.
+	if voting.Refresh(now) {
+		if err := u.VotingRepository.UpdateVoting(ctx, voting); err != nil {
+			return nil, fmt.Errorf("failed to update voting status: %w", err)
+		}
+	}
+
+	err = u.VotingOptionRepository.IncrementVoteCount(ctx, option.ID, voter.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to increment vote count: %w", err)
 	}
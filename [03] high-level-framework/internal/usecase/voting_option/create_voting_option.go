@@ -2,7 +2,6 @@ package voting_option
 
 import (
 	"context"
-	"errors"
 
 	. "github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/custom_type"
 	"github.com/rollmelette/rollmelette"
@@ -37,18 +36,18 @@ func (uc *CreateVotingOptionUseCase) Execute(ctx context.Context, input *CreateV
 		return nil, domain.ErrInvalidVotingOption
 	}
 
-	voting, err := uc.VotingRepository.FindVotingByID(input.VotingID)
+	voting, err := uc.VotingRepository.FindVotingByID(ctx, input.VotingID)
 	if err != nil {
 		return nil, err
 	}
 	if voting.Creator != Address(metadata.MsgSender) {
-		return nil, errors.New("unauthorized")
+		return nil, domain.ErrUnauthorized
 	}
 	option, err := domain.NewVotingOption(input.VotingID)
 	if err != nil {
 		return nil, err
 	}
-	err = uc.VotingOptionRepository.CreateOption(option)
+	err = uc.VotingOptionRepository.CreateOption(ctx, option)
 	if err != nil {
 		return nil, err
 	}
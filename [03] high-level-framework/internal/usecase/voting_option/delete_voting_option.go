@@ -2,8 +2,8 @@ package voting_option
 
 import (
 	"context"
-	"errors"
 
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 	. "github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/custom_type"
 	"github.com/rollmelette/rollmelette"
@@ -26,17 +26,17 @@ func NewDeleteVotingOptionUseCase(votingOptionRepository repository.VotingOption
 }
 
 func (uc *DeleteVotingOptionUseCase) Execute(ctx context.Context, input *DeleteVotingOptionInputDTO, metadata *rollmelette.Metadata) (*DeleteVotingOptionOutputDTO, error) {
-	votingOption, err := uc.VotingOptionRepository.FindOptionByID(input.Id)
+	votingOption, err := uc.VotingOptionRepository.FindOptionByID(ctx, input.Id)
 	if err != nil {
 		return &DeleteVotingOptionOutputDTO{Success: false}, err
 	}
 	if votingOption.Voting == nil {
-		return &DeleteVotingOptionOutputDTO{Success: false}, errors.New("voting not found")
+		return &DeleteVotingOptionOutputDTO{Success: false}, domain.ErrVotingNotFound
 	}
 	if votingOption.Voting.Creator != Address(metadata.MsgSender) {
-		return &DeleteVotingOptionOutputDTO{Success: false}, errors.New("unauthorized")
+		return &DeleteVotingOptionOutputDTO{Success: false}, domain.ErrUnauthorized
 	}
-	err = uc.VotingOptionRepository.DeleteOption(input.Id)
+	err = uc.VotingOptionRepository.DeleteOption(ctx, input.Id)
 	if err != nil {
 		return &DeleteVotingOptionOutputDTO{Success: false}, err
 	}
@@ -25,7 +25,7 @@ func NewFindAllOptionsByVotingIDUseCase(votingOptionRepository repository.Voting
 }
 
 func (uc *FindAllOptionsByVotingIDUseCase) Execute(ctx context.Context, input *FindAllOptionsByVotingIDInputDTO) ([]*FindAllOptionsByVotingIDOutputDTO, error) {
-	options, err := uc.VotingOptionRepository.FindAllOptionsByVotingID(input.VotingID)
+	options, err := uc.VotingOptionRepository.FindAllOptionsByVotingID(ctx, input.VotingID)
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +25,7 @@ func NewFindVotingOptionByIDUseCase(votingOptionRepository repository.VotingOpti
 }
 
 func (uc *FindVotingOptionByIDUseCase) Execute(ctx context.Context, input *FindVotingOptionByIDInputDTO) (*FindVotingOptionByIDOutputDTO, error) {
-	option, err := uc.VotingOptionRepository.FindOptionByID(input.Id)
+	option, err := uc.VotingOptionRepository.FindOptionByID(ctx, input.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,107 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMigrateSuite(t *testing.T) {
+	suite.Run(t, new(MigrateSuite))
+}
+
+type MigrateSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func (s *MigrateSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	s.Require().NoError(err)
+	s.db = db
+}
+
+func exec(sql string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		return tx.Exec(sql).Error
+	}
+}
+
+func (s *MigrateSuite) TestUpAppliesPendingInOrder() {
+	ctx := context.Background()
+	v1 := Migration{Version: 1, Description: "create items", Up: exec("CREATE TABLE items (id integer PRIMARY KEY, name text)")}
+	v2 := Migration{Version: 2, Description: "rename name to title", Up: exec("ALTER TABLE items RENAME COLUMN name TO title")}
+
+	m, err := New(s.db, v1)
+	s.Require().NoError(err)
+	statuses, err := m.Status(ctx)
+	s.Require().NoError(err)
+	s.Equal([]Status{{Version: 1, Description: "create items"}}, statuses)
+	s.Require().NoError(m.Up(ctx))
+	s.Require().NoError(s.db.Exec("INSERT INTO items (id, name) VALUES (1, 'a')").Error)
+
+	m, err = New(s.db, v2, v1)
+	s.Require().NoError(err)
+	statuses, err = m.Status(ctx)
+	s.Require().NoError(err)
+	s.Equal([]Status{
+		{Version: 1, Description: "create items", Applied: true},
+		{Version: 2, Description: "rename name to title"},
+	}, statuses)
+	s.Require().NoError(m.Up(ctx))
+	s.Require().NoError(m.Up(ctx))
+
+	var title string
+	s.Require().NoError(s.db.Raw("SELECT title FROM items WHERE id = 1").Scan(&title).Error)
+	s.Equal("a", title)
+}
+
+func (s *MigrateSuite) TestFailedMigrationIsRolledBack() {
+	ctx := context.Background()
+	failing := Migration{Version: 1, Description: "fails", Up: func(tx *gorm.DB) error {
+		if err := tx.Exec("CREATE TABLE items (id integer PRIMARY KEY)").Error; err != nil {
+			return err
+		}
+		return errors.New("boom")
+	}}
+
+	m, err := New(s.db, failing)
+	s.Require().NoError(err)
+	s.ErrorContains(m.Up(ctx), "boom")
+	s.False(s.db.Migrator().HasTable("items"))
+
+	statuses, err := m.Status(ctx)
+	s.Require().NoError(err)
+	s.False(statuses[0].Applied)
+}
+
+func (s *MigrateSuite) TestUnknownVersion() {
+	ctx := context.Background()
+	v1 := Migration{Version: 1, Description: "create items", Up: exec("CREATE TABLE items (id integer PRIMARY KEY)")}
+	v2 := Migration{Version: 2, Description: "create tags", Up: exec("CREATE TABLE tags (id integer PRIMARY KEY)")}
+
+	m, err := New(s.db, v1, v2)
+	s.Require().NoError(err)
+	s.Require().NoError(m.Up(ctx))
+
+	m, err = New(s.db, v1)
+	s.Require().NoError(err)
+	s.ErrorIs(m.Up(ctx), ErrUnknownVersion)
+	_, err = m.Status(ctx)
+	s.ErrorIs(err, ErrUnknownVersion)
+}
+
+func (s *MigrateSuite) TestInvalidMigrations() {
+	up := exec("SELECT 1")
+	_, err := New(s.db, Migration{Version: 0, Up: up})
+	s.ErrorIs(err, ErrInvalidMigration)
+	_, err = New(s.db, Migration{Version: 1})
+	s.ErrorIs(err, ErrInvalidMigration)
+	_, err = New(s.db, Migration{Version: 1, Up: up}, Migration{Version: 1, Up: up})
+	s.ErrorIs(err, ErrInvalidMigration)
+}
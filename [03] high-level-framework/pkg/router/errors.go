@@ -0,0 +1,85 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+var (
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrRouteNotFound  = errors.New("no handler found for path")
+	ErrInvalidInput   = errors.New("invalid input")
+)
+
+// InternalErrorCode is reported for errors without a registered code.
+const InternalErrorCode = "internal_error"
+
+// ErrorReport is the JSON body reported by ErrorHandlingMiddleware.
+type ErrorReport struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Path       string `json:"path,omitempty"`
+	InputIndex *int   `json:"input_index,omitempty"`
+}
+
+type errorCode struct {
+	err  error
+	code string
+}
+
+var (
+	errorCodesMu sync.RWMutex
+	errorCodes   = []errorCode{
+		{ErrInvalidRequest, "invalid_request"},
+		{ErrRouteNotFound, "route_not_found"},
+		{ErrInvalidInput, "invalid_input"},
+	}
+)
+
+// RegisterErrorCode maps a sentinel error, and every error wrapping it, to code.
+// Registering the same sentinel again replaces its code.
+func RegisterErrorCode(err error, code string) {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+	for i, existing := range errorCodes {
+		if existing.err == err {
+			errorCodes[i].code = code
+			return
+		}
+	}
+	errorCodes = append(errorCodes, errorCode{err: err, code: code})
+}
+
+// ErrorCode returns the code of the first registered sentinel found in the chain
+// of err, or InternalErrorCode.
+func ErrorCode(err error) string {
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
+	for _, registered := range errorCodes {
+		if errors.Is(err, registered.err) {
+			return registered.code
+		}
+	}
+	return InternalErrorCode
+}
+
+// NewErrorReport describes err for the request carried by ctx. inputIndex is
+// nil for inspect requests.
+func NewErrorReport(ctx context.Context, err error, inputIndex *int) ErrorReport {
+	return ErrorReport{
+		Code:       ErrorCode(err),
+		Message:    err.Error(),
+		Path:       RequestPath(ctx),
+		InputIndex: inputIndex,
+	}
+}
+
+func (e ErrorReport) Bytes() []byte {
+	report, err := json.Marshal(e)
+	if err != nil {
+		return []byte(e.Message)
+	}
+	return report
+}
@@ -0,0 +1,169 @@
+package router
+
+import (
+	"context"
+	"strings"
+)
+
+type Params map[string]string
+
+type paramsKey struct{}
+
+func WithParams(ctx context.Context, params Params) context.Context {
+	return context.WithValue(ctx, paramsKey{}, params)
+}
+
+func ParamsFromContext(ctx context.Context) Params {
+	params, _ := ctx.Value(paramsKey{}).(Params)
+	return params
+}
+
+type pathKey struct{}
+
+// WithPath records the trimmed path of the request being dispatched.
+func WithPath(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, pathKey{}, path)
+}
+
+func RequestPath(ctx context.Context) string {
+	path, _ := ctx.Value(pathKey{}).(string)
+	return path
+}
+
+// PathParam returns the value captured by a ":name" segment, or by a "*" / "*name"
+// wildcard, of the route that matched the current request.
+func PathParam(ctx context.Context, name string) (string, bool) {
+	value, ok := ParamsFromContext(ctx)[name]
+	return value, ok
+}
+
+type segmentKind int
+
+const (
+	staticSegment segmentKind = iota
+	paramSegment
+	wildcardSegment
+)
+
+type segment struct {
+	kind  segmentKind
+	value string
+}
+
+type route struct {
+	pattern  string
+	segments []segment
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func compileRoute(pattern string) route {
+	parts := splitPath(pattern)
+	segments := make([]segment, 0, len(parts))
+	for i, part := range parts {
+		switch {
+		case strings.HasPrefix(part, ":") && len(part) > 1:
+			segments = append(segments, segment{kind: paramSegment, value: part[1:]})
+		case strings.HasPrefix(part, "*") && i == len(parts)-1:
+			name := part[1:]
+			if name == "" {
+				name = "*"
+			}
+			segments = append(segments, segment{kind: wildcardSegment, value: name})
+		default:
+			segments = append(segments, segment{kind: staticSegment, value: part})
+		}
+	}
+	return route{pattern: strings.Trim(pattern, "/"), segments: segments}
+}
+
+func (r route) isStatic() bool {
+	for _, s := range r.segments {
+		if s.kind != staticSegment {
+			return false
+		}
+	}
+	return true
+}
+
+func (r route) match(parts []string) (Params, bool) {
+	params := Params{}
+	for i, s := range r.segments {
+		if s.kind == wildcardSegment {
+			params[s.value] = strings.Join(parts[i:], "/")
+			return params, true
+		}
+		if i >= len(parts) {
+			return nil, false
+		}
+		switch s.kind {
+		case staticSegment:
+			if s.value != parts[i] {
+				return nil, false
+			}
+		case paramSegment:
+			if parts[i] == "" {
+				return nil, false
+			}
+			params[s.value] = parts[i]
+		}
+	}
+	if len(parts) != len(r.segments) {
+		return nil, false
+	}
+	return params, true
+}
+
+// moreSpecific reports whether r should win over other when both match the same
+// path: segments are compared left to right, static beats ":param" beats "*".
+func (r route) moreSpecific(other route) bool {
+	for i := 0; i < len(r.segments) && i < len(other.segments); i++ {
+		if r.segments[i].kind != other.segments[i].kind {
+			return r.segments[i].kind < other.segments[i].kind
+		}
+	}
+	return len(r.segments) > len(other.segments)
+}
+
+// lookup resolves path against the registered routes. Exact matches are checked
+// first so that static routes keep their plain map lookup. A path spelling out a
+// pattern, such as "campaign/:id", is not a request for that route and is
+// rejected rather than dispatched without params.
+func lookup[H any](handlers map[string]H, routes []route, path string) (H, Params, bool) {
+	if handler, ok := handlers[path]; ok {
+		if compileRoute(path).isStatic() {
+			return handler, Params{}, true
+		}
+		var zero H
+		return zero, nil, false
+	}
+
+	parts := splitPath(path)
+	var (
+		best       *route
+		bestParams Params
+	)
+	for i := range routes {
+		if routes[i].isStatic() {
+			continue
+		}
+		params, ok := routes[i].match(parts)
+		if !ok {
+			continue
+		}
+		if best == nil || routes[i].moreSpecific(*best) {
+			best, bestParams = &routes[i], params
+		}
+	}
+	if best == nil {
+		var zero H
+		return zero, nil, false
+	}
+	return handlers[best.pattern], bestParams, true
+}
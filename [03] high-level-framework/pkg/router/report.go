@@ -0,0 +1,179 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rollmelette/rollmelette"
+)
+
+// DefaultMaxReportSize keeps every report well under the 2 MiB output buffer
+// of the Cartesi machine.
+const DefaultMaxReportSize = 1 << 20
+
+// minMaxReportSize leaves room for the payload after the chunk header.
+const minMaxReportSize = 256
+
+var (
+	ErrInvalidChunk       = errors.New("invalid report chunk")
+	ErrIncompleteReport   = errors.New("incomplete chunked report")
+	ErrReportSizeTooSmall = errors.New("max report size leaves no room for the chunk header")
+)
+
+// chunkPrefix starts the header of every chunk, "chunk:<index>/<total>:<sha256>\n",
+// where index counts from 1 and sha256 is the hex digest of the whole payload.
+const chunkPrefix = "chunk:"
+
+// SetMaxReportSize sets the size above which Report splits a payload into
+// chunks. It panics if size leaves no room for the chunk header.
+func (r *Router) SetMaxReportSize(size int) {
+	if size < minMaxReportSize {
+		panic(fmt.Sprintf("router: max report size %d is below %d", size, minMaxReportSize))
+	}
+	r.maxReportSize = size
+}
+
+type maxReportSizeKey struct{}
+
+func withMaxReportSize(ctx context.Context, size int) context.Context {
+	return context.WithValue(ctx, maxReportSizeKey{}, size)
+}
+
+func maxReportSizeFromContext(ctx context.Context) int {
+	size, ok := ctx.Value(maxReportSizeKey{}).(int)
+	if !ok {
+		return DefaultMaxReportSize
+	}
+	return size
+}
+
+// Report sends payload as a single report or, when it exceeds the max report
+// size of the router dispatching the request, as numbered chunks that
+// ReassembleReports puts back together.
+func Report(ctx context.Context, env rollmelette.EnvInspector, payload []byte) {
+	for _, chunk := range splitReport(payload, maxReportSizeFromContext(ctx)) {
+		env.Report(chunk)
+	}
+}
+
+// SplitReport returns payload as is if it fits in size bytes, and otherwise
+// the chunks, header included, of at most size bytes each. A payload that
+// starts like a chunk header is always chunked, even into a single chunk, so
+// that ReassembleReports never mistakes it for one. size must be at least 256,
+// as SetMaxReportSize requires.
+func SplitReport(payload []byte, size int) ([][]byte, error) {
+	if size < minMaxReportSize {
+		return nil, fmt.Errorf("%w: %d is below %d", ErrReportSizeTooSmall, size, minMaxReportSize)
+	}
+	return splitReport(payload, size), nil
+}
+
+// splitReport is SplitReport for a size already checked against
+// minMaxReportSize, below which the chunk count may never settle.
+func splitReport(payload []byte, size int) [][]byte {
+	if len(payload) <= size && !bytes.HasPrefix(payload, []byte(chunkPrefix)) {
+		return [][]byte{payload}
+	}
+	total := 1
+	for (size-chunkHeaderLen(total))*total < len(payload) {
+		total++
+	}
+	data := size - chunkHeaderLen(total)
+
+	sum := sha256.Sum256(payload)
+	digest := hex.EncodeToString(sum[:])
+	chunks := make([][]byte, total)
+	for i := range chunks {
+		part := payload[i*data : min((i+1)*data, len(payload))]
+		header := fmt.Sprintf("%s%d/%d:%s\n", chunkPrefix, i+1, total, digest)
+		chunks[i] = append([]byte(header), part...)
+	}
+	return chunks
+}
+
+func chunkHeaderLen(total int) int {
+	digits := len(strconv.Itoa(total))
+	return len(chunkPrefix) + 2*digits + len("/:") + 2*sha256.Size + len("\n")
+}
+
+// ReportChunk is a part of a report split by Report.
+type ReportChunk struct {
+	Index int
+	Total int
+	Hash  string
+	Data  []byte
+}
+
+// ParseReportChunk reads the header of report, returning false if report was
+// not split.
+func ParseReportChunk(report []byte) (*ReportChunk, bool, error) {
+	if !bytes.HasPrefix(report, []byte(chunkPrefix)) {
+		return nil, false, nil
+	}
+	header, data, ok := bytes.Cut(report[len(chunkPrefix):], []byte("\n"))
+	if !ok {
+		return nil, true, fmt.Errorf("%w: missing header", ErrInvalidChunk)
+	}
+	var chunk ReportChunk
+	position, hash, ok := bytes.Cut(header, []byte(":"))
+	if !ok || len(hash) != 2*sha256.Size {
+		return nil, true, fmt.Errorf("%w: malformed header %q", ErrInvalidChunk, header)
+	}
+	if _, err := fmt.Sscanf(string(position), "%d/%d", &chunk.Index, &chunk.Total); err != nil {
+		return nil, true, fmt.Errorf("%w: malformed header %q", ErrInvalidChunk, header)
+	}
+	if chunk.Index < 1 || chunk.Index > chunk.Total {
+		return nil, true, fmt.Errorf("%w: chunk %d of %d", ErrInvalidChunk, chunk.Index, chunk.Total)
+	}
+	chunk.Hash = string(hash)
+	chunk.Data = data
+	return &chunk, true, nil
+}
+
+// ReassembleReports rebuilds the payload sent by Report from the reports of a
+// request, in any order, checking it against the hash of the chunks.
+func ReassembleReports(reports [][]byte) ([]byte, error) {
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("%w: no reports", ErrIncompleteReport)
+	}
+	first, chunked, err := ParseReportChunk(reports[0])
+	if err != nil {
+		return nil, err
+	}
+	if !chunked {
+		if len(reports) != 1 {
+			return nil, fmt.Errorf("%w: %d reports without chunk headers", ErrInvalidChunk, len(reports))
+		}
+		return reports[0], nil
+	}
+	if len(reports) != first.Total {
+		return nil, fmt.Errorf("%w: got %d of %d chunks", ErrIncompleteReport, len(reports), first.Total)
+	}
+
+	parts := make([][]byte, first.Total)
+	for _, report := range reports {
+		chunk, chunked, err := ParseReportChunk(report)
+		if err != nil {
+			return nil, err
+		}
+		if !chunked || chunk.Total != first.Total || chunk.Hash != first.Hash {
+			return nil, fmt.Errorf("%w: chunk of another report", ErrInvalidChunk)
+		}
+		if parts[chunk.Index-1] != nil {
+			return nil, fmt.Errorf("%w: duplicate chunk %d", ErrInvalidChunk, chunk.Index)
+		}
+		parts[chunk.Index-1] = chunk.Data
+	}
+
+	payload := bytes.Join(parts, nil)
+	sum := sha256.Sum256(payload)
+	if hex.EncodeToString(sum[:]) != first.Hash {
+		return nil, fmt.Errorf("%w: hash mismatch", ErrInvalidChunk)
+	}
+	return payload, nil
+}
@@ -1,33 +1,49 @@
 package router
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/rollmelette/rollmelette"
 )
 
-type AdvanceHandlerFunc func(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error
+type AdvanceHandlerFunc func(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error
 
-type InspectHandlerFunc func(env rollmelette.EnvInspector, payload []byte) error
+type InspectHandlerFunc func(ctx context.Context, env rollmelette.EnvInspector, payload []byte) error
 
 type Router struct {
 	advanceHandlers map[string]AdvanceHandlerFunc
 	inspectHandlers map[string]InspectHandlerFunc
+	advanceRoutes   []route
+	inspectRoutes   []route
+	abiRoutes       map[[4]byte]abiRoute
+	routes          []RouteInfo
 	middlewares     []Middleware
+	outputEncoders  []OutputEncoder
+	maxReportSize   int
 }
 
 func NewRouter() *Router {
-	return &Router{
+	r := &Router{
 		advanceHandlers: make(map[string]AdvanceHandlerFunc),
 		inspectHandlers: make(map[string]InspectHandlerFunc),
 		middlewares:     make([]Middleware, 0),
+		outputEncoders:  []OutputEncoder{JSONOutputEncoder{}},
+		maxReportSize:   DefaultMaxReportSize,
 	}
+	HandleInspectTyped(r, RoutesPath, r.inspectRoutesManifest)
+	return r
 }
 
+// Use appends router-level middleware. It is applied when a request is dispatched,
+// so it also covers handlers registered before the call.
 func (r *Router) Use(middleware ...Middleware) {
+	for _, m := range middleware {
+		m.check()
+	}
 	r.middlewares = append(r.middlewares, middleware...)
 }
 
@@ -38,20 +54,57 @@ func (r *Router) Group(prefix string) *Group {
 	}
 }
 
-func (r *Router) HandleAdvance(path string, handler AdvanceHandlerFunc) {
-	for i := len(r.middlewares) - 1; i >= 0; i-- {
-		handler = r.middlewares[i](handler).(AdvanceHandlerFunc)
+// HandleAdvance registers handler for path, wrapped by the optional per-route middleware.
+func (r *Router) HandleAdvance(path string, handler AdvanceHandlerFunc, middleware ...Middleware) {
+	r.handleAdvance(path, handler, nil, middleware)
+}
+
+// HandleInspect registers handler for path, wrapped by the optional per-route middleware.
+func (r *Router) HandleInspect(path string, handler InspectHandlerFunc, middleware ...Middleware) {
+	r.handleInspect(path, handler, nil, middleware)
+}
+
+func (r *Router) handleAdvance(path string, handler AdvanceHandlerFunc, input reflect.Type, middleware []Middleware) {
+	r.addAdvance(nil, path, handler, input, middleware)
+}
+
+func (r *Router) handleInspect(path string, handler InspectHandlerFunc, input reflect.Type, middleware []Middleware) {
+	r.addInspect(nil, path, handler, input, middleware)
+}
+
+// addAdvance registers handler wrapped by the per-route middleware. The
+// middleware of group, if any, is wrapped around it on dispatch.
+func (r *Router) addAdvance(group *Group, path string, handler AdvanceHandlerFunc, input reflect.Type, middleware []Middleware) {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i].wrapAdvance(handler)
+	}
+	if group != nil {
+		handler = group.wrapAdvance(handler)
 	}
 
+	path = strings.Trim(path, "/")
+	if _, exists := r.advanceHandlers[path]; !exists {
+		r.advanceRoutes = append(r.advanceRoutes, compileRoute(path))
+	}
 	r.advanceHandlers[path] = handler
+	r.addRouteInfo(AdvanceRoute, group, path, input, middleware)
 }
 
-func (r *Router) HandleInspect(path string, handler InspectHandlerFunc) {
-	for i := len(r.middlewares) - 1; i >= 0; i-- {
-		handler = r.middlewares[i](handler).(InspectHandlerFunc)
+// addInspect is the inspect counterpart of addAdvance.
+func (r *Router) addInspect(group *Group, path string, handler InspectHandlerFunc, input reflect.Type, middleware []Middleware) {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i].wrapInspect(handler)
+	}
+	if group != nil {
+		handler = group.wrapInspect(handler)
 	}
 
+	path = strings.Trim(path, "/")
+	if _, exists := r.inspectHandlers[path]; !exists {
+		r.inspectRoutes = append(r.inspectRoutes, compileRoute(path))
+	}
 	r.inspectHandlers[path] = handler
+	r.addRouteInfo(InspectRoute, group, path, input, middleware)
 }
 
 type Request struct {
@@ -62,43 +115,75 @@ type Request struct {
 func parseRequestRawPayload(payload []byte) (*Request, error) {
 	var req Request
 	if err := json.Unmarshal(payload, &req); err != nil {
-		return nil, fmt.Errorf("invalid request format: %v", err)
+		return nil, fmt.Errorf("%w format: %v", ErrInvalidRequest, err)
 	}
 
-	validator := validator.New()
-	if err := validator.Struct(req); err != nil {
-		return nil, fmt.Errorf("invalid request: %w", err)
+	if err := validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
 	}
 
 	return &req, nil
 }
 
 func (r *Router) Advance(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	req, err := parseRequestRawPayload(payload)
+	ctx, handler, data := r.routeAdvance(payload)
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		handler = r.middlewares[i].wrapAdvance(handler)
+	}
+	return handler(ctx, env, metadata, deposit, data)
+}
+
+func (r *Router) Inspect(env rollmelette.EnvInspector, payload []byte) error {
+	ctx, handler, data := r.routeInspect(payload)
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		handler = r.middlewares[i].wrapInspect(handler)
+	}
+	return handler(ctx, env, data)
+}
+
+// routeAdvance resolves the handler for payload. Requests that cannot be routed
+// get a handler returning the error, so router-level middleware still sees it.
+func (r *Router) routeAdvance(payload []byte) (context.Context, AdvanceHandlerFunc, []byte) {
+	ctx := withOutputEncoders(context.Background(), r.outputEncoders)
+	ctx = withMaxReportSize(ctx, r.maxReportSize)
+	req, err := r.parseAdvanceRequest(payload)
 	if err != nil {
-		return fmt.Errorf("invalid request: %w", err)
+		return ctx, advanceError(err), nil
 	}
 
 	path := strings.Trim(req.Path, "/")
-	handler, exists := r.advanceHandlers[path]
+	ctx = WithPath(ctx, path)
+	handler, params, exists := lookup(r.advanceHandlers, r.advanceRoutes, path)
 	if !exists {
-		return fmt.Errorf("no handler found for path: %s", path)
+		return ctx, advanceError(fmt.Errorf("%w: %s", ErrRouteNotFound, path)), nil
 	}
-
-	return handler(env, metadata, deposit, req.Data)
+	return WithParams(ctx, params), handler, req.Data
 }
 
-func (r *Router) Inspect(env rollmelette.EnvInspector, payload []byte) error {
+func (r *Router) routeInspect(payload []byte) (context.Context, InspectHandlerFunc, []byte) {
+	ctx := withMaxReportSize(context.Background(), r.maxReportSize)
 	req, err := parseRequestRawPayload(payload)
 	if err != nil {
-		return fmt.Errorf("invalid request: %w", err)
+		return ctx, inspectError(err), nil
 	}
 
 	path := strings.Trim(req.Path, "/")
-	handler, exists := r.inspectHandlers[path]
+	ctx = WithPath(ctx, path)
+	handler, params, exists := lookup(r.inspectHandlers, r.inspectRoutes, path)
 	if !exists {
-		return fmt.Errorf("no handler found for path: %s", path)
+		return ctx, inspectError(fmt.Errorf("%w: %s", ErrRouteNotFound, path)), nil
+	}
+	return WithParams(ctx, params), handler, req.Data
+}
+
+func advanceError(err error) AdvanceHandlerFunc {
+	return func(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
+		return err
 	}
+}
 
-	return handler(env, req.Data)
+func inspectError(err error) InspectHandlerFunc {
+	return func(ctx context.Context, env rollmelette.EnvInspector, payload []byte) error {
+		return err
+	}
 }
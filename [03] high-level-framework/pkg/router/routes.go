@@ -0,0 +1,207 @@
+package router
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/rollmelette/rollmelette"
+)
+
+// RoutesPath is the built-in inspect route that reports the API manifest.
+const RoutesPath = "__routes"
+
+type RouteKind string
+
+const (
+	AdvanceRoute RouteKind = "advance"
+	InspectRoute RouteKind = "inspect"
+)
+
+type RouteInfo struct {
+	Path       string
+	Kind       RouteKind
+	Params     []string
+	Middleware []string
+	// Input is the DTO type of routes registered through HandleAdvanceTyped or
+	// HandleInspectTyped, and nil for plain handlers.
+	Input reflect.Type
+	// Signature is the ABI method bound to an advance route through HandleAdvanceABI.
+	Signature string
+
+	group *Group
+}
+
+type RouteManifest struct {
+	Path       string    `json:"path"`
+	Kind       RouteKind `json:"kind"`
+	Params     []string  `json:"params,omitempty"`
+	Middleware []string  `json:"middleware,omitempty"`
+	Input      *Schema   `json:"input,omitempty"`
+	Signature  string    `json:"signature,omitempty"`
+}
+
+func (r *Router) addRouteInfo(kind RouteKind, group *Group, path string, input reflect.Type, middleware []Middleware) {
+	info := RouteInfo{
+		Path:       path,
+		Kind:       kind,
+		Params:     compileRoute(path).params(),
+		Middleware: middlewareNames(middleware),
+		Input:      input,
+		group:      group,
+	}
+	for i, existing := range r.routes {
+		if existing.Path == path && existing.Kind == kind {
+			r.routes[i] = info
+			return
+		}
+	}
+	r.routes = append(r.routes, info)
+}
+
+// Routes lists every registered route in registration order. Middleware names
+// include the router-level and group middleware that is applied at dispatch.
+func (r *Router) Routes() []RouteInfo {
+	global := middlewareNames(r.middlewares)
+	routes := make([]RouteInfo, len(r.routes))
+	for i, info := range r.routes {
+		middleware := append([]string{}, global...)
+		if info.group != nil {
+			middleware = append(middleware, middlewareNames(info.group.chain())...)
+		}
+		info.Middleware = append(middleware, info.Middleware...)
+		info.group = nil
+		if info.Kind == AdvanceRoute {
+			info.Signature = r.abiSignature(info.Path)
+		}
+		routes[i] = info
+	}
+	return routes
+}
+
+// Manifest describes every route with a JSON schema of its input DTO.
+func (r *Router) Manifest() []RouteManifest {
+	routes := r.Routes()
+	manifest := make([]RouteManifest, len(routes))
+	for i, info := range routes {
+		manifest[i] = RouteManifest{
+			Path:       info.Path,
+			Kind:       info.Kind,
+			Params:     info.Params,
+			Middleware: info.Middleware,
+			Signature:  info.Signature,
+		}
+		if info.Input != nil {
+			manifest[i].Input = SchemaOf(info.Input)
+		}
+	}
+	return manifest
+}
+
+func (r *Router) inspectRoutesManifest(ctx context.Context, env rollmelette.EnvInspector, input *Empty) ([]RouteManifest, error) {
+	return r.Manifest(), nil
+}
+
+func (r *Router) abiSignature(path string) string {
+	for _, route := range r.abiRoutes {
+		if route.path == path {
+			return route.method.Sig
+		}
+	}
+	return ""
+}
+
+func middlewareNames(middleware []Middleware) []string {
+	names := make([]string, 0, len(middleware))
+	for _, m := range middleware {
+		if m.Name != "" {
+			names = append(names, m.Name)
+		}
+	}
+	return names
+}
+
+func (r route) params() []string {
+	var params []string
+	for _, s := range r.segments {
+		if s.kind != staticSegment {
+			params = append(params, s.value)
+		}
+	}
+	return params
+}
+
+// Schema is the subset of JSON Schema needed to describe request DTOs.
+type Schema struct {
+	Type       string             `json:"type,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Enum       []string           `json:"enum,omitempty"`
+}
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// SchemaOf builds a schema from the json and validate struct tags of t. Types
+// with their own JSON or text encoding, such as Address and uint256.Int, are
+// described as strings.
+func SchemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string"}
+		}
+		return &Schema{Type: "array", Items: SchemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	case reflect.Struct:
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			property := SchemaOf(field.Type)
+			for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+				switch {
+				case rule == "required":
+					schema.Required = append(schema.Required, name)
+				case strings.HasPrefix(rule, "oneof="):
+					property.Enum = strings.Fields(strings.TrimPrefix(rule, "oneof="))
+				}
+			}
+			schema.Properties[name] = property
+		}
+		return schema
+	}
+	return &Schema{}
+}
@@ -0,0 +1,110 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/rollmelette/rollmelette"
+)
+
+// validate is shared by every request so struct metadata is parsed only once.
+var validate = validator.New()
+
+// RegisterValidations lets register add custom validate tags, available to the
+// inputs of every typed handler.
+func RegisterValidations(register func(v *validator.Validate) error) error {
+	return register(validate)
+}
+
+// Empty is the input type for routes that take no data.
+type Empty struct{}
+
+type TypedAdvanceFunc[In, Out any] func(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *In) (Out, error)
+
+type TypedInspectFunc[In, Out any] func(ctx context.Context, env rollmelette.EnvInspector, input *In) (Out, error)
+
+// AdvanceRegistrar is implemented by Router and Group.
+type AdvanceRegistrar interface {
+	HandleAdvance(path string, handler AdvanceHandlerFunc, middleware ...Middleware)
+	handleAdvance(path string, handler AdvanceHandlerFunc, input reflect.Type, middleware []Middleware)
+}
+
+// InspectRegistrar is implemented by Router and Group.
+type InspectRegistrar interface {
+	HandleInspect(path string, handler InspectHandlerFunc, middleware ...Middleware)
+	handleInspect(path string, handler InspectHandlerFunc, input reflect.Type, middleware []Middleware)
+}
+
+// HandleAdvanceTyped registers fn on r. The request data is bound into In and
+// validated, and the result is emitted as event through the router's output
+// encoders. An empty event leaves emitting outputs to fn.
+func HandleAdvanceTyped[In, Out any](r AdvanceRegistrar, path string, event string, fn TypedAdvanceFunc[In, Out], middleware ...Middleware) {
+	r.handleAdvance(path, AdvanceTyped(event, fn), reflect.TypeFor[In](), middleware)
+}
+
+// HandleInspectTyped registers fn on r, reporting its result as JSON.
+func HandleInspectTyped[In, Out any](r InspectRegistrar, path string, fn TypedInspectFunc[In, Out], middleware ...Middleware) {
+	r.handleInspect(path, InspectTyped(fn), reflect.TypeFor[In](), middleware)
+}
+
+func AdvanceTyped[In, Out any](event string, fn TypedAdvanceFunc[In, Out]) AdvanceHandlerFunc {
+	return func(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
+		input, err := Decode[In](ctx, payload)
+		if err != nil {
+			return err
+		}
+
+		res, err := fn(ctx, env, metadata, deposit, input)
+		if err != nil {
+			return err
+		}
+		if event == "" {
+			return nil
+		}
+		return Emit(ctx, env, event, res)
+	}
+}
+
+func InspectTyped[In, Out any](fn TypedInspectFunc[In, Out]) InspectHandlerFunc {
+	return func(ctx context.Context, env rollmelette.EnvInspector, payload []byte) error {
+		input, err := Decode[In](ctx, payload)
+		if err != nil {
+			return err
+		}
+
+		res, err := fn(ctx, env, input)
+		if err != nil {
+			return err
+		}
+
+		report, err := json.Marshal(res)
+		if err != nil {
+			return fmt.Errorf("failed to marshal response: %w", err)
+		}
+		Report(ctx, env, report)
+		return nil
+	}
+}
+
+// Decode binds the request data and path parameters into a new In and validates it.
+func Decode[In any](ctx context.Context, payload []byte) (*In, error) {
+	var input In
+	if err := Bind(ctx, payload, &input); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal input: %w", ErrInvalidInput, err)
+	}
+	if err := validate.Struct(input); err != nil {
+		return nil, fmt.Errorf("%w: failed to validate input: %w", ErrInvalidInput, err)
+	}
+	return &input, nil
+}
+
+func NoticeEnvelope(event string, v any) ([]byte, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return append([]byte(event+" - "), body...), nil
+}
@@ -1,6 +1,8 @@
 package test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
@@ -9,7 +11,10 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/cmd/root"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/domain"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/internal/infra/repository/factory"
+	"github.com/henriquemarlon/cartesi-golang-series/high-level-framework/pkg/router"
 	"github.com/rollmelette/rollmelette"
 	"github.com/stretchr/testify/suite"
 )
@@ -21,6 +26,7 @@ func TestVotingSystem(t *testing.T) {
 type VotingSystemSuite struct {
 	suite.Suite
 	tester *rollmelette.Tester
+	repo   repository.Repository
 }
 
 func (s *VotingSystemSuite) SetupTest() {
@@ -29,6 +35,7 @@ func (s *VotingSystemSuite) SetupTest() {
 		slog.Error("Failed to setup in-memory SQLite database", "error", err)
 		os.Exit(1)
 	}
+	s.repo = repo
 	dapp := root.NewVotingSystem(repo)
 	s.tester = rollmelette.NewTester(dapp)
 }
@@ -136,35 +143,74 @@ func (s *VotingSystemSuite) TestInspectVotingHandlers() {
 	createVotingInput := []byte(fmt.Sprintf(`{"path":"voting/create","data":{"title":"Test Voting","start_date":%d,"end_date":%d}}`, startDate, endDate))
 	result := s.tester.Advance(candidate, createVotingInput)
 	s.Len(result.Notices, 1)
-	s.Equal(fmt.Sprintf(`voting created - {"id":1,"title":"Test Voting","Creator":"%s","status":"open","start_date":%d,"end_date":%d}`, candidate.Hex(), startDate, endDate), string(result.Notices[0].Payload))
+	s.Equal(fmt.Sprintf(`voting created - {"id":1,"title":"Test Voting","Creator":"%s","status":"pending","start_date":%d,"end_date":%d}`, candidate.Hex(), startDate, endDate), string(result.Notices[0].Payload))
 
 	// Test FindAll
 	findAllInput := []byte(`{"path":"voting","data":{}}`)
 	inspectResult := s.tester.Inspect(findAllInput)
 	s.Nil(inspectResult.Err)
-	expectedFindAll := fmt.Sprintf(`[{"id":1,"title":"Test Voting","status":"open","start_date":%d,"end_date":%d}]`, startDate, endDate)
+	expectedFindAll := fmt.Sprintf(`{"items":[{"id":1,"title":"Test Voting","status":"pending","start_date":%d,"end_date":%d}],"total":1,"limit":20,"offset":0}`, startDate, endDate)
 	s.Equal(expectedFindAll, string(inspectResult.Reports[0].Payload))
 
+	// Test FindAll filtered by status and start date
+	findPendingInput := []byte(fmt.Sprintf(`{"path":"voting","data":{"state":"pending","from":%d,"to":%d}}`, startDate, startDate))
+	inspectResult = s.tester.Inspect(findPendingInput)
+	s.Nil(inspectResult.Err)
+	s.Equal(expectedFindAll, string(inspectResult.Reports[0].Payload))
+
+	findOpenInput := []byte(`{"path":"voting","data":{"state":"open"}}`)
+	inspectResult = s.tester.Inspect(findOpenInput)
+	s.Nil(inspectResult.Err)
+	s.Equal(`{"items":[],"total":0,"limit":20,"offset":0}`, string(inspectResult.Reports[0].Payload))
+
+	findClosedInput := []byte(`{"path":"voting","data":{"state":"closed"}}`)
+	inspectResult = s.tester.Inspect(findClosedInput)
+	s.Nil(inspectResult.Err)
+	s.Equal(`{"items":[],"total":0,"limit":20,"offset":0}`, string(inspectResult.Reports[0].Payload))
+
+	findAfterStartInput := []byte(fmt.Sprintf(`{"path":"voting","data":{"from":%d,"limit":1}}`, startDate+1))
+	inspectResult = s.tester.Inspect(findAfterStartInput)
+	s.Nil(inspectResult.Err)
+	s.Equal(`{"items":[],"total":0,"limit":1,"offset":0}`, string(inspectResult.Reports[0].Payload))
+
+	for _, sort := range []string{"creator", "status"} {
+		invalidQueryInput := []byte(fmt.Sprintf(`{"path":"voting","data":{"sort":%q}}`, sort))
+		inspectResult = s.tester.Inspect(invalidQueryInput)
+		s.Require().Len(inspectResult.Reports, 1)
+		s.Contains(string(inspectResult.Reports[0].Payload), `"code":"invalid_query"`)
+	}
+
 	// Test FindByID
 	findByIdInput := []byte(`{"path":"voting/id","data":{"id":1}}`)
 	inspectResult = s.tester.Inspect(findByIdInput)
 	s.Nil(inspectResult.Err)
-	expectedFindById := fmt.Sprintf(`{"id":1,"title":"Test Voting","status":"open","start_date":%d,"end_date":%d}`, startDate, endDate)
+	expectedFindById := fmt.Sprintf(`{"id":1,"title":"Test Voting","status":"pending","start_date":%d,"end_date":%d}`, startDate, endDate)
 	s.Equal(expectedFindById, string(inspectResult.Reports[0].Payload))
 
 	// Test FindAllActive
 	findActiveInput := []byte(`{"path":"voting/active","data":{}}`)
 	inspectResult = s.tester.Inspect(findActiveInput)
 	s.Nil(inspectResult.Err)
-	expectedFindActive := fmt.Sprintf(`[{"id":1,"title":"Test Voting","status":"open","start_date":%d,"end_date":%d}]`, startDate, endDate)
-	s.Equal(expectedFindActive, string(inspectResult.Reports[0].Payload))
+	s.Equal(`null`, string(inspectResult.Reports[0].Payload))
 
 	// Test GetResults
 	getResultsInput := []byte(`{"path":"voting/results","data":{"id":1}}`)
 	inspectResult = s.tester.Inspect(getResultsInput)
 	s.Nil(inspectResult.Err)
-	expectedGetResults := `{"id":1,"title":"Test Voting","status":"open","total_votes":0,"options":[],"winner_id":0,"winner_votes":0}`
+	expectedGetResults := `{"id":1,"title":"Test Voting","status":"pending","total_votes":0,"options":[],"winner_id":0,"winner_votes":0}`
 	s.Equal(expectedGetResults, string(inspectResult.Reports[0].Payload))
+
+	// Test path parameters
+	inspectResult = s.tester.Inspect([]byte(`{"path":"voting/1"}`))
+	s.Nil(inspectResult.Err)
+	s.Equal(expectedFindById, string(inspectResult.Reports[0].Payload))
+
+	inspectResult = s.tester.Inspect([]byte(`{"path":"voting/1/results"}`))
+	s.Nil(inspectResult.Err)
+	s.Equal(expectedGetResults, string(inspectResult.Reports[0].Payload))
+
+	inspectResult = s.tester.Inspect([]byte(`{"path":"voting/abc"}`))
+	s.ErrorContains(inspectResult.Err, "invalid path parameter")
 }
 
 func (s *VotingSystemSuite) TestInspectVoterHandlers() {
@@ -186,6 +232,14 @@ func (s *VotingSystemSuite) TestInspectVoterHandlers() {
 	s.Nil(inspectResult.Err)
 	expectedFindByAddress := fmt.Sprintf(`{"id":1,"address":"%s"}`, admin.Hex())
 	s.Equal(expectedFindByAddress, string(inspectResult.Reports[0].Payload))
+
+	inspectResult = s.tester.Inspect([]byte(`{"path":"voter/1"}`))
+	s.Nil(inspectResult.Err)
+	s.Equal(expectedFindById, string(inspectResult.Reports[0].Payload))
+
+	inspectResult = s.tester.Inspect([]byte(fmt.Sprintf(`{"path":"voter/address/%s"}`, admin)))
+	s.Nil(inspectResult.Err)
+	s.Equal(expectedFindByAddress, string(inspectResult.Reports[0].Payload))
 }
 
 func (s *VotingSystemSuite) TestInspectVotingOptionHandlers() {
@@ -198,7 +252,7 @@ func (s *VotingSystemSuite) TestInspectVotingOptionHandlers() {
 	createVotingInput := []byte(fmt.Sprintf(`{"path":"voting/create","data":{"title":"Test Voting","start_date":%d,"end_date":%d}}`, startDate, endDate))
 	result := s.tester.Advance(candidate, createVotingInput)
 	s.Len(result.Notices, 1)
-	s.Equal(fmt.Sprintf(`voting created - {"id":1,"title":"Test Voting","Creator":"%s","status":"open","start_date":%d,"end_date":%d}`, candidate.Hex(), startDate, endDate), string(result.Notices[0].Payload))
+	s.Equal(fmt.Sprintf(`voting created - {"id":1,"title":"Test Voting","Creator":"%s","status":"pending","start_date":%d,"end_date":%d}`, candidate.Hex(), startDate, endDate), string(result.Notices[0].Payload))
 
 	createOptionInput := []byte(`{"path":"voting-option/create","data":{"voting_id":1}}`)
 	result = s.tester.Advance(candidate, createOptionInput)
@@ -216,6 +270,14 @@ func (s *VotingSystemSuite) TestInspectVotingOptionHandlers() {
 	s.Nil(inspectResult.Err)
 	expectedFindByVotingId := `[{"id":1,"voting_id":1,"vote_count":0}]`
 	s.Equal(expectedFindByVotingId, string(inspectResult.Reports[0].Payload))
+
+	inspectResult = s.tester.Inspect([]byte(`{"path":"voting-option/1"}`))
+	s.Nil(inspectResult.Err)
+	s.Equal(expectedFindById, string(inspectResult.Reports[0].Payload))
+
+	inspectResult = s.tester.Inspect([]byte(`{"path":"voting/1/options"}`))
+	s.Nil(inspectResult.Err)
+	s.Equal(expectedFindByVotingId, string(inspectResult.Reports[0].Payload))
 }
 
 func (s *VotingSystemSuite) TestVotingWorkflow() {
@@ -229,7 +291,7 @@ func (s *VotingSystemSuite) TestVotingWorkflow() {
 	createVotingInput := []byte(fmt.Sprintf(`{"path":"voting/create","data":{"title":"Test Voting","start_date":%d,"end_date":%d}}`, startDate, endDate))
 	result := s.tester.Advance(candidate, createVotingInput)
 	s.Len(result.Notices, 1)
-	s.Equal(fmt.Sprintf(`voting created - {"id":1,"title":"Test Voting","Creator":"%s","status":"open","start_date":%d,"end_date":%d}`, candidate.Hex(), startDate, endDate), string(result.Notices[0].Payload))
+	s.Equal(fmt.Sprintf(`voting created - {"id":1,"title":"Test Voting","Creator":"%s","status":"pending","start_date":%d,"end_date":%d}`, candidate.Hex(), startDate, endDate), string(result.Notices[0].Payload))
 
 	createVoterInput := []byte(`{"path":"voter/create","data":{}}`)
 	result = s.tester.Advance(admin, createVoterInput)
@@ -244,7 +306,7 @@ func (s *VotingSystemSuite) TestVotingWorkflow() {
 	findVotingInput := []byte(`{"path":"voting/id","data":{"id":1}}`)
 	inspectResult := s.tester.Inspect(findVotingInput)
 	s.Nil(inspectResult.Err)
-	expectedFindVoting := fmt.Sprintf(`{"id":1,"title":"Test Voting","status":"open","start_date":%d,"end_date":%d}`, startDate, endDate)
+	expectedFindVoting := fmt.Sprintf(`{"id":1,"title":"Test Voting","status":"pending","start_date":%d,"end_date":%d}`, startDate, endDate)
 	s.Equal(expectedFindVoting, string(inspectResult.Reports[0].Payload))
 
 	findVoterInput := []byte(fmt.Sprintf(`{"path":"voter/address","data":{"address":"%s"}}`, admin))
@@ -301,13 +363,51 @@ func (s *VotingSystemSuite) TestNonExistentEntities() {
 	s.NotNil(inspectResult.Err)
 }
 
+func (s *VotingSystemSuite) TestErrorReports() {
+	candidate := common.HexToAddress("0x0000000000000000000000000000000000000007")
+	admin := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	inspectResult := s.tester.Inspect([]byte(`{"path":"voting/999"}`))
+	s.Require().Len(inspectResult.Reports, 1)
+	var report router.ErrorReport
+	s.Require().NoError(json.Unmarshal(inspectResult.Reports[0].Payload, &report))
+	s.Equal("not_found", report.Code)
+	s.Equal("voting/999", report.Path)
+	s.Nil(report.InputIndex)
+
+	inspectResult = s.tester.Inspect([]byte(`{"path":"voting/unknown/route"}`))
+	s.Require().Len(inspectResult.Reports, 1)
+	s.Equal(`{"code":"route_not_found","message":"no handler found for path: voting/unknown/route","path":"voting/unknown/route"}`, string(inspectResult.Reports[0].Payload))
+
+	baseTime := time.Now().Unix()
+	createVotingInput := []byte(fmt.Sprintf(`{"path":"voting/create","data":{"title":"Test Voting","start_date":%d,"end_date":%d}}`, baseTime+60, baseTime+120))
+	result := s.tester.Advance(candidate, createVotingInput)
+	s.Require().NoError(result.Err)
+
+	result = s.tester.Advance(admin, []byte(`{"path":"voting/delete","data":{"id":1}}`))
+	s.ErrorIs(result.Err, domain.ErrUnauthorized)
+	s.Require().Len(result.Reports, 1)
+	report = router.ErrorReport{}
+	s.Require().NoError(json.Unmarshal(result.Reports[0].Payload, &report))
+	s.Equal("unauthorized", report.Code)
+	s.Equal("voting/delete", report.Path)
+	s.Require().NotNil(report.InputIndex)
+	s.Equal(1, *report.InputIndex)
+
+	result = s.tester.Advance(candidate, []byte(`{"path":"voting-option/create","data":{}}`))
+	s.Require().Len(result.Reports, 1)
+	report = router.ErrorReport{}
+	s.Require().NoError(json.Unmarshal(result.Reports[0].Payload, &report))
+	s.Equal("invalid_input", report.Code)
+}
+
 func (s *VotingSystemSuite) TestVotingFlow() {
 	candidate := common.HexToAddress("0x0000000000000000000000000000000000000007")
 	admin := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
 
 	baseTime := time.Now().Unix()
-	startDate := baseTime + 60
-	endDate := baseTime + 120
+	startDate := baseTime + 1
+	endDate := baseTime + 2
 
 	createVotingInput := []byte(fmt.Sprintf(`{"path":"voting/create","data":{"title":"Test Voting","start_date":%d,"end_date":%d}}`, startDate, endDate))
 	result := s.tester.Advance(candidate, createVotingInput)
@@ -328,11 +428,84 @@ func (s *VotingSystemSuite) TestVotingFlow() {
 	s.Contains(string(result.Notices[0].Payload), "voting option created")
 
 	voteInput := []byte(`{"path":"voting/vote","data":{"voting_id":1,"option_id":1}}`)
+	result = s.tester.Advance(admin, voteInput)
+	s.ErrorIs(result.Err, domain.ErrVotingPending)
+
+	// The tester stamps inputs with the wall clock, so wait for the block
+	// timestamp to reach the start date.
+	time.Sleep(time.Until(time.Unix(startDate, 0)))
+
 	result = s.tester.Advance(admin, voteInput)
 	s.Nil(result.Err, "Failed to vote")
 	s.Len(result.Notices, 1, "Expected one notice for vote")
 	s.Contains(string(result.Notices[0].Payload), "vote registered")
 
 	result = s.tester.Advance(admin, voteInput)
-	s.NotNil(result.Err, "Expected error when voting twice")
+	s.ErrorIs(result.Err, domain.ErrAlreadyVoted)
+
+	time.Sleep(time.Until(time.Unix(endDate, 0)))
+
+	result = s.tester.Advance(admin, voteInput)
+	s.ErrorIs(result.Err, domain.ErrVotingClosed)
+
+	// The rejected vote leaves the status stored by the accepted one.
+	voting, err := s.repo.FindVotingByID(context.Background(), 1)
+	s.Require().NoError(err)
+	s.Equal(domain.VotingStatusOpen, voting.Status)
+
+	inspectResult := s.tester.Inspect([]byte(`{"path":"voting/1"}`))
+	s.Nil(inspectResult.Err)
+	s.Equal(fmt.Sprintf(`{"id":1,"title":"Test Voting","status":"closed","start_date":%d,"end_date":%d}`, startDate, endDate), string(inspectResult.Reports[0].Payload))
+}
+
+func (s *VotingSystemSuite) TestInspectAfterRestart() {
+	candidate := common.HexToAddress("0x0000000000000000000000000000000000000007")
+	admin := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	baseTime := time.Now().Unix()
+	startDate := baseTime + 1
+	endDate := baseTime + 120
+
+	createVotingInput := []byte(fmt.Sprintf(`{"path":"voting/create","data":{"title":"Test Voting","start_date":%d,"end_date":%d}}`, startDate, endDate))
+	result := s.tester.Advance(candidate, createVotingInput)
+	s.Require().NoError(result.Err)
+
+	time.Sleep(time.Until(time.Unix(startDate, 0)))
+	result = s.tester.Advance(admin, []byte(`{"path":"voter/create","data":{}}`))
+	s.Require().NoError(result.Err)
+
+	// A restarted application answers inspects at the last stored block
+	// timestamp, before any new advance comes in.
+	s.tester = rollmelette.NewTester(root.NewVotingSystem(s.repo))
+
+	inspectResult := s.tester.Inspect([]byte(`{"path":"voting/1"}`))
+	s.Require().NoError(inspectResult.Err)
+	s.Contains(string(inspectResult.Reports[0].Payload), `"status":"open"`)
+
+	inspectResult = s.tester.Inspect([]byte(`{"path":"voting","data":{"state":"open"}}`))
+	s.Require().NoError(inspectResult.Err)
+	s.Contains(string(inspectResult.Reports[0].Payload), `"total":1`)
+}
+
+func (s *VotingSystemSuite) TestRoutesManifest() {
+	inspectResult := s.tester.Inspect([]byte(`{"path":"__routes"}`))
+	s.Nil(inspectResult.Err)
+
+	var manifest []router.RouteManifest
+	s.Require().NoError(json.Unmarshal(inspectResult.Reports[0].Payload, &manifest))
+
+	var createVoting, findVoting *router.RouteManifest
+	for i := range manifest {
+		switch {
+		case manifest[i].Kind == router.AdvanceRoute && manifest[i].Path == "voting/create":
+			createVoting = &manifest[i]
+		case manifest[i].Kind == router.InspectRoute && manifest[i].Path == "voting/:id":
+			findVoting = &manifest[i]
+		}
+	}
+	s.Require().NotNil(createVoting)
+	s.Equal([]string{"title", "start_date", "end_date"}, createVoting.Input.Required)
+	s.Require().NotNil(findVoting)
+	s.Equal([]string{"id"}, findVoting.Params)
+	s.Equal("integer", findVoting.Input.Properties["id"].Type)
 }
@@ -6,10 +6,16 @@ import (
 	"log/slog"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/henriquemarlon/cartesi-golang-series/asset-handling/pkg/voucher"
 	"github.com/rollmelette/rollmelette"
 )
 
-type Application struct{}
+var addressBook = rollmelette.NewAddressBook()
+
+type Application struct {
+	erc721Wallet  erc721Wallet
+	erc1155Wallet erc1155Wallet
+}
 
 func (a *Application) Advance(
 	env rollmelette.Env,
@@ -17,6 +23,31 @@ func (a *Application) Advance(
 	deposit rollmelette.Deposit,
 	payload []byte,
 ) error {
+	// rollmelette only decodes Ether and ERC20 deposits, NFT portal inputs
+	// arrive as plain advances.
+	if deposit == nil {
+		switch metadata.MsgSender {
+		case addressBook.ERC721Portal:
+			d, _, err := decodeERC721Deposit(payload)
+			if err != nil {
+				return err
+			}
+			return a.handleERC721Deposit(env, d)
+		case addressBook.ERC1155SinglePortal:
+			d, _, err := decodeERC1155SingleDeposit(payload)
+			if err != nil {
+				return err
+			}
+			return a.handleERC1155Deposit(env, d)
+		case addressBook.ERC1155BatchPortal:
+			d, _, err := decodeERC1155BatchDeposit(payload)
+			if err != nil {
+				return err
+			}
+			return a.handleERC1155Deposit(env, d)
+		}
+	}
+
 	switch d := deposit.(type) {
 	case *rollmelette.EtherDeposit:
 		env.Notice([]byte(fmt.Sprintf("1 - Ether balance of %s: %d, before transfer to 0x0000000000000000000000000000000000000000", d.Sender, env.EtherBalanceOf(d.Sender))))
@@ -56,6 +87,80 @@ func (a *Application) Advance(
 	return nil
 }
 
+func (a *Application) handleERC721Deposit(env rollmelette.Env, d *ERC721Deposit) error {
+	a.erc721Wallet.deposit(d.Token, d.Sender, d.TokenId)
+
+	env.Notice([]byte(fmt.Sprintf("1 - ERC721 balance of %s: %d before transfer of token %d to 0x0000000000000000000000000000000000000000", d.Sender, a.erc721Wallet.balanceOf(d.Token, d.Sender), d.TokenId)))
+	if err := a.erc721Wallet.transfer(d.Token, d.Sender, common.HexToAddress("0x0000000000000000000000000000000000000000"), d.TokenId); err != nil {
+		return err
+	}
+
+	env.Notice([]byte(fmt.Sprintf(
+		"2 - Balance of %s: %d before transfer of token %d to %s",
+		common.HexToAddress("0x0000000000000000000000000000000000000000").Hex(),
+		a.erc721Wallet.balanceOf(d.Token, common.HexToAddress("0x0000000000000000000000000000000000000000")),
+		d.TokenId,
+		d.Sender,
+	)))
+	if err := a.erc721Wallet.transfer(d.Token, common.HexToAddress("0x0000000000000000000000000000000000000000"), d.Sender, d.TokenId); err != nil {
+		return err
+	}
+
+	env.Notice([]byte(fmt.Sprintf("3 - ERC721 balance of %s: %d before withdraw", d.Sender, a.erc721Wallet.balanceOf(d.Token, d.Sender))))
+	if err := a.erc721Wallet.withdraw(d.Token, d.Sender, d.TokenId); err != nil {
+		return err
+	}
+	withdraw, err := voucher.ERC721SafeTransferFrom(d.Token, env.AppAddress(), d.Sender, d.TokenId)
+	if err != nil {
+		return err
+	}
+	withdraw.Emit(env)
+
+	env.Notice([]byte(fmt.Sprintf("4 - ERC721 balance of %s: %d after withdraw", d.Sender, a.erc721Wallet.balanceOf(d.Token, d.Sender))))
+	return nil
+}
+
+func (a *Application) handleERC1155Deposit(env rollmelette.Env, d *ERC1155Deposit) error {
+	a.erc1155Wallet.deposit(d.Token, d.Sender, d.TokenIds, d.Values)
+
+	env.Notice([]byte(fmt.Sprintf("1 - ERC1155 balances of %s for ids %v: %v before transfer to 0x0000000000000000000000000000000000000000", d.Sender, d.TokenIds, a.erc1155Wallet.balancesOf(d.Token, d.TokenIds, d.Sender))))
+	if err := a.erc1155Wallet.transfer(d.Token, d.Sender, common.HexToAddress("0x0000000000000000000000000000000000000000"), d.TokenIds, d.Values); err != nil {
+		return err
+	}
+
+	env.Notice([]byte(fmt.Sprintf(
+		"2 - Balances of %s for ids %v: %v before transfer to %s",
+		common.HexToAddress("0x0000000000000000000000000000000000000000").Hex(),
+		d.TokenIds,
+		a.erc1155Wallet.balancesOf(d.Token, d.TokenIds, common.HexToAddress("0x0000000000000000000000000000000000000000")),
+		d.Sender,
+	)))
+	if err := a.erc1155Wallet.transfer(d.Token, common.HexToAddress("0x0000000000000000000000000000000000000000"), d.Sender, d.TokenIds, d.Values); err != nil {
+		return err
+	}
+
+	env.Notice([]byte(fmt.Sprintf("3 - ERC1155 balances of %s for ids %v: %v before withdraw", d.Sender, d.TokenIds, a.erc1155Wallet.balancesOf(d.Token, d.TokenIds, d.Sender))))
+	if err := a.erc1155Wallet.withdraw(d.Token, d.Sender, d.TokenIds, d.Values); err != nil {
+		return err
+	}
+	var (
+		withdraw voucher.Voucher
+		err      error
+	)
+	if d.Batch {
+		withdraw, err = voucher.ERC1155SafeBatchTransferFrom(d.Token, env.AppAddress(), d.Sender, d.TokenIds, d.Values, nil)
+	} else {
+		withdraw, err = voucher.ERC1155SafeTransferFrom(d.Token, env.AppAddress(), d.Sender, d.TokenIds[0], d.Values[0], nil)
+	}
+	if err != nil {
+		return err
+	}
+	withdraw.Emit(env)
+
+	env.Notice([]byte(fmt.Sprintf("4 - ERC1155 balances of %s for ids %v: %v after withdraw", d.Sender, d.TokenIds, a.erc1155Wallet.balancesOf(d.Token, d.TokenIds, d.Sender))))
+	return nil
+}
+
 func (a *Application) Inspect(env rollmelette.EnvInspector, payload []byte) error {
 	return nil
 }
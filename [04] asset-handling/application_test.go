@@ -2,8 +2,10 @@ package main
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rollmelette/rollmelette"
 	"github.com/stretchr/testify/suite"
@@ -71,3 +73,130 @@ func (s *ApplicationSuite) TestEtherDeposit() {
 	s.Equal(big.NewInt(10000), result.Vouchers[0].Value)
 	s.Empty(result.Vouchers[0].Payload)
 }
+
+func erc721DepositPayload(token common.Address, sender common.Address, tokenId *big.Int, execLayerData []byte) []byte {
+	data, err := portalDataArguments.Pack([]byte{}, execLayerData)
+	if err != nil {
+		panic(err)
+	}
+	portalPayload := make([]byte, 0, 2*common.AddressLength+common.HashLength+len(data))
+	portalPayload = append(portalPayload, token[:]...)
+	portalPayload = append(portalPayload, sender[:]...)
+	portalPayload = append(portalPayload, tokenId.FillBytes(make([]byte, common.HashLength))...)
+	return append(portalPayload, data...)
+}
+
+func erc1155SingleDepositPayload(token common.Address, sender common.Address, tokenId *big.Int, value *big.Int, execLayerData []byte) []byte {
+	data, err := portalDataArguments.Pack([]byte{}, execLayerData)
+	if err != nil {
+		panic(err)
+	}
+	portalPayload := make([]byte, 0, 2*common.AddressLength+2*common.HashLength+len(data))
+	portalPayload = append(portalPayload, token[:]...)
+	portalPayload = append(portalPayload, sender[:]...)
+	portalPayload = append(portalPayload, tokenId.FillBytes(make([]byte, common.HashLength))...)
+	portalPayload = append(portalPayload, value.FillBytes(make([]byte, common.HashLength))...)
+	return append(portalPayload, data...)
+}
+
+func erc1155BatchDepositPayload(token common.Address, sender common.Address, tokenIds []*big.Int, values []*big.Int, execLayerData []byte) []byte {
+	data, err := batchDataArguments.Pack(tokenIds, values, []byte{}, execLayerData)
+	if err != nil {
+		panic(err)
+	}
+	portalPayload := make([]byte, 0, 2*common.AddressLength+len(data))
+	portalPayload = append(portalPayload, token[:]...)
+	portalPayload = append(portalPayload, sender[:]...)
+	return append(portalPayload, data...)
+}
+
+func (s *ApplicationSuite) TestERC721Deposit() {
+	user := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	erc721token := common.HexToAddress("0xBa46623aD94AB45850c4ecbA9555D26328917c3B")
+	result := s.tester.Advance(addressBook.ERC721Portal, erc721DepositPayload(erc721token, user, big.NewInt(42), payload))
+	s.Len(result.Notices, 4)
+	s.Len(result.Vouchers, 1)
+	s.Nil(result.Err)
+
+	s.Equal("1 - ERC721 balance of 0x70997970C51812dc3A010C7d01b50e0d17dc79C8: 1 before transfer of token 42 to 0x0000000000000000000000000000000000000000", string(result.Notices[0].Payload))
+
+	s.Equal("2 - Balance of 0x0000000000000000000000000000000000000000: 1 before transfer of token 42 to 0x70997970C51812dc3A010C7d01b50e0d17dc79C8", string(result.Notices[1].Payload))
+
+	s.Equal("3 - ERC721 balance of 0x70997970C51812dc3A010C7d01b50e0d17dc79C8: 1 before withdraw", string(result.Notices[2].Payload))
+
+	s.Equal("4 - ERC721 balance of 0x70997970C51812dc3A010C7d01b50e0d17dc79C8: 0 after withdraw", string(result.Notices[3].Payload))
+
+	appAddress := common.HexToAddress("0xab7528bb862fb57e8a2bcd567a2e929a0be56a5e")
+	expectedWithdrawVoucherPayload := make([]byte, 0, 4+3*32)
+	expectedWithdrawVoucherPayload = append(expectedWithdrawVoucherPayload, 0x42, 0x84, 0x2e, 0x0e)
+	expectedWithdrawVoucherPayload = append(expectedWithdrawVoucherPayload, make([]byte, 12)...)
+	expectedWithdrawVoucherPayload = append(expectedWithdrawVoucherPayload, appAddress[:]...)
+	expectedWithdrawVoucherPayload = append(expectedWithdrawVoucherPayload, make([]byte, 12)...)
+	expectedWithdrawVoucherPayload = append(expectedWithdrawVoucherPayload, user[:]...)
+	expectedWithdrawVoucherPayload = append(expectedWithdrawVoucherPayload, big.NewInt(42).FillBytes(make([]byte, 32))...)
+	s.Equal(expectedWithdrawVoucherPayload, result.Vouchers[0].Payload)
+	s.Equal(erc721token, result.Vouchers[0].Destination)
+	s.Equal(big.NewInt(0), result.Vouchers[0].Value)
+}
+
+func (s *ApplicationSuite) TestERC1155SingleDeposit() {
+	user := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	erc1155token := common.HexToAddress("0xDC6d64971B77a47fB3E3c6c409D4A05468C398D2")
+	result := s.tester.Advance(addressBook.ERC1155SinglePortal, erc1155SingleDepositPayload(erc1155token, user, big.NewInt(1), big.NewInt(100), payload))
+	s.Len(result.Notices, 4)
+	s.Len(result.Vouchers, 1)
+	s.Nil(result.Err)
+
+	s.Equal("1 - ERC1155 balances of 0x70997970C51812dc3A010C7d01b50e0d17dc79C8 for ids [1]: [100] before transfer to 0x0000000000000000000000000000000000000000", string(result.Notices[0].Payload))
+
+	s.Equal("2 - Balances of 0x0000000000000000000000000000000000000000 for ids [1]: [100] before transfer to 0x70997970C51812dc3A010C7d01b50e0d17dc79C8", string(result.Notices[1].Payload))
+
+	s.Equal("3 - ERC1155 balances of 0x70997970C51812dc3A010C7d01b50e0d17dc79C8 for ids [1]: [100] before withdraw", string(result.Notices[2].Payload))
+
+	s.Equal("4 - ERC1155 balances of 0x70997970C51812dc3A010C7d01b50e0d17dc79C8 for ids [1]: [0] after withdraw", string(result.Notices[3].Payload))
+
+	safeTransferFromABI, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"safeTransferFrom","inputs":[{"type":"address"},{"type":"address"},{"type":"uint256"},{"type":"uint256"},{"type":"bytes"}]}]`))
+	s.Require().NoError(err)
+	s.Equal(safeTransferFromABI.Methods["safeTransferFrom"].ID, result.Vouchers[0].Payload[:4])
+
+	unpacked, err := safeTransferFromABI.Methods["safeTransferFrom"].Inputs.Unpack(result.Vouchers[0].Payload[4:])
+	s.Require().NoError(err)
+	s.Equal([]any{common.HexToAddress("0xab7528bb862fb57e8a2bcd567a2e929a0be56a5e"), user, big.NewInt(1), big.NewInt(100), []byte{}}, unpacked)
+	s.Equal(erc1155token, result.Vouchers[0].Destination)
+	s.Equal(big.NewInt(0), result.Vouchers[0].Value)
+}
+
+func (s *ApplicationSuite) TestERC1155BatchDeposit() {
+	user := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	erc1155token := common.HexToAddress("0xDC6d64971B77a47fB3E3c6c409D4A05468C398D2")
+	tokenIds := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	values := []*big.Int{big.NewInt(100), big.NewInt(200)}
+	result := s.tester.Advance(addressBook.ERC1155BatchPortal, erc1155BatchDepositPayload(erc1155token, user, tokenIds, values, payload))
+	s.Len(result.Notices, 4)
+	s.Len(result.Vouchers, 1)
+	s.Nil(result.Err)
+
+	s.Equal("1 - ERC1155 balances of 0x70997970C51812dc3A010C7d01b50e0d17dc79C8 for ids [1 2]: [100 200] before transfer to 0x0000000000000000000000000000000000000000", string(result.Notices[0].Payload))
+
+	s.Equal("2 - Balances of 0x0000000000000000000000000000000000000000 for ids [1 2]: [100 200] before transfer to 0x70997970C51812dc3A010C7d01b50e0d17dc79C8", string(result.Notices[1].Payload))
+
+	s.Equal("3 - ERC1155 balances of 0x70997970C51812dc3A010C7d01b50e0d17dc79C8 for ids [1 2]: [100 200] before withdraw", string(result.Notices[2].Payload))
+
+	s.Equal("4 - ERC1155 balances of 0x70997970C51812dc3A010C7d01b50e0d17dc79C8 for ids [1 2]: [0 0] after withdraw", string(result.Notices[3].Payload))
+
+	safeBatchTransferFromABI, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"safeBatchTransferFrom","inputs":[{"type":"address"},{"type":"address"},{"type":"uint256[]"},{"type":"uint256[]"},{"type":"bytes"}]}]`))
+	s.Require().NoError(err)
+	s.Equal(safeBatchTransferFromABI.Methods["safeBatchTransferFrom"].ID, result.Vouchers[0].Payload[:4])
+
+	unpacked, err := safeBatchTransferFromABI.Methods["safeBatchTransferFrom"].Inputs.Unpack(result.Vouchers[0].Payload[4:])
+	s.Require().NoError(err)
+	s.Equal([]any{common.HexToAddress("0xab7528bb862fb57e8a2bcd567a2e929a0be56a5e"), user, tokenIds, values, []byte{}}, unpacked)
+	s.Equal(erc1155token, result.Vouchers[0].Destination)
+}
+
+func (s *ApplicationSuite) TestInvalidNFTDeposit() {
+	result := s.tester.Advance(addressBook.ERC721Portal, payload)
+	s.ErrorContains(result.Err, "invalid ERC721 deposit size")
+	s.Empty(result.Notices)
+	s.Empty(result.Vouchers)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ERC721Deposit is an input sent by the ERC721 portal.
+type ERC721Deposit struct {
+	Token   common.Address
+	Sender  common.Address
+	TokenId *big.Int
+}
+
+// ERC1155Deposit is an input sent by the ERC1155 single or batch portal. Single
+// deposits carry one id and one value.
+type ERC1155Deposit struct {
+	Token    common.Address
+	Sender   common.Address
+	TokenIds []*big.Int
+	Values   []*big.Int
+	Batch    bool
+}
+
+var (
+	uint256ArrayType, _ = abi.NewType("uint256[]", "", nil)
+	bytesType, _        = abi.NewType("bytes", "", nil)
+	portalDataArguments = abi.Arguments{{Type: bytesType}, {Type: bytesType}}
+	batchDataArguments  = abi.Arguments{{Type: uint256ArrayType}, {Type: uint256ArrayType}, {Type: bytesType}, {Type: bytesType}}
+)
+
+// decodeERC721Deposit decodes
+// abi.encodePacked(token, sender, tokenId, abi.encode(baseLayerData, execLayerData))
+// and returns the deposit and the exec layer data.
+func decodeERC721Deposit(payload []byte) (*ERC721Deposit, []byte, error) {
+	if len(payload) < 2*common.AddressLength+common.HashLength {
+		return nil, nil, fmt.Errorf("invalid ERC721 deposit size: %d", len(payload))
+	}
+	deposit := &ERC721Deposit{
+		Token:   common.BytesToAddress(payload[:20]),
+		Sender:  common.BytesToAddress(payload[20:40]),
+		TokenId: new(big.Int).SetBytes(payload[40:72]),
+	}
+	data, err := portalDataArguments.Unpack(payload[72:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode ERC721 deposit data: %w", err)
+	}
+	return deposit, data[1].([]byte), nil
+}
+
+// decodeERC1155SingleDeposit decodes
+// abi.encodePacked(token, sender, tokenId, value, abi.encode(baseLayerData, execLayerData)).
+func decodeERC1155SingleDeposit(payload []byte) (*ERC1155Deposit, []byte, error) {
+	if len(payload) < 2*common.AddressLength+2*common.HashLength {
+		return nil, nil, fmt.Errorf("invalid ERC1155 single deposit size: %d", len(payload))
+	}
+	deposit := &ERC1155Deposit{
+		Token:    common.BytesToAddress(payload[:20]),
+		Sender:   common.BytesToAddress(payload[20:40]),
+		TokenIds: []*big.Int{new(big.Int).SetBytes(payload[40:72])},
+		Values:   []*big.Int{new(big.Int).SetBytes(payload[72:104])},
+	}
+	data, err := portalDataArguments.Unpack(payload[104:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode ERC1155 single deposit data: %w", err)
+	}
+	return deposit, data[1].([]byte), nil
+}
+
+// decodeERC1155BatchDeposit decodes
+// abi.encodePacked(token, sender, abi.encode(tokenIds, values, baseLayerData, execLayerData)).
+func decodeERC1155BatchDeposit(payload []byte) (*ERC1155Deposit, []byte, error) {
+	if len(payload) < 2*common.AddressLength {
+		return nil, nil, fmt.Errorf("invalid ERC1155 batch deposit size: %d", len(payload))
+	}
+	data, err := batchDataArguments.Unpack(payload[40:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode ERC1155 batch deposit data: %w", err)
+	}
+	deposit := &ERC1155Deposit{
+		Token:    common.BytesToAddress(payload[:20]),
+		Sender:   common.BytesToAddress(payload[20:40]),
+		TokenIds: data[0].([]*big.Int),
+		Values:   data[1].([]*big.Int),
+		Batch:    true,
+	}
+	if len(deposit.TokenIds) != len(deposit.Values) {
+		return nil, nil, fmt.Errorf("invalid ERC1155 batch deposit: %d ids and %d values", len(deposit.TokenIds), len(deposit.Values))
+	}
+	return deposit, data[3].([]byte), nil
+}
@@ -0,0 +1,186 @@
+package voucher
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rollmelette/rollmelette"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	token   = common.HexToAddress("0x0000000000000000000000000000000000000009")
+	library = common.HexToAddress("0xfafafafafafafafafafafafafafafafafafafafa")
+	admin   = common.HexToAddress("0x976EA74026E726554dB657fA54763abd0C3a0aa9")
+	from    = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	to      = common.HexToAddress("0x0000000000000000000000000000000000000002")
+)
+
+func TestVoucherSuite(t *testing.T) {
+	suite.Run(t, new(VoucherSuite))
+}
+
+type VoucherSuite struct {
+	suite.Suite
+}
+
+func selector(signature string) []byte {
+	return crypto.Keccak256([]byte(signature))[:4]
+}
+
+func (s *VoucherSuite) TestERC20Transfer() {
+	v, err := ERC20Transfer(token, to, big.NewInt(100))
+	s.Require().NoError(err)
+	s.Equal(token, v.Destination)
+	s.Equal(big.NewInt(0), v.Value)
+	s.Equal(selector("transfer(address,uint256)"), v.Payload[:4])
+
+	args, err := erc20ABI.Methods["transfer"].Inputs.Unpack(v.Payload[4:])
+	s.Require().NoError(err)
+	s.Equal([]any{to, big.NewInt(100)}, args)
+}
+
+func (s *VoucherSuite) TestERC721() {
+	v, err := ERC721SafeMint(token, to, "https://example.com")
+	s.Require().NoError(err)
+	s.Equal(token, v.Destination)
+	s.Equal(selector("safeMint(address,string)"), v.Payload[:4])
+
+	args, err := erc721ABI.Methods["safeMint"].Inputs.Unpack(v.Payload[4:])
+	s.Require().NoError(err)
+	s.Equal([]any{to, "https://example.com"}, args)
+
+	v, err = ERC721SafeTransferFrom(token, from, to, big.NewInt(7))
+	s.Require().NoError(err)
+	s.Equal(selector("safeTransferFrom(address,address,uint256)"), v.Payload[:4])
+}
+
+func (s *VoucherSuite) TestERC1155() {
+	v, err := ERC1155SafeTransferFrom(token, from, to, big.NewInt(1), big.NewInt(10), nil)
+	s.Require().NoError(err)
+	s.Equal(token, v.Destination)
+	s.Equal(selector("safeTransferFrom(address,address,uint256,uint256,bytes)"), v.Payload[:4])
+
+	args, err := erc1155ABI.Methods["safeTransferFrom"].Inputs.Unpack(v.Payload[4:])
+	s.Require().NoError(err)
+	s.Equal([]any{from, to, big.NewInt(1), big.NewInt(10), []byte{}}, args)
+
+	v, err = ERC1155SafeBatchTransferFrom(token, from, to, []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)}, nil)
+	s.Require().NoError(err)
+	s.Equal(selector("safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)"), v.Payload[:4])
+
+	_, err = ERC1155SafeBatchTransferFrom(token, from, to, []*big.Int{big.NewInt(1)}, nil, []byte("data"))
+	s.NoError(err)
+}
+
+func (s *VoucherSuite) TestDeploy() {
+	v, err := Deploy(library, []byte{0x60, 0x80})
+	s.Require().NoError(err)
+	s.Equal(library, v.Destination)
+	s.Equal(selector("deploy(bytes)"), v.Payload[:4])
+}
+
+func (s *VoucherSuite) TestDelegateCallVouchers() {
+	v, err := SafeERC20Transfer(library, token, to, big.NewInt(5))
+	s.Require().NoError(err)
+	s.Equal(library, v.Destination)
+	s.Equal(selector("safeTransfer(address,address,uint256)"), v.Payload[:4])
+
+	v, err = SafeERC20TransferTargeted(library, token, from, to, big.NewInt(5))
+	s.Require().NoError(err)
+	s.Equal(selector("safeTransferTargeted(address,address,address,uint256)"), v.Payload[:4])
+
+	v, err = EmergencyERC20Withdraw(library, token, to)
+	s.Require().NoError(err)
+	s.Equal(selector("emergencyERC20Withdraw(address,address)"), v.Payload[:4])
+
+	v, err = EmergencyETHWithdraw(library, to)
+	s.Require().NoError(err)
+	s.Equal(selector("emergencyETHWithdraw(address)"), v.Payload[:4])
+
+	v, err = AdminEmergencyERC20Withdraw(library, admin, token, to)
+	s.Require().NoError(err)
+	s.Equal(selector("emergencyERC20Withdraw(address,address,address)"), v.Payload[:4])
+
+	args, err := adminEmergencyWithdrawABI.Methods["emergencyERC20Withdraw"].Inputs.Unpack(v.Payload[4:])
+	s.Require().NoError(err)
+	s.Equal([]any{admin, token, to}, args)
+
+	v, err = AdminEmergencyETHWithdraw(library, admin, to)
+	s.Require().NoError(err)
+	s.Equal(selector("emergencyETHWithdraw(address,address)"), v.Payload[:4])
+}
+
+// TestEncodedPayloads pins the exact bytes of every builder. The other chapters
+// carry their own copy of this package, and this test keeps them in step.
+func (s *VoucherSuite) TestEncodedPayloads() {
+	payload := func(v Voucher, err error) []byte {
+		s.Require().NoError(err)
+		return v.Payload
+	}
+	delegatePayload := func(v DelegateCallVoucher, err error) []byte {
+		s.Require().NoError(err)
+		return v.Payload
+	}
+
+	tests := []struct {
+		name    string
+		payload []byte
+		want    string
+	}{
+		{"ERC20Transfer", payload(ERC20Transfer(token, to, big.NewInt(100))), "0xa9059cbb00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000064"},
+		{"ERC721SafeMint", payload(ERC721SafeMint(token, to, "ipfs://x")), "0xd204c45e000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000000008697066733a2f2f78000000000000000000000000000000000000000000000000"},
+		{"ERC721SafeTransferFrom", payload(ERC721SafeTransferFrom(token, from, to, big.NewInt(7))), "0x42842e0e000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000007"},
+		{"ERC1155SafeTransferFrom", payload(ERC1155SafeTransferFrom(token, from, to, big.NewInt(1), big.NewInt(10), nil)), "0xf242432a000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000a00000000000000000000000000000000000000000000000000000000000000a00000000000000000000000000000000000000000000000000000000000000000"},
+		{"ERC1155SafeBatchTransferFrom", payload(ERC1155SafeBatchTransferFrom(token, from, to, []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)}, []byte{0xab})), "0x2eb2c2d60000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000a0000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001600000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000a00000000000000000000000000000000000000000000000000000000000000140000000000000000000000000000000000000000000000000000000000000001ab00000000000000000000000000000000000000000000000000000000000000"},
+		{"Deploy", payload(Deploy(library, []byte{0x60, 0x80})), "0x00774360000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000026080000000000000000000000000000000000000000000000000000000000000"},
+		{"SafeERC20Transfer", delegatePayload(SafeERC20Transfer(library, token, to, big.NewInt(5))), "0xd1660f99000000000000000000000000000000000000000000000000000000000000000900000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000005"},
+		{"SafeERC20TransferTargeted", delegatePayload(SafeERC20TransferTargeted(library, token, from, to, big.NewInt(5))), "0x9d4260bc0000000000000000000000000000000000000000000000000000000000000009000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000005"},
+		{"EmergencyERC20Withdraw", delegatePayload(EmergencyERC20Withdraw(library, token, to)), "0x76fbd2e200000000000000000000000000000000000000000000000000000000000000090000000000000000000000000000000000000000000000000000000000000002"},
+		{"EmergencyETHWithdraw", delegatePayload(EmergencyETHWithdraw(library, to)), "0x5b804cd40000000000000000000000000000000000000000000000000000000000000002"},
+		{"AdminEmergencyERC20Withdraw", delegatePayload(AdminEmergencyERC20Withdraw(library, admin, token, to)), "0x77290223000000000000000000000000976ea74026e726554db657fa54763abd0c3a0aa900000000000000000000000000000000000000000000000000000000000000090000000000000000000000000000000000000000000000000000000000000002"},
+		{"AdminEmergencyETHWithdraw", delegatePayload(AdminEmergencyETHWithdraw(library, admin, to)), "0xb6d25336000000000000000000000000976ea74026e726554db657fa54763abd0c3a0aa90000000000000000000000000000000000000000000000000000000000000002"},
+	}
+	for _, tt := range tests {
+		s.Equal(hexutil.MustDecode(tt.want), tt.payload, tt.name)
+	}
+}
+
+type emitApplication struct {
+	voucher             Voucher
+	delegateCallVoucher DelegateCallVoucher
+}
+
+func (a *emitApplication) Advance(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
+	a.voucher.Emit(env)
+	a.delegateCallVoucher.Emit(env)
+	return nil
+}
+
+func (a *emitApplication) Inspect(env rollmelette.EnvInspector, payload []byte) error {
+	return nil
+}
+
+func (s *VoucherSuite) TestEmit() {
+	transfer, err := ERC20Transfer(token, to, big.NewInt(100))
+	s.Require().NoError(err)
+	withdraw, err := EmergencyETHWithdraw(library, to)
+	s.Require().NoError(err)
+
+	transfer.Value = nil
+	tester := rollmelette.NewTester(&emitApplication{voucher: transfer, delegateCallVoucher: withdraw})
+	result := tester.Advance(admin, nil)
+	s.Require().NoError(result.Err)
+
+	s.Require().Len(result.Vouchers, 1)
+	s.Equal(token, result.Vouchers[0].Destination)
+	s.Equal(big.NewInt(0), result.Vouchers[0].Value)
+	s.Equal(transfer.Payload, result.Vouchers[0].Payload)
+
+	s.Require().Len(result.DelegateCallVouchers, 1)
+	s.Equal(library, result.DelegateCallVouchers[0].Destination)
+	s.Equal(withdraw.Payload, result.DelegateCallVouchers[0].Payload)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// erc721Wallet keeps the owner of every ERC721 token held by the application.
+type erc721Wallet struct {
+	owners map[common.Address]map[string]common.Address
+}
+
+func (w *erc721Wallet) deposit(token common.Address, owner common.Address, tokenId *big.Int) {
+	if w.owners == nil {
+		w.owners = make(map[common.Address]map[string]common.Address)
+	}
+	if w.owners[token] == nil {
+		w.owners[token] = make(map[string]common.Address)
+	}
+	w.owners[token][tokenId.String()] = owner
+}
+
+func (w *erc721Wallet) ownerOf(token common.Address, tokenId *big.Int) (common.Address, bool) {
+	owner, ok := w.owners[token][tokenId.String()]
+	return owner, ok
+}
+
+// balanceOf returns how many tokens of the collection owner holds.
+func (w *erc721Wallet) balanceOf(token common.Address, owner common.Address) int {
+	var balance int
+	for _, o := range w.owners[token] {
+		if o == owner {
+			balance++
+		}
+	}
+	return balance
+}
+
+func (w *erc721Wallet) transfer(token common.Address, src common.Address, dst common.Address, tokenId *big.Int) error {
+	if owner, ok := w.ownerOf(token, tokenId); !ok || owner != src {
+		return fmt.Errorf("%s does not own ERC721 token %s of %s", src, tokenId, token)
+	}
+	w.owners[token][tokenId.String()] = dst
+	return nil
+}
+
+func (w *erc721Wallet) withdraw(token common.Address, owner common.Address, tokenId *big.Int) error {
+	if o, ok := w.ownerOf(token, tokenId); !ok || o != owner {
+		return fmt.Errorf("%s does not own ERC721 token %s of %s", owner, tokenId, token)
+	}
+	delete(w.owners[token], tokenId.String())
+	return nil
+}
+
+// erc1155Wallet keeps the ERC1155 balances held by the application.
+type erc1155Wallet struct {
+	balances map[common.Address]map[string]map[common.Address]*big.Int
+}
+
+func (w *erc1155Wallet) balanceOf(token common.Address, tokenId *big.Int, owner common.Address) *big.Int {
+	balance, ok := w.balances[token][tokenId.String()][owner]
+	if !ok {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(balance)
+}
+
+func (w *erc1155Wallet) balancesOf(token common.Address, tokenIds []*big.Int, owner common.Address) []*big.Int {
+	balances := make([]*big.Int, len(tokenIds))
+	for i, tokenId := range tokenIds {
+		balances[i] = w.balanceOf(token, tokenId, owner)
+	}
+	return balances
+}
+
+func (w *erc1155Wallet) setBalance(token common.Address, tokenId *big.Int, owner common.Address, balance *big.Int) {
+	if w.balances == nil {
+		w.balances = make(map[common.Address]map[string]map[common.Address]*big.Int)
+	}
+	if w.balances[token] == nil {
+		w.balances[token] = make(map[string]map[common.Address]*big.Int)
+	}
+	if w.balances[token][tokenId.String()] == nil {
+		w.balances[token][tokenId.String()] = make(map[common.Address]*big.Int)
+	}
+	if balance.Sign() == 0 {
+		delete(w.balances[token][tokenId.String()], owner)
+		return
+	}
+	w.balances[token][tokenId.String()][owner] = balance
+}
+
+func (w *erc1155Wallet) deposit(token common.Address, owner common.Address, tokenIds []*big.Int, values []*big.Int) {
+	for i, tokenId := range tokenIds {
+		w.setBalance(token, tokenId, owner, new(big.Int).Add(w.balanceOf(token, tokenId, owner), values[i]))
+	}
+}
+
+func (w *erc1155Wallet) transfer(token common.Address, src common.Address, dst common.Address, tokenIds []*big.Int, values []*big.Int) error {
+	if err := w.withdraw(token, src, tokenIds, values); err != nil {
+		return err
+	}
+	w.deposit(token, dst, tokenIds, values)
+	return nil
+}
+
+func (w *erc1155Wallet) withdraw(token common.Address, owner common.Address, tokenIds []*big.Int, values []*big.Int) error {
+	required := make(map[string]*big.Int)
+	for i, tokenId := range tokenIds {
+		total, ok := required[tokenId.String()]
+		if !ok {
+			total = new(big.Int)
+			required[tokenId.String()] = total
+		}
+		total.Add(total, values[i])
+		if w.balanceOf(token, tokenId, owner).Cmp(total) < 0 {
+			return fmt.Errorf("insufficient ERC1155 balance of %s for token %s of %s", owner, tokenId, token)
+		}
+	}
+	for i, tokenId := range tokenIds {
+		w.setBalance(token, tokenId, owner, new(big.Int).Sub(w.balanceOf(token, tokenId, owner), values[i]))
+	}
+	return nil
+}
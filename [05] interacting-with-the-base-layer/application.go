@@ -5,57 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
-	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-playground/validator/v10"
+	"github.com/henriquemarlon/cartesi-golang-series/interacting-with-the-base-layer/pkg/voucher"
 	"github.com/rollmelette/rollmelette"
 )
 
 type Application struct{}
 
-func (a *Application) MintNFT(to common.Address, uri string) ([]byte, error) {
-	abiJSON := `[{
-		"type":"function",
-		"name":"safeMint",
-		"inputs":[
-			{"type":"address"},
-			{"type":"string"}
-		]
-	}]`
-	abiInterface, err := abi.JSON(strings.NewReader(abiJSON))
-	if err != nil {
-		return nil, err
-	}
-	voucher, err := abiInterface.Pack("safeMint", to, uri)
-	if err != nil {
-		return nil, err
-	}
-	return voucher, nil
-}
-
-func (a *Application) DeployContract(bytecode []byte) ([]byte, error) {
-	abiJSON := `[{
-		"type":"function",
-		"name":"deploy",
-		"inputs":[
-			{"type":"bytes"}
-		]
-	}]`
-	abiInterface, err := abi.JSON(strings.NewReader(abiJSON))
-	if err != nil {
-		return nil, err
-	}
-
-	voucher, err := abiInterface.Pack("deploy", bytecode)
-	if err != nil {
-		return nil, err
-	}
-	return voucher, nil
-}
-
 func (a *Application) Advance(
 	env rollmelette.Env,
 	metadata rollmelette.Metadata,
@@ -85,11 +43,11 @@ func (a *Application) Advance(
 		if err := json.Unmarshal(input.Data, &mintNFTInput); err != nil {
 			return err
 		}
-		voucher, err := a.MintNFT(mintNFTInput.To, mintNFTInput.URI)
+		mint, err := voucher.ERC721SafeMint(mintNFTInput.Token, mintNFTInput.To, mintNFTInput.URI)
 		if err != nil {
 			return err
 		}
-		env.Voucher(mintNFTInput.Token, big.NewInt(0), voucher)
+		mint.Emit(env)
 	case "deploy_contract":
 		var deployContractInput struct {
 			Deployer common.Address `json:"deployer"`
@@ -98,11 +56,11 @@ func (a *Application) Advance(
 		if err := json.Unmarshal(input.Data, &deployContractInput); err != nil {
 			return err
 		}
-		voucher, err := a.DeployContract(deployContractInput.Bytecode)
+		deploy, err := voucher.Deploy(deployContractInput.Deployer, deployContractInput.Bytecode)
 		if err != nil {
 			return err
 		}
-		env.Voucher(deployContractInput.Deployer, big.NewInt(0), voucher)
+		deploy.Emit(env)
 	default:
 		// using report to log an error
 		env.Report([]byte(fmt.Sprintf("Unknown path: %s", input.Path)))
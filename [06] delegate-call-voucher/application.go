@@ -6,11 +6,10 @@ import (
 	"fmt"
 	"log/slog"
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-playground/validator/v10"
+	"github.com/henriquemarlon/cartesi-golang-series/delegate-call-voucher/pkg/voucher"
 	"github.com/rollmelette/rollmelette"
 )
 
@@ -45,46 +44,22 @@ func (a *Application) Advance(
 		switch d := deposit.(type) {
 		case *rollmelette.ERC20Deposit:
 			if input.Path == "safe_transfer" {
-				abiJSON := `[{
-					"type":"function",
-					"name":"safeTransfer",
-					"inputs":[
-						{"type":"address"},
-						{"type":"address"},
-						{"type":"uint256"}
-					]
-				},
-				{
-					"type":"function",
-					"name":"safeTransferTargeted",
-					"inputs":[
-						{"type":"address"},
-						{"type":"address"},
-						{"type":"address"},
-						{"type":"uint256"}
-					]
-				}]`
-				abiInterface, err := abi.JSON(strings.NewReader(abiJSON))
-				if err != nil {
-					return err
-				}
-
 				halfAmount := new(big.Int).Div(d.Value, big.NewInt(2))
 
-				delegateCallVoucher, err := abiInterface.Pack("safeTransfer", d.Token, d.Sender, halfAmount)
+				safeTransfer, err := voucher.SafeERC20Transfer(safeERC20TransferAddress, d.Token, d.Sender, halfAmount)
 				if err != nil {
 					return err
 				}
 
-				delegateCallVoucherTargeted, err := abiInterface.Pack("safeTransferTargeted", d.Token, anyone, d.Sender, halfAmount)
+				safeTransferTargeted, err := voucher.SafeERC20TransferTargeted(safeERC20TransferAddress, d.Token, anyone, d.Sender, halfAmount)
 				if err != nil {
 					return err
 				}
 
 				env.SetERC20Balance(d.Token, d.Sender, new(big.Int).Sub(env.ERC20BalanceOf(d.Token, d.Sender), d.Value))
 
-				env.DelegateCallVoucher(safeERC20TransferAddress, delegateCallVoucher)
-				env.DelegateCallVoucher(safeERC20TransferAddress, delegateCallVoucherTargeted)
+				safeTransfer.Emit(env)
+				safeTransferTargeted.Emit(env)
 				return nil
 			}
 		default:
@@ -102,23 +77,11 @@ func (a *Application) Advance(
 		if err := json.Unmarshal(input.Data, &emergencyInput); err != nil {
 			return err
 		}
-		abiJSON := `[{
-			"type":"function",
-			"name":"emergencyERC20Withdraw",
-			"inputs":[
-				{"type":"address"},
-				{"type":"address"}
-			]
-		}]`
-		abiInterface, err := abi.JSON(strings.NewReader(abiJSON))
-		if err != nil {
-			return err
-		}
-		delegateCallVoucher, err := abiInterface.Pack("emergencyERC20Withdraw", emergencyInput.Token, emergencyInput.To)
+		withdraw, err := voucher.EmergencyERC20Withdraw(emergencyWithdrawAddress, emergencyInput.Token, emergencyInput.To)
 		if err != nil {
 			return err
 		}
-		env.DelegateCallVoucher(emergencyWithdrawAddress, delegateCallVoucher)
+		withdraw.Emit(env)
 		return nil
 
 	case "emergency_eth_withdraw":
@@ -128,22 +91,11 @@ func (a *Application) Advance(
 		if err := json.Unmarshal(input.Data, &emergencyInput); err != nil {
 			return err
 		}
-		abiJSON := `[{
-			"type":"function",
-			"name":"emergencyETHWithdraw",
-			"inputs":[
-				{"type":"address"}
-			]
-		}]`
-		abiInterface, err := abi.JSON(strings.NewReader(abiJSON))
-		if err != nil {
-			return err
-		}
-		delegateCallVoucher, err := abiInterface.Pack("emergencyETHWithdraw", emergencyInput.To)
+		withdraw, err := voucher.EmergencyETHWithdraw(emergencyWithdrawAddress, emergencyInput.To)
 		if err != nil {
 			return err
 		}
-		env.DelegateCallVoucher(emergencyWithdrawAddress, delegateCallVoucher)
+		withdraw.Emit(env)
 		return nil
 	}
 
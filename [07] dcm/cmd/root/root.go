@@ -4,9 +4,12 @@ import (
 	"log/slog"
 	"os"
 
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/cartesi/middleware"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository/factory"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/router"
 	"github.com/rollmelette/rollmelette"
 	"github.com/spf13/cobra"
@@ -18,6 +21,8 @@ const (
 
 var (
 	useMemoryDB bool
+	databaseURL string
+	abiNotices  bool
 	Cmd         = &cobra.Command{
 		Use:   "dcm-" + CMD_NAME,
 		Short: "Runs DCM Rollup",
@@ -33,12 +38,22 @@ func init() {
 		false,
 		"Use in-memory SQLite database instead of persistent",
 	)
+	Cmd.PersistentFlags().StringVar(
+		&databaseURL,
+		"database",
+		"",
+		"Database connection string, such as kv:///mnt/data/dcm.kv, overriding --memory-db",
+	)
+	Cmd.PersistentFlags().BoolVar(
+		&abiNotices,
+		"abi-notices",
+		false,
+		"Emit ABI-encoded notices alongside the JSON ones; reports stay JSON",
+	)
 }
 
 func run(cmd *cobra.Command, args []string) {
-	repo, err := factory.NewRepositoryFromConnectionString(
-		map[bool]string{true: "sqlite://:memory:", false: "sqlite:///mnt/data/dcm.db"}[useMemoryDB],
-	)
+	repo, err := factory.NewRepositoryFromConnectionString(databaseConn())
 	if err != nil {
 		slog.Error("Failed to setup database", "error", err, "type", map[bool]string{true: "in-memory", false: "persistent"}[useMemoryDB])
 		os.Exit(1)
@@ -47,7 +62,12 @@ func run(cmd *cobra.Command, args []string) {
 
 	defer repo.Close()
 
-	r := NewDCMSystem(repo)
+	var encoders []router.OutputEncoder
+	if abiNotices {
+		encoders = append(encoders, router.JSONOutputEncoder{}, NewABIOutputEncoder())
+	}
+
+	r := NewDCMSystem(repo, encoders...)
 	opts := rollmelette.NewRunOpts()
 	if err := rollmelette.Run(cmd.Context(), opts, r); err != nil {
 		slog.Error("Failed to run rollmelette", "error", err)
@@ -55,7 +75,16 @@ func run(cmd *cobra.Command, args []string) {
 	}
 }
 
-func NewDCMSystem(repo repository.Repository) *router.Router {
+func databaseConn() string {
+	if databaseURL != "" {
+		return databaseURL
+	}
+	return map[bool]string{true: "sqlite://:memory:", false: "sqlite:///mnt/data/dcm.db"}[useMemoryDB]
+}
+
+// NewDCMSystem builds the dApp router. Notices are emitted through encoders, or
+// as JSON text when none are given.
+func NewDCMSystem(repo repository.Repository, encoders ...router.OutputEncoder) *router.Router {
 	handlers, err := NewHandlers(repo)
 	if err != nil {
 		slog.Error("Failed to initialize handlers", "error", err)
@@ -63,55 +92,110 @@ func NewDCMSystem(repo repository.Repository) *router.Router {
 	}
 	slog.Info("Handlers initialized")
 
+	registerErrorCodes()
+	if err := router.RegisterValidations(custom_type.RegisterUint256Validations); err != nil {
+		slog.Error("Failed to register validations", "error", err)
+		os.Exit(1)
+	}
+
 	r := router.NewRouter()
 	r.Use(router.LoggingMiddleware)
 	r.Use(router.ErrorHandlingMiddleware)
+	r.Use(middleware.NewTransactionMiddleware(repo))
+	if len(encoders) > 0 {
+		r.SetOutputEncoders(encoders...)
+	}
 
 	rbacFactory := middleware.NewRBACFactory(repo)
 
 	orderGroup := r.Group("order")
 	{
 		orderGroup.Use(rbacFactory.InvestorOnly())
-		orderGroup.HandleAdvance("create", handlers.OrderAdvanceHandlers.CreateOrder)
-		orderGroup.HandleAdvance("cancel", handlers.OrderAdvanceHandlers.CancelOrder)
+		router.HandleAdvanceTyped(orderGroup, "create", "order created", handlers.OrderAdvanceHandlers.CreateOrder)
+		router.HandleAdvanceTyped(orderGroup, "cancel", "order canceled", handlers.OrderAdvanceHandlers.CancelOrder)
+		orderGroup.HandleAdvanceABI("createOrder(uint256 campaign_id,uint256 interest_rate)", "create")
+		orderGroup.HandleAdvanceABI("cancelOrder(uint256 id)", "cancel")
 
 		// Public operations
-		orderGroup.HandleInspect("", handlers.OrderInspectHandlers.FindAllOrders)
-		orderGroup.HandleInspect("id", handlers.OrderInspectHandlers.FindOrderById)
-		orderGroup.HandleInspect("campaign", handlers.OrderInspectHandlers.FindBidsByCampaignId)
-		orderGroup.HandleInspect("investor", handlers.OrderInspectHandlers.FindOrdersByInvestor)
+		router.HandleInspectTyped(orderGroup, "", handlers.OrderInspectHandlers.FindAllOrders)
+		router.HandleInspectTyped(orderGroup, "id", handlers.OrderInspectHandlers.FindOrderById)
+		router.HandleInspectTyped(orderGroup, "campaign", handlers.OrderInspectHandlers.FindBidsByCampaignId)
+		router.HandleInspectTyped(orderGroup, "investor", handlers.OrderInspectHandlers.FindOrdersByInvestor)
+		router.HandleInspectTyped(orderGroup, ":id", handlers.OrderInspectHandlers.FindOrderById)
+		router.HandleInspectTyped(orderGroup, "investor/:investor", handlers.OrderInspectHandlers.FindOrdersByInvestor)
 	}
 
 	campaignGroup := r.Group("campaign")
 	{
 		debtorGroup := campaignGroup.Group("debtor")
 		debtorGroup.Use(rbacFactory.DebtorOnly())
-		debtorGroup.HandleAdvance("create", handlers.CampaignAdvanceHandlers.CreateCampaign)
-		debtorGroup.HandleAdvance("settle", handlers.CampaignAdvanceHandlers.SettleCampaign)
+		router.HandleAdvanceTyped(debtorGroup, "create", "campaign created", handlers.CampaignAdvanceHandlers.CreateCampaign)
+		router.HandleAdvanceTyped(debtorGroup, "settle", "campaign settled", handlers.CampaignAdvanceHandlers.SettleCampaign)
+		debtorGroup.HandleAdvanceABI("createCampaign(address token,uint256 debt_issued,uint256 max_interest_rate,uint64 closes_at,uint64 maturity_at)", "create")
+		debtorGroup.HandleAdvanceABI("settleCampaign(uint256 campaign_id)", "settle")
 
 		// Public operations
-		campaignGroup.HandleInspect("", handlers.CampaignInspectHandlers.FindAllCampaigns)
-		campaignGroup.HandleInspect("id", handlers.CampaignInspectHandlers.FindCampaignById)
-		campaignGroup.HandleAdvance("close", handlers.CampaignAdvanceHandlers.CloseCampaign)
-		campaignGroup.HandleInspect("debtor", handlers.CampaignInspectHandlers.FindCampaignsByDebtor)
-		campaignGroup.HandleInspect("investor", handlers.CampaignInspectHandlers.FindCampaignsByInvestor)
-		campaignGroup.HandleAdvance("execute-collateral", handlers.CampaignAdvanceHandlers.ExecuteCampaignCollateral)
+		router.HandleInspectTyped(campaignGroup, "", handlers.CampaignInspectHandlers.FindAllCampaigns)
+		router.HandleInspectTyped(campaignGroup, "id", handlers.CampaignInspectHandlers.FindCampaignById)
+		router.HandleAdvanceTyped(campaignGroup, "close", "", handlers.CampaignAdvanceHandlers.CloseCampaign)
+		router.HandleInspectTyped(campaignGroup, "debtor", handlers.CampaignInspectHandlers.FindCampaignsByDebtor)
+		router.HandleInspectTyped(campaignGroup, "investor", handlers.CampaignInspectHandlers.FindCampaignsByInvestor)
+		router.HandleAdvanceTyped(campaignGroup, "execute-collateral", "campaign collateral executed", handlers.CampaignAdvanceHandlers.ExecuteCampaignCollateral)
+		router.HandleInspectTyped(campaignGroup, ":id", handlers.CampaignInspectHandlers.FindCampaignById)
+		router.HandleInspectTyped(campaignGroup, ":campaign_id/orders", handlers.OrderInspectHandlers.FindBidsByCampaignId)
+		router.HandleInspectTyped(campaignGroup, "debtor/:debtor", handlers.CampaignInspectHandlers.FindCampaignsByDebtor)
+		router.HandleInspectTyped(campaignGroup, "investor/:investor", handlers.CampaignInspectHandlers.FindCampaignsByInvestor)
+		campaignGroup.HandleAdvanceABI("closeCampaign(address debtor)", "close")
+		campaignGroup.HandleAdvanceABI("executeCampaignCollateral(uint256 campaign_id)", "execute-collateral")
 	}
 
 	userGroup := r.Group("user")
 	{
 		adminGroup := userGroup.Group("admin")
 		adminGroup.Use(rbacFactory.AdminOnly())
-		adminGroup.HandleAdvance("create", handlers.UserAdvanceHandlers.CreateUser)
-		adminGroup.HandleAdvance("delete", handlers.UserAdvanceHandlers.DeleteUser)
-		adminGroup.HandleAdvance("emergency-erc20-withdraw", handlers.UserAdvanceHandlers.EmergencyERC20Withdraw)
-		adminGroup.HandleAdvance("emergency-ether-withdraw", handlers.UserAdvanceHandlers.EmergencyEtherWithdraw)
+		router.HandleAdvanceTyped(adminGroup, "create", "user created", handlers.UserAdvanceHandlers.CreateUser)
+		router.HandleAdvanceTyped(adminGroup, "delete", "user deleted", handlers.UserAdvanceHandlers.DeleteUser)
+		router.HandleAdvanceTyped(adminGroup, "emergency-erc20-withdraw", "", handlers.UserAdvanceHandlers.EmergencyERC20Withdraw)
+		router.HandleAdvanceTyped(adminGroup, "emergency-ether-withdraw", "", handlers.UserAdvanceHandlers.EmergencyEtherWithdraw)
+		adminGroup.HandleAdvanceABI("createUser(string role,address address)", "create")
+		adminGroup.HandleAdvanceABI("deleteUser(address address)", "delete")
+		adminGroup.HandleAdvanceABI("emergencyERC20Withdraw(address to,address token,address emergency_withdraw_address)", "emergency-erc20-withdraw")
+		adminGroup.HandleAdvanceABI("emergencyEtherWithdraw(address to,address emergency_withdraw_address)", "emergency-ether-withdraw")
 
 		// Public operations
-		userGroup.HandleInspect("", handlers.UserInspectHandlers.FindAllUsers)
-		userGroup.HandleInspect("address", handlers.UserInspectHandlers.FindUserByAddress)
-		userGroup.HandleInspect("erc20-balance", handlers.UserInspectHandlers.ERC20BalanceOf)
-		userGroup.HandleAdvance("erc20-withdraw", handlers.UserAdvanceHandlers.ERC20Withdraw)
+		router.HandleInspectTyped(userGroup, "", handlers.UserInspectHandlers.FindAllUsers)
+		router.HandleInspectTyped(userGroup, "address", handlers.UserInspectHandlers.FindUserByAddress)
+		router.HandleInspectTyped(userGroup, "erc20-balance", handlers.UserInspectHandlers.ERC20BalanceOf)
+		router.HandleAdvanceTyped(userGroup, "erc20-withdraw", "", handlers.UserAdvanceHandlers.ERC20Withdraw)
+		userGroup.HandleAdvanceABI("erc20Withdraw(address token,uint256 amount)", "erc20-withdraw")
+		router.HandleInspectTyped(userGroup, ":address", handlers.UserInspectHandlers.FindUserByAddress)
+		router.HandleInspectTyped(userGroup, ":address/erc20-balance/:token", handlers.UserInspectHandlers.ERC20BalanceOf)
 	}
 	return r
 }
+
+func registerErrorCodes() {
+	router.RegisterErrorCode(middleware.ErrUnauthorized, "unauthorized")
+	router.RegisterErrorCode(entity.ErrCampaignNotFound, "campaign_not_found")
+	router.RegisterErrorCode(entity.ErrInvalidCampaign, "invalid_campaign")
+	router.RegisterErrorCode(entity.ErrOrderNotFound, "order_not_found")
+	router.RegisterErrorCode(entity.ErrInvalidOrder, "invalid_order")
+	router.RegisterErrorCode(entity.ErrUserNotFound, "user_not_found")
+	router.RegisterErrorCode(entity.ErrInvalidUser, "invalid_user")
+	router.RegisterErrorCode(query.ErrInvalidQuery, "invalid_query")
+}
+
+// NewABIOutputEncoder describes the notices L1 contracts can decode.
+func NewABIOutputEncoder() *router.ABIOutputEncoder {
+	encoder := router.NewABIOutputEncoder()
+	encoder.Register("order created", "OrderCreated(uint256 id,uint256 campaign_id,address investor,uint256 amount,uint256 interest_rate)")
+	encoder.Register("campaign created", "CampaignCreated(uint256 id,address token,address debtor,uint256 debt_issued,uint256 max_interest_rate,uint64 closes_at,uint64 maturity_at)")
+	encoder.Register("campaign closed", "CampaignClosed(uint256 id,address debtor,uint256 total_obligation,uint256 total_raised,string state)")
+	// A canceled close is the same L1 event, told apart by its state
+	encoder.Register("campaign canceled", "CampaignClosed(uint256 id,address debtor,uint256 total_obligation,uint256 total_raised,string state)")
+	encoder.Register("campaign settled", "CampaignSettled(uint256 id,address debtor,uint256 total_obligation,uint256 total_raised)")
+	encoder.Register("campaign collateral executed", "CampaignCollateralExecuted(uint256 campaign_id,address debtor,address collateral_address,uint256 collateral_amount)")
+	encoder.Register("user created", "UserCreated(address address,string role)")
+	encoder.Register("user deleted", "UserDeleted(address address)")
+	return encoder
+}
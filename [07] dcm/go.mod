@@ -12,6 +12,7 @@ require (
 	github.com/rollmelette/rollmelette v0.0.0-20250617235715-ae3ab2e9957f
 	github.com/spf13/cobra v1.9.1
 	github.com/stretchr/testify v1.10.0
+	go.etcd.io/bbolt v1.4.3
 	gorm.io/driver/sqlite v1.5.6
 	gorm.io/gorm v1.25.10
 )
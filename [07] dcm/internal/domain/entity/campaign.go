@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
-	"github.com/holiman/uint256"
 )
 
 var (
@@ -25,15 +24,15 @@ const (
 
 type Campaign struct {
 	Id                uint          `json:"id" gorm:"primaryKey"`
-	Token             Address       `json:"token,omitempty" gorm:"custom_type:text;not null"`
-	Debtor            Address       `json:"debtor,omitempty" gorm:"custom_type:text;not null"`
-	CollateralAddress Address       `json:"collateral_address,omitempty" gorm:"custom_type:text;not null"`
-	CollateralAmount  *uint256.Int  `json:"collateral_amount,omitempty" gorm:"custom_type:text;not null"`
-	DebtIssued        *uint256.Int  `json:"debt_issued,omitempty" gorm:"custom_type:text;not null"`
-	MaxInterestRate   *uint256.Int  `json:"max_interest_rate,omitempty" gorm:"custom_type:text;not null"`
-	TotalObligation   *uint256.Int  `json:"total_obligation,omitempty" gorm:"custom_type:text;not null;default:0"`
-	TotalRaised       *uint256.Int  `json:"total_raised,omitempty" gorm:"custom_type:text;not null;default:0"`
-	State             CampaignState `json:"state,omitempty" gorm:"custom_type:text;not null"`
+	Token             Address       `json:"token,omitempty" gorm:"type:text;not null"`
+	Debtor            Address       `json:"debtor,omitempty" gorm:"type:text;not null"`
+	CollateralAddress Address       `json:"collateral_address,omitempty" gorm:"type:text;not null"`
+	CollateralAmount  *Uint256      `json:"collateral_amount,omitempty" gorm:"type:text;not null"`
+	DebtIssued        *Uint256      `json:"debt_issued,omitempty" gorm:"type:text;not null"`
+	MaxInterestRate   *Uint256      `json:"max_interest_rate,omitempty" gorm:"type:text;not null"`
+	TotalObligation   *Uint256      `json:"total_obligation,omitempty" gorm:"type:text;not null;default:0"`
+	TotalRaised       *Uint256      `json:"total_raised,omitempty" gorm:"type:text;not null;default:0"`
+	State             CampaignState `json:"state,omitempty" gorm:"type:text;not null"`
 	Orders            []*Order      `json:"orders,omitempty" gorm:"foreignKey:CampaignId;constraint:OnDelete:CASCADE"`
 	ClosesAt          int64         `json:"closes_at,omitempty" gorm:"not null"`
 	MaturityAt        int64         `json:"maturity_at,omitempty" gorm:"not null"`
@@ -41,7 +40,7 @@ type Campaign struct {
 	UpdatedAt         int64         `json:"updated_at,omitempty" gorm:"default:0"`
 }
 
-func NewCampaign(token Address, debtor Address, collateral_address Address, collateral_amount *uint256.Int, debt_issued *uint256.Int, maxInterestRate *uint256.Int, closesAt int64, maturityAt int64, createdAt int64) (*Campaign, error) {
+func NewCampaign(token Address, debtor Address, collateral_address Address, collateral_amount *Uint256, debt_issued *Uint256, maxInterestRate *Uint256, closesAt int64, maturityAt int64, createdAt int64) (*Campaign, error) {
 	Campaign := &Campaign{
 		Token:             token,
 		Debtor:            debtor,
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
-	"github.com/holiman/uint256"
 )
 
 var (
@@ -26,17 +25,17 @@ const (
 )
 
 type Order struct {
-	Id           uint         `json:"id" gorm:"primaryKey"`
-	CampaignId   uint         `json:"campaign_id" gorm:"not null;index"`
-	Investor     Address      `json:"investor,omitempty" gorm:"not null"`
-	Amount       *uint256.Int `json:"amount,omitempty" gorm:"custom_type:text;not null"`
-	InterestRate *uint256.Int `json:"interest_rate,omitempty" gorm:"custom_type:text;not null"`
-	State        OrderState   `json:"state,omitempty" gorm:"custom_type:text;not null"`
-	CreatedAt    int64        `json:"created_at,omitempty" gorm:"not null"`
-	UpdatedAt    int64        `json:"updated_at,omitempty" gorm:"default:0"`
+	Id           uint       `json:"id" gorm:"primaryKey"`
+	CampaignId   uint       `json:"campaign_id" gorm:"not null;index"`
+	Investor     Address    `json:"investor,omitempty" gorm:"not null"`
+	Amount       *Uint256   `json:"amount,omitempty" gorm:"type:text;not null"`
+	InterestRate *Uint256   `json:"interest_rate,omitempty" gorm:"type:text;not null"`
+	State        OrderState `json:"state,omitempty" gorm:"type:text;not null"`
+	CreatedAt    int64      `json:"created_at,omitempty" gorm:"not null"`
+	UpdatedAt    int64      `json:"updated_at,omitempty" gorm:"default:0"`
 }
 
-func NewOrder(CampaignId uint, investor Address, amount *uint256.Int, interestRate *uint256.Int, createdAt int64) (*Order, error) {
+func NewOrder(CampaignId uint, investor Address, amount *Uint256, interestRate *Uint256, createdAt int64) (*Order, error) {
 	order := &Order{
 		CampaignId:   CampaignId,
 		Investor:     investor,
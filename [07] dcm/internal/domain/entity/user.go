@@ -23,7 +23,7 @@ const (
 type User struct {
 	Id        uint     `json:"id" gorm:"primaryKey"`
 	Role      UserRole `json:"role,omitempty" gorm:"not null"`
-	Address   Address  `json:"address,omitempty" gorm:"custom_type:text;uniqueIndex;not null"`
+	Address   Address  `json:"address,omitempty" gorm:"type:text;uniqueIndex;not null"`
 	CreatedAt int64    `json:"created_at,omitempty" gorm:"not null"`
 	UpdatedAt int64    `json:"updated_at,omitempty" gorm:"default:0"`
 }
@@ -2,14 +2,13 @@ package advance
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/go-playground/validator/v10"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/usecase/campaign"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/router"
 	"github.com/holiman/uint256"
 	"github.com/rollmelette/rollmelette"
 )
@@ -32,26 +31,15 @@ func NewCampaignAdvanceHandlers(
 	}
 }
 
-func (h *CampaignAdvanceHandlers) CreateCampaign(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input campaign.CreateCampaignInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *CampaignAdvanceHandlers) CreateCampaign(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *campaign.CreateCampaignInputDTO) (*campaign.CreateCampaignOutputDTO, error) {
 	createCampaign := campaign.NewCreateCampaignUseCase(
 		h.CampaignRepository,
 		h.UserRepository,
 	)
 
-	res, err := createCampaign.Execute(ctx, &input, deposit, metadata)
+	res, err := createCampaign.Execute(ctx, input, deposit, metadata)
 	if err != nil {
-		return fmt.Errorf("failed to create campaign: %w", err)
+		return nil, fmt.Errorf("failed to create campaign: %w", err)
 	}
 
 	erc20Deposit := deposit.(*rollmelette.ERC20Deposit)
@@ -61,34 +49,17 @@ func (h *CampaignAdvanceHandlers) CreateCampaign(env rollmelette.Env, metadata r
 		env.AppAddress(),
 		erc20Deposit.Value,
 	); err != nil {
-		return fmt.Errorf("failed to transfer ERC20: %w", err)
+		return nil, fmt.Errorf("failed to transfer ERC20: %w", err)
 	}
 
-	campaign, err := json.Marshal(res)
-	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	env.Notice(append([]byte("campaign created - "), campaign...))
-	return nil
+	return res, nil
 }
 
-func (h *CampaignAdvanceHandlers) CloseCampaign(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input campaign.CloseCampaignInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *CampaignAdvanceHandlers) CloseCampaign(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *campaign.CloseCampaignInputDTO) (*campaign.CloseCampaignOutputDTO, error) {
 	closeCampaign := campaign.NewCloseCampaignUseCase(h.CampaignRepository, h.OrderRepository)
-	res, err := closeCampaign.Execute(ctx, &input, metadata)
-	if err != nil && res == nil {
-		return fmt.Errorf("failed to close campaign: %w", err)
+	res, err := closeCampaign.Execute(ctx, input, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close campaign: %w", err)
 	}
 
 	token := common.Address(res.Token)
@@ -102,44 +73,34 @@ func (h *CampaignAdvanceHandlers) CloseCampaign(env rollmelette.Env, metadata ro
 				common.Address(order.Investor),
 				order.Amount.ToBig(),
 			); err != nil {
-				return fmt.Errorf("failed to transfer rejected order: %w", err)
+				return nil, fmt.Errorf("failed to transfer rejected order: %w", err)
 			}
 		}
 	}
 
-	if err := env.ERC20Transfer(token, env.AppAddress(), common.Address(res.Debtor), res.TotalRaised.ToBig()); err != nil {
-		return fmt.Errorf("failed to transfer total raised: %w", err)
+	// A canceled campaign raised nothing, all of its orders were refunded above
+	if res.State == string(entity.CampaignStateClosed) {
+		if err := env.ERC20Transfer(token, env.AppAddress(), common.Address(res.Debtor), res.TotalRaised.ToBig()); err != nil {
+			return nil, fmt.Errorf("failed to transfer total raised: %w", err)
+		}
 	}
 
-	campaign, err := json.Marshal(res)
-	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
+	// The event follows the outcome, "campaign closed" or "campaign canceled"
+	if err := router.Emit(ctx, env, "campaign "+res.State, res); err != nil {
+		return nil, err
 	}
-
-	env.Notice(append([]byte(fmt.Sprintf("campaign %v - ", res.State)), campaign...))
-	return nil
+	return res, nil
 }
 
-func (h *CampaignAdvanceHandlers) SettleCampaign(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input campaign.SettleCampaignInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *CampaignAdvanceHandlers) SettleCampaign(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *campaign.SettleCampaignInputDTO) (*campaign.SettleCampaignOutputDTO, error) {
 	settleCampaign := campaign.NewSettleCampaignUseCase(
 		h.CampaignRepository,
 		h.OrderRepository,
 	)
 
-	res, err := settleCampaign.Execute(ctx, &input, deposit, metadata)
+	res, err := settleCampaign.Execute(ctx, input, deposit, metadata)
 	if err != nil {
-		return fmt.Errorf("failed to settle campaign: %w", err)
+		return nil, fmt.Errorf("failed to settle campaign: %w", err)
 	}
 
 	contractAddr := common.Address(res.Token)
@@ -149,11 +110,11 @@ func (h *CampaignAdvanceHandlers) SettleCampaign(env rollmelette.Env, metadata r
 	for _, order := range res.Orders {
 		if order.State == entity.OrderStateSettled {
 			// Calculate interest for this order
-			interest := new(uint256.Int).Mul(order.Amount, order.InterestRate)
+			interest := new(uint256.Int).Mul(&order.Amount.Int, &order.InterestRate.Int)
 			interest.Div(interest, uint256.NewInt(100))
 
 			// Calculate total payment
-			totalPayment := new(uint256.Int).Add(order.Amount, interest)
+			totalPayment := new(uint256.Int).Add(&order.Amount.Int, interest)
 
 			if err := env.ERC20Transfer(
 				contractAddr,
@@ -161,45 +122,28 @@ func (h *CampaignAdvanceHandlers) SettleCampaign(env rollmelette.Env, metadata r
 				common.Address(order.Investor),
 				totalPayment.ToBig(),
 			); err != nil {
-				return fmt.Errorf("failed to transfer settled order: %w", err)
+				return nil, fmt.Errorf("failed to transfer settled order: %w", err)
 			}
 		}
 	}
 
-	campaign, err := json.Marshal(res)
-	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	env.Notice(append([]byte("campaign settled - "), campaign...))
-	return nil
+	return res, nil
 }
 
-func (h *CampaignAdvanceHandlers) ExecuteCampaignCollateral(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input campaign.ExecuteCampaignCollateralInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *CampaignAdvanceHandlers) ExecuteCampaignCollateral(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *campaign.ExecuteCampaignCollateralInputDTO) (*campaign.ExecuteCampaignCollateralOutputDTO, error) {
 	executeCampaignCollateral := campaign.NewExecuteCampaignCollateralUseCase(h.CampaignRepository, h.OrderRepository)
-	res, err := executeCampaignCollateral.Execute(ctx, &input, metadata)
+	res, err := executeCampaignCollateral.Execute(ctx, input, metadata)
 	if err != nil {
-		return fmt.Errorf("failed to execute campaign collateral: %w", err)
+		return nil, fmt.Errorf("failed to execute campaign collateral: %w", err)
 	}
 
 	totalFinalValue := uint256.NewInt(0)
 	orderFinalValues := make(map[uint]*uint256.Int)
 	for _, order := range res.Orders {
 		if order.State == entity.OrderStateSettledByCollateral {
-			interest := new(uint256.Int).Mul(order.Amount, order.InterestRate)
+			interest := new(uint256.Int).Mul(&order.Amount.Int, &order.InterestRate.Int)
 			interest.Div(interest, uint256.NewInt(100))
-			finalValue := new(uint256.Int).Add(order.Amount, interest)
+			finalValue := new(uint256.Int).Add(&order.Amount.Int, interest)
 			orderFinalValues[order.Id] = finalValue
 			totalFinalValue.Add(totalFinalValue, finalValue)
 		}
@@ -208,7 +152,7 @@ func (h *CampaignAdvanceHandlers) ExecuteCampaignCollateral(env rollmelette.Env,
 	for _, order := range res.Orders {
 		if order.State == entity.OrderStateSettledByCollateral {
 			finalValue := orderFinalValues[order.Id]
-			orderShare := new(uint256.Int).Mul(finalValue, res.CollateralAmount)
+			orderShare := new(uint256.Int).Mul(finalValue, &res.CollateralAmount.Int)
 			orderShare.Div(orderShare, totalFinalValue)
 
 			if err = env.ERC20Transfer(
@@ -217,16 +161,10 @@ func (h *CampaignAdvanceHandlers) ExecuteCampaignCollateral(env rollmelette.Env,
 				common.Address(order.Investor),
 				orderShare.ToBig(),
 			); err != nil {
-				return fmt.Errorf("failed to transfer collateral to investor: %w", err)
+				return nil, fmt.Errorf("failed to transfer collateral to investor: %w", err)
 			}
 		}
 	}
 
-	campaign, err := json.Marshal(res)
-	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	env.Notice(append([]byte("campaign collateral executed - "), campaign...))
-	return nil
+	return res, nil
 }
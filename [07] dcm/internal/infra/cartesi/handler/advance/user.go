@@ -2,22 +2,20 @@ package advance
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/go-playground/validator/v10"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/usecase/user"
 	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/router"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/voucher"
 	"github.com/rollmelette/rollmelette"
 )
 
 type UserAdvanceHandlers struct {
-	UserRepository           repository.UserRepository
+	UserRepository repository.UserRepository
 }
 
 func NewUserAdvanceHandlers(userRepository repository.UserRepository) *UserAdvanceHandlers {
@@ -26,77 +24,32 @@ func NewUserAdvanceHandlers(userRepository repository.UserRepository) *UserAdvan
 	}
 }
 
-func (h *UserAdvanceHandlers) CreateUser(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input user.CreateUserInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *UserAdvanceHandlers) CreateUser(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *user.CreateUserInputDTO) (*user.CreateUserOutputDTO, error) {
 	createUser := user.NewCreateUserUseCase(h.UserRepository)
-	res, err := createUser.Execute(ctx, &input, metadata)
+	res, err := createUser.Execute(ctx, input, metadata)
 	if err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
+		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	user, err := json.Marshal(res)
-	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	env.Notice(append([]byte("user created - "), user...))
-	return nil
+	return res, nil
 }
 
-func (h *UserAdvanceHandlers) DeleteUser(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input user.DeleteUserInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *UserAdvanceHandlers) DeleteUser(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *user.DeleteUserInputDTO) (*user.DeleteUserInputDTO, error) {
 	deleteUserByAddress := user.NewDeleteUserUseCase(h.UserRepository)
-	if err := deleteUserByAddress.Execute(ctx, &input); err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+	if err := deleteUserByAddress.Execute(ctx, input); err != nil {
+		return nil, fmt.Errorf("failed to delete user: %w", err)
 	}
 
-	user, err := json.Marshal(input)
-	if err != nil {
-		return fmt.Errorf("failed to marshal input: %w", err)
-	}
-
-	env.Notice(append([]byte("user deleted - "), user...))
-	return nil
+	return input, nil
 }
 
-func (h *UserAdvanceHandlers) ERC20Withdraw(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input user.WithdrawInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *UserAdvanceHandlers) ERC20Withdraw(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *user.WithdrawInputDTO) (*router.Empty, error) {
 	findUserByAddress := user.NewFindUserByAddressUseCase(h.UserRepository)
 	res, err := findUserByAddress.Execute(ctx, &user.FindUserByAddressInputDTO{
 		Address: Address(metadata.MsgSender),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to find user: %w", err)
+		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
 
 	// For admin, transfer from app address to admin first, then withdraw
@@ -107,7 +60,7 @@ func (h *UserAdvanceHandlers) ERC20Withdraw(env rollmelette.Env, metadata rollme
 			metadata.MsgSender,
 			input.Amount.ToBig(),
 		); err != nil {
-			return fmt.Errorf("failed to transfer ERC20 from app to admin: %w", err)
+			return nil, fmt.Errorf("failed to transfer ERC20 from app to admin: %w", err)
 		}
 	}
 
@@ -117,7 +70,7 @@ func (h *UserAdvanceHandlers) ERC20Withdraw(env rollmelette.Env, metadata rollme
 		metadata.MsgSender,
 		input.Amount.ToBig(),
 	); err != nil {
-		return fmt.Errorf("failed to withdraw ERC20: %w", err)
+		return nil, fmt.Errorf("failed to withdraw ERC20: %w", err)
 	}
 
 	env.Notice([]byte(
@@ -125,87 +78,32 @@ func (h *UserAdvanceHandlers) ERC20Withdraw(env rollmelette.Env, metadata rollme
 			"ERC20 withdrawn - token: %s, amount: %s, user: %s", common.Address(input.Token), input.Amount.ToBig(), metadata.MsgSender,
 		),
 	))
-	return nil
+	return nil, nil
 }
 
-func (h *UserAdvanceHandlers) EmergencyERC20Withdraw(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input user.EmergencyERC20WithdrawInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	abiJSON := `[{
-		"type":"function",
-		"name":"emergencyERC20Withdraw",
-		"inputs":[
-			{"type":"address"},
-			{"type":"address"},
-			{"type":"address"}
-		]
-	}]`
-	abiInterface, err := abi.JSON(strings.NewReader(abiJSON))
-	if err != nil {
-		return fmt.Errorf("failed to parse ABI: %w", err)
-	}
-
-	delegateCallVoucher, err := abiInterface.Pack(
-		"emergencyERC20Withdraw",
+func (h *UserAdvanceHandlers) EmergencyERC20Withdraw(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *user.EmergencyERC20WithdrawInputDTO) (*router.Empty, error) {
+	delegateCallVoucher, err := voucher.AdminEmergencyERC20Withdraw(
+		common.Address(input.EmergencyWithdrawAddress),
 		metadata.MsgSender,
-		input.Token,
-		input.To,
+		common.Address(input.Token),
+		common.Address(input.To),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to pack ABI: %w", err)
+		return nil, err
 	}
-
-	env.DelegateCallVoucher(
-		common.Address(input.EmergencyWithdrawAddress),
-		delegateCallVoucher,
-	)
-	return nil
+	delegateCallVoucher.Emit(env)
+	return nil, nil
 }
 
-func (h *UserAdvanceHandlers) EmergencyEtherWithdraw(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-	var input user.EmergencyEtherWithdrawInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	abiJSON := `[{
-		"type":"function",
-		"name":"emergencyETHWithdraw",
-		"inputs":[
-			{"type":"address"},
-			{"type":"address"}
-		]
-	}]`
-	abiInterface, err := abi.JSON(strings.NewReader(abiJSON))
-	if err != nil {
-		return fmt.Errorf("failed to parse ABI: %w", err)
-	}
-
-	delegateCallVoucher, err := abiInterface.Pack(
-		"emergencyETHWithdraw",
+func (h *UserAdvanceHandlers) EmergencyEtherWithdraw(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, input *user.EmergencyEtherWithdrawInputDTO) (*router.Empty, error) {
+	delegateCallVoucher, err := voucher.AdminEmergencyETHWithdraw(
+		common.Address(input.EmergencyWithdrawAddress),
 		metadata.MsgSender,
-		input.To,
+		common.Address(input.To),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to pack ABI: %w", err)
+		return nil, err
 	}
-
-	env.DelegateCallVoucher(
-		common.Address(input.EmergencyWithdrawAddress),
-		delegateCallVoucher,
-	)
-	return nil
+	delegateCallVoucher.Emit(env)
+	return nil, nil
 }
@@ -2,10 +2,8 @@ package inspect
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
 	campaign "github.com/henriquemarlon/cartesi-golang-series/dcm/internal/usecase/campaign"
 	"github.com/rollmelette/rollmelette"
@@ -21,92 +19,38 @@ func NewCampaignInspectHandlers(campaignRepository repository.CampaignRepository
 	}
 }
 
-func (h *CampaignInspectHandlers) FindCampaignById(env rollmelette.EnvInspector, payload []byte) error {
-	var input campaign.FindCampaignByIdInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *CampaignInspectHandlers) FindCampaignById(ctx context.Context, env rollmelette.EnvInspector, input *campaign.FindCampaignByIdInputDTO) (*campaign.FindCampaignOutputDTO, error) {
 	findCampaignById := campaign.NewFindCampaignByIdUseCase(h.CampaignRepository)
-	res, err := findCampaignById.Execute(ctx, &input)
-	if err != nil {
-		return fmt.Errorf("failed to find campaign: %w", err)
-	}
-	campaign, err := json.Marshal(res)
+	res, err := findCampaignById.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal campaign: %w", err)
+		return nil, fmt.Errorf("failed to find campaign: %w", err)
 	}
-	env.Report(campaign)
-	return nil
+	return res, nil
 }
 
-func (h *CampaignInspectHandlers) FindAllCampaigns(env rollmelette.EnvInspector, payload []byte) error {
-	ctx := context.Background()
+func (h *CampaignInspectHandlers) FindAllCampaigns(ctx context.Context, env rollmelette.EnvInspector, input *campaign.FindAllCampaignsInputDTO) (*campaign.FindAllCampaignsOutputDTO, error) {
 	findAllCampaignsUseCase := campaign.NewFindAllCampaignsUseCase(h.CampaignRepository)
-	res, err := findAllCampaignsUseCase.Execute(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to find all campaigns: %w", err)
-	}
-	allCampaigns, err := json.Marshal(res)
+	res, err := findAllCampaignsUseCase.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal all campaigns: %w", err)
+		return nil, fmt.Errorf("failed to find all campaigns: %w", err)
 	}
-	env.Report(allCampaigns)
-	return nil
+	return res, nil
 }
 
-func (h *CampaignInspectHandlers) FindCampaignsByInvestor(env rollmelette.EnvInspector, payload []byte) error {
-	var input campaign.FindCampaignsByInvestorInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *CampaignInspectHandlers) FindCampaignsByInvestor(ctx context.Context, env rollmelette.EnvInspector, input *campaign.FindCampaignsByInvestorInputDTO) (*campaign.FindCampaignsByInvestorOutputDTO, error) {
 	findCampaignsByInvestor := campaign.NewFindCampaignsByInvestorUseCase(h.CampaignRepository)
-	res, err := findCampaignsByInvestor.Execute(ctx, &input)
+	res, err := findCampaignsByInvestor.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to find campaigns by investor: %w", err)
+		return nil, fmt.Errorf("failed to find campaigns by investor: %w", err)
 	}
-	campaigns, err := json.Marshal(res)
-	if err != nil {
-		return fmt.Errorf("failed to marshal campaigns: %w", err)
-	}
-	env.Report(campaigns)
-	return nil
+	return res, nil
 }
 
-func (h *CampaignInspectHandlers) FindCampaignsByDebtor(env rollmelette.EnvInspector, payload []byte) error {
-	var input campaign.FindCampaignsByDebtorInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *CampaignInspectHandlers) FindCampaignsByDebtor(ctx context.Context, env rollmelette.EnvInspector, input *campaign.FindCampaignsByDebtorInputDTO) (*campaign.FindCampaignsByDebtorOutputDTO, error) {
 	findCampaignsByDebtor := campaign.NewFindCampaignsByDebtorUseCase(h.CampaignRepository)
-	res, err := findCampaignsByDebtor.Execute(ctx, &input)
-	if err != nil {
-		return fmt.Errorf("failed to find campaigns by debtor: %w", err)
-	}
-	campaigns, err := json.Marshal(res)
+	res, err := findCampaignsByDebtor.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal campaigns: %w", err)
+		return nil, fmt.Errorf("failed to find campaigns by debtor: %w", err)
 	}
-	env.Report(campaigns)
-	return nil
+	return res, nil
 }
@@ -2,7 +2,6 @@ package inspect
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
@@ -20,77 +19,38 @@ func NewOrderInspectHandlers(orderRepository repository.OrderRepository) *OrderI
 	}
 }
 
-func (h *OrderInspectHandlers) FindOrderById(env rollmelette.EnvInspector, payload []byte) error {
-	var input order.FindOrderByIdInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *OrderInspectHandlers) FindOrderById(ctx context.Context, env rollmelette.EnvInspector, input *order.FindOrderByIdInputDTO) (*order.FindOrderOutputDTO, error) {
 	findOrderById := order.NewFindOrderByIdUseCase(h.OrderRepository)
-	res, err := findOrderById.Execute(ctx, &input)
-	if err != nil {
-		return fmt.Errorf("failed to find order: %w", err)
-	}
-	order, err := json.Marshal(res)
+	res, err := findOrderById.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal order: %w", err)
+		return nil, fmt.Errorf("failed to find order: %w", err)
 	}
-	env.Report(order)
-	return nil
+	return res, nil
 }
 
-func (h *OrderInspectHandlers) FindBidsByCampaignId(env rollmelette.EnvInspector, payload []byte) error {
-	var input order.FindOrdersByCampaignIdInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *OrderInspectHandlers) FindBidsByCampaignId(ctx context.Context, env rollmelette.EnvInspector, input *order.FindOrdersByCampaignIdInputDTO) (*order.FindOrdersByCampaignIdOutputDTO, error) {
 	findOrdersByCampaignId := order.NewFindOrdersByCampaignIdUseCase(h.OrderRepository)
-	res, err := findOrdersByCampaignId.Execute(ctx, &input)
-	if err != nil {
-		return fmt.Errorf("failed to find orders by campaign id: %v", err)
-	}
-	orders, err := json.Marshal(res)
+	res, err := findOrdersByCampaignId.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal orders: %w", err)
+		return nil, fmt.Errorf("failed to find orders by campaign id: %v", err)
 	}
-	env.Report(orders)
-	return nil
+	return res, nil
 }
 
-func (h *OrderInspectHandlers) FindAllOrders(env rollmelette.EnvInspector, payload []byte) error {
-	ctx := context.Background()
+func (h *OrderInspectHandlers) FindAllOrders(ctx context.Context, env rollmelette.EnvInspector, input *order.FindAllOrdersInputDTO) (*order.FindAllOrdersOutputDTO, error) {
 	findAllOrders := order.NewFindAllOrdersUseCase(h.OrderRepository)
-	res, err := findAllOrders.Execute(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to find all orders: %w", err)
-	}
-	allOrders, err := json.Marshal(res)
+	res, err := findAllOrders.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal all orders: %w", err)
+		return nil, fmt.Errorf("failed to find all orders: %w", err)
 	}
-	env.Report(allOrders)
-	return nil
+	return res, nil
 }
 
-func (h *OrderInspectHandlers) FindOrdersByInvestor(env rollmelette.EnvInspector, payload []byte) error {
-	var input order.FindOrdersByInvestorInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *OrderInspectHandlers) FindOrdersByInvestor(ctx context.Context, env rollmelette.EnvInspector, input *order.FindOrdersByInvestorInputDTO) (order.FindOrdersByInvestorOutputDTO, error) {
 	findOrdersByInvestor := order.NewFindOrdersByInvestorUseCase(h.OrderRepository)
-	res, err := findOrdersByInvestor.Execute(ctx, &input)
-	if err != nil {
-		return fmt.Errorf("failed to find orders by investor: %w", err)
-	}
-	orders, err := json.Marshal(res)
+	res, err := findOrdersByInvestor.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal orders: %w", err)
+		return nil, fmt.Errorf("failed to find orders by investor: %w", err)
 	}
-	env.Report(orders)
-	return nil
+	return res, nil
 }
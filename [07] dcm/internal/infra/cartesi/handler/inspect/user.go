@@ -2,11 +2,9 @@ package inspect
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/go-playground/validator/v10"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/usecase/user"
 	"github.com/rollmelette/rollmelette"
@@ -22,64 +20,31 @@ func NewUserInspectHandlers(userRepository repository.UserRepository) *UserInspe
 	}
 }
 
-func (h *UserInspectHandlers) FindUserByAddress(env rollmelette.EnvInspector, payload []byte) error {
-	var input user.FindUserByAddressInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *UserInspectHandlers) FindUserByAddress(ctx context.Context, env rollmelette.EnvInspector, input *user.FindUserByAddressInputDTO) (*user.FindUserOutputDTO, error) {
 	findUserByAddress := user.NewFindUserByAddressUseCase(h.UserRepository)
-	res, err := findUserByAddress.Execute(ctx, &input)
+	res, err := findUserByAddress.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to find User: %w", err)
+		return nil, fmt.Errorf("failed to find User: %w", err)
 	}
-	User, err := json.Marshal(res)
-	if err != nil {
-		return fmt.Errorf("failed to marshal User: %w", err)
-	}
-	env.Report(User)
-	return nil
+	return res, nil
 }
 
-func (h *UserInspectHandlers) FindAllUsers(env rollmelette.EnvInspector, payload []byte) error {
-	ctx := context.Background()
+func (h *UserInspectHandlers) FindAllUsers(ctx context.Context, env rollmelette.EnvInspector, input *user.FindAllUsersInputDTO) (*user.FindAllUsersOutputDTO, error) {
 	findAllUsers := user.NewFindAllUsersUseCase(h.UserRepository)
-	res, err := findAllUsers.Execute(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to find all Users: %w", err)
-	}
-	allUsers, err := json.Marshal(res)
+	res, err := findAllUsers.Execute(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal all Users: %w", err)
+		return nil, fmt.Errorf("failed to find all Users: %w", err)
 	}
-	env.Report(allUsers)
-	return nil
+	return res, nil
 }
 
-func (h *UserInspectHandlers) ERC20BalanceOf(env rollmelette.EnvInspector, payload []byte) error {
-	var input user.BalanceOfInputDTO
-	if err := json.Unmarshal(payload, &input); err != nil {
-		return fmt.Errorf("failed to unmarshal input: %w", err)
-	}
-
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
-	}
-
-	ctx := context.Background()
+func (h *UserInspectHandlers) ERC20BalanceOf(ctx context.Context, env rollmelette.EnvInspector, input *user.BalanceOfInputDTO) (string, error) {
 	findUserByAddress := user.NewFindUserByAddressUseCase(h.UserRepository)
 	res, err := findUserByAddress.Execute(ctx, &user.FindUserByAddressInputDTO{
 		Address: input.Address,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to find User: %w", err)
+		return "", fmt.Errorf("failed to find User: %w", err)
 	}
 
 	balance := env.ERC20BalanceOf(
@@ -87,11 +52,5 @@ func (h *UserInspectHandlers) ERC20BalanceOf(env rollmelette.EnvInspector, paylo
 		common.Address(res.Address),
 	).String()
 
-	balanceBytes, err := json.Marshal(balance)
-	if err != nil {
-		return fmt.Errorf("failed to marshal balance: %w", err)
-	}
-
-	env.Report(balanceBytes)
-	return nil
+	return balance, nil
 }
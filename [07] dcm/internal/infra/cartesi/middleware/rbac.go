@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,6 +13,8 @@ import (
 	"github.com/rollmelette/rollmelette"
 )
 
+var ErrUnauthorized = errors.New("unauthorized")
+
 type RBACFactory struct {
 	userRepository repository.UserRepository
 }
@@ -23,12 +26,11 @@ func NewRBACFactory(userRepository repository.UserRepository) *RBACFactory {
 }
 
 func (f *RBACFactory) Create(roles []string) router.Middleware {
-	return func(handler any) any {
-		switch h := handler.(type) {
-		case router.AdvanceHandlerFunc:
-			return router.AdvanceHandlerFunc(func(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
+	return router.Middleware{
+		Name: fmt.Sprintf("rbac%v", roles),
+		Advance: func(next router.AdvanceHandlerFunc) router.AdvanceHandlerFunc {
+			return func(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
 				var address Address
-				ctx := context.Background()
 
 				// Get the sender address from either ERC20 deposit or metadata
 				erc20Deposit, ok := deposit.(*rollmelette.ERC20Deposit)
@@ -56,16 +58,12 @@ func (f *RBACFactory) Create(roles []string) router.Middleware {
 					}
 				}
 				if !hasRole {
-					return fmt.Errorf("user %s lacks required permissions: %v", common.Address(user.Address), roles)
+					return fmt.Errorf("%w: user %s lacks required permissions: %v", ErrUnauthorized, common.Address(user.Address), roles)
 				}
 
-				return h(env, metadata, deposit, payload)
-			})
-		case router.InspectHandlerFunc:
-			return h
-		default:
-			return handler
-		}
+				return next(ctx, env, metadata, deposit, payload)
+			}
+		},
 	}
 }
 
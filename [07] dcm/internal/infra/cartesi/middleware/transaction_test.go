@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/cartesi/middleware"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository/factory"
+	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/router"
+	"github.com/rollmelette/rollmelette"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTransactionSuite(t *testing.T) {
+	suite.Run(t, new(TransactionSuite))
+}
+
+type TransactionSuite struct {
+	suite.Suite
+	repo   repository.Repository
+	tester *rollmelette.Tester
+}
+
+var (
+	investor  = HexToAddress("0x0000000000000000000000000000000000000002")
+	errFailed = errors.New("failed after writing")
+)
+
+func (s *TransactionSuite) SetupTest() {
+	repo, err := factory.NewRepositoryFromConnectionString("sqlite://:memory:")
+	s.Require().NoError(err)
+	s.repo = repo
+
+	r := router.NewRouter()
+	r.Use(middleware.NewTransactionMiddleware(repo))
+	createUser := func(fail bool) router.AdvanceHandlerFunc {
+		return func(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
+			user, err := entity.NewUser(string(entity.UserRoleInvestor), investor, metadata.BlockTimestamp)
+			if err != nil {
+				return err
+			}
+			if _, err := repo.CreateUser(ctx, user); err != nil {
+				return err
+			}
+			if fail {
+				return errFailed
+			}
+			return nil
+		}
+	}
+	r.HandleAdvance("accept", createUser(false))
+	r.HandleAdvance("reject", createUser(true))
+	s.tester = rollmelette.NewTester(r)
+}
+
+func (s *TransactionSuite) TearDownTest() {
+	s.Require().NoError(s.repo.Close())
+}
+
+func (s *TransactionSuite) TestRejectedInputRollsBack() {
+	output := s.tester.Advance(common.Address{}, []byte(`{"path":"reject"}`))
+	s.ErrorIs(output.Err, errFailed)
+	_, err := s.repo.FindUserByAddress(context.Background(), investor)
+	s.ErrorIs(err, entity.ErrUserNotFound)
+}
+
+func (s *TransactionSuite) TestAcceptedInputCommits() {
+	output := s.tester.Advance(common.Address{}, []byte(`{"path":"accept"}`))
+	s.Require().NoError(output.Err)
+	user, err := s.repo.FindUserByAddress(context.Background(), investor)
+	s.Require().NoError(err)
+	s.Equal(investor, user.Address)
+}
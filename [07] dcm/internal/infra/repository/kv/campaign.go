@@ -0,0 +1,212 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
+	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	campaignsByDebtor = &index[entity.Campaign]{
+		name: []byte("campaigns_by_debtor"),
+		key:  func(c *entity.Campaign) []byte { return c.Debtor[:] },
+	}
+	campaignsByState = &index[entity.Campaign]{
+		name: []byte("campaigns_by_state"),
+		key:  func(c *entity.Campaign) []byte { return stringKey(string(c.State)) },
+	}
+	campaigns = &table[entity.Campaign]{
+		name:    []byte("campaigns"),
+		id:      func(c *entity.Campaign) uint { return c.Id },
+		setId:   func(c *entity.Campaign, id uint) { c.Id = id },
+		indexes: []*index[entity.Campaign]{campaignsByDebtor, campaignsByState},
+	}
+	campaignColumns = columns[entity.Campaign]{
+		id:   func(c *entity.Campaign) uint { return c.Id },
+		time: func(c *entity.Campaign) int64 { return c.CreatedAt },
+		sort: map[string]func(*entity.Campaign) *Uint256{
+			"id":                intColumn(func(c *entity.Campaign) uint { return c.Id }),
+			"debt_issued":       func(c *entity.Campaign) *Uint256 { return c.DebtIssued },
+			"max_interest_rate": func(c *entity.Campaign) *Uint256 { return c.MaxInterestRate },
+			"created_at":        intColumn(func(c *entity.Campaign) int64 { return c.CreatedAt }),
+			"closes_at":         intColumn(func(c *entity.Campaign) int64 { return c.ClosesAt }),
+			"maturity_at":       intColumn(func(c *entity.Campaign) int64 { return c.MaturityAt }),
+			"updated_at":        intColumn(func(c *entity.Campaign) int64 { return c.UpdatedAt }),
+		},
+	}
+)
+
+func (r *KVRepository) CreateCampaign(ctx context.Context, input *entity.Campaign) (*entity.Campaign, error) {
+	// Orders are stored on their own, so keep them out of the campaign record.
+	record := *input
+	record.Orders = nil
+	if record.TotalObligation == nil {
+		record.TotalObligation = new(Uint256)
+	}
+	if record.TotalRaised == nil {
+		record.TotalRaised = new(Uint256)
+	}
+	if err := r.update(ctx, func(tx *bolt.Tx) error {
+		return campaigns.insert(tx, &record)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create campaign: %w", err)
+	}
+	input.Id = record.Id
+	return input, nil
+}
+
+func (r *KVRepository) FindCampaignById(ctx context.Context, id uint) (*entity.Campaign, error) {
+	var campaign *entity.Campaign
+	if err := r.view(ctx, func(tx *bolt.Tx) (err error) {
+		campaign, err = findCampaignWithOrders(tx, id)
+		return err
+	}); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, entity.ErrCampaignNotFound
+		}
+		return nil, fmt.Errorf("failed to find campaign by id: %w", err)
+	}
+	return campaign, nil
+}
+
+func (r *KVRepository) FindAllCampaigns(ctx context.Context, q query.Query) ([]*entity.Campaign, int64, error) {
+	var page []*entity.Campaign
+	var total int64
+	if err := r.view(ctx, func(tx *bolt.Tx) error {
+		var records []*entity.Campaign
+		var err error
+		if q.State != "" {
+			records, err = campaigns.lookup(tx, campaignsByState, stringKey(q.State))
+		} else {
+			records, err = campaigns.all(tx)
+		}
+		if err != nil {
+			return err
+		}
+		page, total = findPage(records, q, campaignColumns)
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to find all campaigns: %w", err)
+	}
+	return page, total, nil
+}
+
+func (r *KVRepository) FindCampaignsByInvestor(ctx context.Context, investor Address) ([]*entity.Campaign, error) {
+	var result []*entity.Campaign
+	if err := r.view(ctx, func(tx *bolt.Tx) error {
+		investorOrders, err := orders.lookup(tx, ordersByInvestor, investor[:])
+		if err != nil {
+			return err
+		}
+		seen := make(map[uint]bool)
+		for _, order := range investorOrders {
+			if seen[order.CampaignId] {
+				continue
+			}
+			seen[order.CampaignId] = true
+			campaign, err := findCampaignWithOrders(tx, order.CampaignId)
+			if err != nil {
+				return err
+			}
+			result = append(result, campaign)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to find Campaigns by investor: %w", err)
+	}
+	return result, nil
+}
+
+func (r *KVRepository) FindCampaignsByDebtor(ctx context.Context, debtor Address) ([]*entity.Campaign, error) {
+	var result []*entity.Campaign
+	if err := r.view(ctx, func(tx *bolt.Tx) error {
+		records, err := campaigns.lookup(tx, campaignsByDebtor, debtor[:])
+		if err != nil {
+			return err
+		}
+		for _, campaign := range records {
+			if campaign.Orders, err = orders.lookup(tx, ordersByCampaign, itob(campaign.Id)); err != nil {
+				return err
+			}
+		}
+		result = records
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to find campaigns by debtor: %w", err)
+	}
+	return result, nil
+}
+
+// UpdateCampaign sets the non-zero fields of input on the stored campaign,
+// leaving its orders untouched.
+func (r *KVRepository) UpdateCampaign(ctx context.Context, input *entity.Campaign) (*entity.Campaign, error) {
+	if err := r.update(ctx, func(tx *bolt.Tx) error {
+		old, err := campaigns.get(tx, input.Id)
+		if err != nil {
+			return err
+		}
+		record := *old
+		mergeCampaign(&record, input)
+		return campaigns.put(tx, &record, old)
+	}); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, entity.ErrCampaignNotFound
+		}
+		return nil, fmt.Errorf("failed to update campaign: %w", err)
+	}
+	return r.FindCampaignById(ctx, input.Id)
+}
+
+func findCampaignWithOrders(tx *bolt.Tx, id uint) (*entity.Campaign, error) {
+	campaign, err := campaigns.get(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Orders, err = orders.lookup(tx, ordersByCampaign, itob(id)); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+func mergeCampaign(dst, src *entity.Campaign) {
+	if src.Token != (Address{}) {
+		dst.Token = src.Token
+	}
+	if src.Debtor != (Address{}) {
+		dst.Debtor = src.Debtor
+	}
+	if src.CollateralAddress != (Address{}) {
+		dst.CollateralAddress = src.CollateralAddress
+	}
+	mergeUint256(&dst.CollateralAmount, src.CollateralAmount)
+	mergeUint256(&dst.DebtIssued, src.DebtIssued)
+	mergeUint256(&dst.MaxInterestRate, src.MaxInterestRate)
+	mergeUint256(&dst.TotalObligation, src.TotalObligation)
+	mergeUint256(&dst.TotalRaised, src.TotalRaised)
+	if src.State != "" {
+		dst.State = src.State
+	}
+	if src.ClosesAt != 0 {
+		dst.ClosesAt = src.ClosesAt
+	}
+	if src.MaturityAt != 0 {
+		dst.MaturityAt = src.MaturityAt
+	}
+	if src.CreatedAt != 0 {
+		dst.CreatedAt = src.CreatedAt
+	}
+	if src.UpdatedAt != 0 {
+		dst.UpdatedAt = src.UpdatedAt
+	}
+}
+
+func mergeUint256(dst **Uint256, src *Uint256) {
+	if src != nil {
+		*dst = src
+	}
+}
@@ -0,0 +1,186 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
+	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	debtor    = HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	investorA = HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	investorB = HexToAddress("0x90F79bf6EB2c4f870365E51660A8a5D1B0a4E5d0")
+	token     = HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+)
+
+func TestKVSuite(t *testing.T) {
+	suite.Run(t, new(KVSuite))
+}
+
+type KVSuite struct {
+	suite.Suite
+	path string
+	repo *KVRepository
+	ctx  context.Context
+}
+
+func (s *KVSuite) SetupTest() {
+	s.path = "kv://" + filepath.Join(s.T().TempDir(), "dcm.kv")
+	repo, err := NewKVRepository(s.path)
+	s.Require().NoError(err)
+	s.repo = repo
+	s.ctx = context.Background()
+}
+
+func (s *KVSuite) TearDownTest() {
+	s.Require().NoError(s.repo.Close())
+}
+
+func (s *KVSuite) createCampaign(createdAt int64) *entity.Campaign {
+	campaign, err := entity.NewCampaign(token, debtor, token, Uint256FromUint64(10000), Uint256FromUint64(1000), Uint256FromUint64(10), createdAt+10, createdAt+20, createdAt)
+	s.Require().NoError(err)
+	campaign, err = s.repo.CreateCampaign(s.ctx, campaign)
+	s.Require().NoError(err)
+	return campaign
+}
+
+func (s *KVSuite) createOrder(campaignId uint, investor Address, amount, rate uint64) *entity.Order {
+	return s.createOrderIn(s.ctx, campaignId, investor, amount, rate)
+}
+
+func (s *KVSuite) createOrderIn(ctx context.Context, campaignId uint, investor Address, amount, rate uint64) *entity.Order {
+	order, err := entity.NewOrder(campaignId, investor, Uint256FromUint64(amount), Uint256FromUint64(rate), 1)
+	s.Require().NoError(err)
+	order, err = s.repo.CreateOrder(ctx, order)
+	s.Require().NoError(err)
+	return order
+}
+
+func (s *KVSuite) TestSeedsAdminOnce() {
+	admins, err := s.repo.FindUsersByRole(s.ctx, string(entity.UserRoleAdmin))
+	s.Require().NoError(err)
+	s.Len(admins, 1)
+
+	s.Require().NoError(s.repo.Close())
+	repo, err := NewKVRepository(s.path)
+	s.Require().NoError(err)
+	s.repo = repo
+
+	admins, err = s.repo.FindUsersByRole(s.ctx, string(entity.UserRoleAdmin))
+	s.Require().NoError(err)
+	s.Len(admins, 1)
+}
+
+func (s *KVSuite) TestCampaignLoadsItsOrders() {
+	campaign := s.createCampaign(1)
+	other := s.createCampaign(2)
+	s.createOrder(campaign.Id, investorA, 500, 8)
+	s.createOrder(campaign.Id, investorA, 300, 9)
+	s.createOrder(other.Id, investorB, 700, 5)
+
+	found, err := s.repo.FindCampaignById(s.ctx, campaign.Id)
+	s.Require().NoError(err)
+	s.Len(found.Orders, 2)
+	s.True(found.TotalRaised.IsZero())
+
+	byInvestor, err := s.repo.FindCampaignsByInvestor(s.ctx, investorA)
+	s.Require().NoError(err)
+	s.Require().Len(byInvestor, 1)
+	s.Equal(campaign.Id, byInvestor[0].Id)
+
+	byDebtor, err := s.repo.FindCampaignsByDebtor(s.ctx, debtor)
+	s.Require().NoError(err)
+	s.Len(byDebtor, 2)
+
+	_, err = s.repo.FindCampaignById(s.ctx, 42)
+	s.ErrorIs(err, entity.ErrCampaignNotFound)
+}
+
+func (s *KVSuite) TestUpdateMovesStateIndex() {
+	campaign := s.createCampaign(1)
+	order := s.createOrder(campaign.Id, investorA, 500, 8)
+
+	order.State = entity.OrderStateAccepted
+	_, err := s.repo.UpdateOrder(s.ctx, order)
+	s.Require().NoError(err)
+	pending, err := s.repo.FindOrdersByState(s.ctx, campaign.Id, string(entity.OrderStatePending))
+	s.Require().NoError(err)
+	s.Empty(pending)
+	accepted, err := s.repo.FindOrdersByState(s.ctx, campaign.Id, string(entity.OrderStateAccepted))
+	s.Require().NoError(err)
+	s.Len(accepted, 1)
+
+	updated, err := s.repo.UpdateCampaign(s.ctx, &entity.Campaign{Id: campaign.Id, State: entity.CampaignStateClosed, TotalRaised: Uint256FromUint64(500)})
+	s.Require().NoError(err)
+	s.Equal(entity.CampaignStateClosed, updated.State)
+	s.Equal(uint64(500), updated.TotalRaised.Uint64())
+	s.Equal(debtor, updated.Debtor)
+	s.Len(updated.Orders, 1)
+
+	closed, total, err := s.repo.FindAllCampaigns(s.ctx, query.Query{Limit: 10, Sort: "id", Order: query.Asc, State: string(entity.CampaignStateClosed)})
+	s.Require().NoError(err)
+	s.Equal(int64(1), total)
+	s.Len(closed, 1)
+}
+
+func (s *KVSuite) TestOrdersSortNumerically() {
+	campaign := s.createCampaign(1)
+	s.createOrder(campaign.Id, investorA, 900, 10)
+	s.createOrder(campaign.Id, investorA, 100000, 9)
+	s.createOrder(campaign.Id, investorB, 2000, 9)
+
+	sorted, err := s.repo.FindOrdersByCampaignIdSortedByRate(s.ctx, campaign.Id)
+	s.Require().NoError(err)
+	s.Require().Len(sorted, 3)
+	s.Equal(uint64(100000), sorted[0].Amount.Uint64())
+	s.Equal(uint64(2000), sorted[1].Amount.Uint64())
+	s.Equal(uint64(900), sorted[2].Amount.Uint64())
+
+	page, total, err := s.repo.FindAllOrders(s.ctx, query.Query{Limit: 2, Offset: 1, Sort: "amount", Order: query.Desc})
+	s.Require().NoError(err)
+	s.Equal(int64(3), total)
+	s.Require().Len(page, 2)
+	s.Equal(uint64(2000), page[0].Amount.Uint64())
+	s.Equal(uint64(900), page[1].Amount.Uint64())
+}
+
+func (s *KVSuite) TestUserAddressIsUnique() {
+	user, err := entity.NewUser(string(entity.UserRoleInvestor), investorA, 1)
+	s.Require().NoError(err)
+	_, err = s.repo.CreateUser(s.ctx, user)
+	s.Require().NoError(err)
+
+	duplicate, err := entity.NewUser(string(entity.UserRoleDebtor), investorA, 2)
+	s.Require().NoError(err)
+	_, err = s.repo.CreateUser(s.ctx, duplicate)
+	s.Error(err)
+
+	s.Require().NoError(s.repo.DeleteUser(s.ctx, investorA))
+	_, err = s.repo.FindUserByAddress(s.ctx, investorA)
+	s.ErrorIs(err, entity.ErrUserNotFound)
+	s.ErrorIs(s.repo.DeleteUser(s.ctx, investorA), entity.ErrUserNotFound)
+}
+
+func (s *KVSuite) TestRunInTransactionRollsBack() {
+	campaign := s.createCampaign(1)
+	errRejected := errors.New("rejected")
+
+	err := s.repo.RunInTransaction(s.ctx, func(ctx context.Context) error {
+		s.createOrderIn(ctx, campaign.Id, investorA, 500, 8)
+		orders, err := s.repo.FindOrdersByCampaignId(ctx, campaign.Id)
+		s.Require().NoError(err)
+		s.Len(orders, 1)
+		return errRejected
+	})
+	s.ErrorIs(err, errRejected)
+
+	orders, err := s.repo.FindOrdersByCampaignId(s.ctx, campaign.Id)
+	s.Require().NoError(err)
+	s.Empty(orders)
+}
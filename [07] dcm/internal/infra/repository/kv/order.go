@@ -0,0 +1,195 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
+	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	ordersByCampaign = &index[entity.Order]{
+		name: []byte("orders_by_campaign"),
+		key:  func(o *entity.Order) []byte { return itob(o.CampaignId) },
+	}
+	ordersByInvestor = &index[entity.Order]{
+		name: []byte("orders_by_investor"),
+		key:  func(o *entity.Order) []byte { return o.Investor[:] },
+	}
+	ordersByState = &index[entity.Order]{
+		name: []byte("orders_by_state"),
+		key:  func(o *entity.Order) []byte { return stringKey(string(o.State)) },
+	}
+	orders = &table[entity.Order]{
+		name:    []byte("orders"),
+		id:      func(o *entity.Order) uint { return o.Id },
+		setId:   func(o *entity.Order, id uint) { o.Id = id },
+		indexes: []*index[entity.Order]{ordersByCampaign, ordersByInvestor, ordersByState},
+	}
+	orderColumns = columns[entity.Order]{
+		id:   func(o *entity.Order) uint { return o.Id },
+		time: func(o *entity.Order) int64 { return o.CreatedAt },
+		sort: map[string]func(*entity.Order) *Uint256{
+			"id":            intColumn(func(o *entity.Order) uint { return o.Id }),
+			"campaign_id":   intColumn(func(o *entity.Order) uint { return o.CampaignId }),
+			"amount":        func(o *entity.Order) *Uint256 { return o.Amount },
+			"interest_rate": func(o *entity.Order) *Uint256 { return o.InterestRate },
+			"created_at":    intColumn(func(o *entity.Order) int64 { return o.CreatedAt }),
+			"updated_at":    intColumn(func(o *entity.Order) int64 { return o.UpdatedAt }),
+		},
+	}
+)
+
+func (r *KVRepository) CreateOrder(ctx context.Context, input *entity.Order) (*entity.Order, error) {
+	if err := r.update(ctx, func(tx *bolt.Tx) error {
+		return orders.insert(tx, input)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+	return input, nil
+}
+
+func (r *KVRepository) FindOrderById(ctx context.Context, id uint) (*entity.Order, error) {
+	var order *entity.Order
+	if err := r.view(ctx, func(tx *bolt.Tx) (err error) {
+		order, err = orders.get(tx, id)
+		return err
+	}); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, entity.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to find order by ID: %w", err)
+	}
+	return order, nil
+}
+
+func (r *KVRepository) FindOrdersByCampaignId(ctx context.Context, id uint) ([]*entity.Order, error) {
+	var result []*entity.Order
+	if err := r.view(ctx, func(tx *bolt.Tx) (err error) {
+		result, err = orders.lookup(tx, ordersByCampaign, itob(id))
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to find orders by campaign ID: %w", err)
+	}
+	return result, nil
+}
+
+func (r *KVRepository) FindOrdersByCampaignIdSortedByRate(ctx context.Context, id uint) ([]*entity.Order, error) {
+	result, err := r.FindOrdersByCampaignId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(result, func(a, b *entity.Order) int {
+		if c := compareUint256(a.InterestRate, b.InterestRate); c != 0 {
+			return c
+		}
+		return compareUint256(b.Amount, a.Amount)
+	})
+	return result, nil
+}
+
+func (r *KVRepository) FindOrdersByState(ctx context.Context, campaignId uint, state string) ([]*entity.Order, error) {
+	var result []*entity.Order
+	if err := r.view(ctx, func(tx *bolt.Tx) (err error) {
+		result, err = orders.lookup(tx, ordersByState, stringKey(state))
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to find orders by state: %w", err)
+	}
+	return slices.DeleteFunc(result, func(o *entity.Order) bool {
+		return o.CampaignId != campaignId
+	}), nil
+}
+
+func (r *KVRepository) FindOrdersByInvestor(ctx context.Context, investor Address) ([]*entity.Order, error) {
+	var result []*entity.Order
+	if err := r.view(ctx, func(tx *bolt.Tx) (err error) {
+		result, err = orders.lookup(tx, ordersByInvestor, investor[:])
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to find orders by investor: %w", err)
+	}
+	return result, nil
+}
+
+func (r *KVRepository) FindAllOrders(ctx context.Context, q query.Query) ([]*entity.Order, int64, error) {
+	var page []*entity.Order
+	var total int64
+	if err := r.view(ctx, func(tx *bolt.Tx) error {
+		var records []*entity.Order
+		var err error
+		if q.State != "" {
+			records, err = orders.lookup(tx, ordersByState, stringKey(q.State))
+		} else {
+			records, err = orders.all(tx)
+		}
+		if err != nil {
+			return err
+		}
+		page, total = findPage(records, q, orderColumns)
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to find all orders: %w", err)
+	}
+	return page, total, nil
+}
+
+// UpdateOrder sets the non-zero fields of input on the stored order.
+func (r *KVRepository) UpdateOrder(ctx context.Context, input *entity.Order) (*entity.Order, error) {
+	if err := r.update(ctx, func(tx *bolt.Tx) error {
+		old, err := orders.get(tx, input.Id)
+		if err != nil {
+			return err
+		}
+		record := *old
+		mergeOrder(&record, input)
+		return orders.put(tx, &record, old)
+	}); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, entity.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to update order: %w", err)
+	}
+	return r.FindOrderById(ctx, input.Id)
+}
+
+func (r *KVRepository) DeleteOrder(ctx context.Context, id uint) error {
+	if err := r.update(ctx, func(tx *bolt.Tx) error {
+		order, err := orders.get(tx, id)
+		if err != nil {
+			return err
+		}
+		return orders.delete(tx, order)
+	}); err != nil {
+		if errors.Is(err, errNotFound) {
+			return entity.ErrOrderNotFound
+		}
+		return fmt.Errorf("failed to delete order: %w", err)
+	}
+	return nil
+}
+
+func mergeOrder(dst, src *entity.Order) {
+	if src.CampaignId != 0 {
+		dst.CampaignId = src.CampaignId
+	}
+	if src.Investor != (Address{}) {
+		dst.Investor = src.Investor
+	}
+	mergeUint256(&dst.Amount, src.Amount)
+	mergeUint256(&dst.InterestRate, src.InterestRate)
+	if src.State != "" {
+		dst.State = src.State
+	}
+	if src.CreatedAt != 0 {
+		dst.CreatedAt = src.CreatedAt
+	}
+	if src.UpdatedAt != 0 {
+		dst.UpdatedAt = src.UpdatedAt
+	}
+}
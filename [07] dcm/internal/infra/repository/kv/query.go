@@ -0,0 +1,73 @@
+package kv
+
+import (
+	"cmp"
+	"slices"
+
+	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
+)
+
+// columns describes how findPage reads the records of a table: their id, the
+// time column From and To bound, and the value of every sortable column.
+type columns[T any] struct {
+	id   func(*T) uint
+	time func(*T) int64
+	sort map[string]func(*T) *Uint256
+}
+
+// findPage returns the page of records selected by the normalized query q and
+// how many records match its filters. records are expected to be already
+// filtered by state and in id order.
+func findPage[T any](records []*T, q query.Query, cols columns[T]) ([]*T, int64) {
+	records = slices.DeleteFunc(records, func(v *T) bool {
+		t := cols.time(v)
+		return (q.From != 0 && t < q.From) || (q.To != 0 && t > q.To)
+	})
+	total := int64(len(records))
+
+	desc := q.Order == query.Desc
+	if q.Cursor != 0 {
+		records = slices.DeleteFunc(records, func(v *T) bool {
+			if desc {
+				return cols.id(v) >= q.Cursor
+			}
+			return cols.id(v) <= q.Cursor
+		})
+	}
+	column := cols.sort[q.Sort]
+	slices.SortStableFunc(records, func(a, b *T) int {
+		c := compareUint256(column(a), column(b))
+		if c == 0 {
+			c = cmp.Compare(cols.id(a), cols.id(b))
+		}
+		if desc {
+			return -c
+		}
+		return c
+	})
+
+	start := min(q.Offset, len(records))
+	end := min(start+q.Limit, len(records))
+	return records[start:end], total
+}
+
+// intColumn reads an integer column as a sortable value. The sign bit is
+// flipped so that negative timestamps order before positive ones, as they do
+// in SQL.
+func intColumn[T any, N ~int64 | ~uint](field func(*T) N) func(*T) *Uint256 {
+	return func(v *T) *Uint256 {
+		return Uint256FromUint64(uint64(int64(field(v))) ^ 1<<63)
+	}
+}
+
+// compareUint256 orders a nil value, an unset column, as zero.
+func compareUint256(a, b *Uint256) int {
+	if a == nil {
+		a = new(Uint256)
+	}
+	if b == nil {
+		b = new(Uint256)
+	}
+	return a.Cmp(&b.Int)
+}
@@ -0,0 +1,132 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var errNotFound = errors.New("record not found")
+
+// table stores records of T as JSON in a bucket keyed by their big-endian id,
+// and keeps an index bucket per secondary key. An index entry is the key of
+// the record followed by its id, so the ids of the records sharing a key are
+// a prefix scan away.
+type table[T any] struct {
+	name    []byte
+	id      func(*T) uint
+	setId   func(*T, uint)
+	indexes []*index[T]
+}
+
+type index[T any] struct {
+	name []byte
+	key  func(*T) []byte
+}
+
+func (t *table[T]) buckets() [][]byte {
+	names := [][]byte{t.name}
+	for _, idx := range t.indexes {
+		names = append(names, idx.name)
+	}
+	return names
+}
+
+func (t *table[T]) get(tx *bolt.Tx, id uint) (*T, error) {
+	data := tx.Bucket(t.name).Get(itob(id))
+	if data == nil {
+		return nil, errNotFound
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to decode %s %d: %w", t.name, id, err)
+	}
+	return &v, nil
+}
+
+// insert stores v under the next id of the table, setting it on v.
+func (t *table[T]) insert(tx *bolt.Tx, v *T) error {
+	seq, err := tx.Bucket(t.name).NextSequence()
+	if err != nil {
+		return err
+	}
+	t.setId(v, uint(seq))
+	return t.put(tx, v, nil)
+}
+
+// put stores v, replacing old, the record previously stored under its id, and
+// its index entries.
+func (t *table[T]) put(tx *bolt.Tx, v *T, old *T) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", t.name, err)
+	}
+	id := itob(t.id(v))
+	for _, idx := range t.indexes {
+		b := tx.Bucket(idx.name)
+		if old != nil {
+			if err := b.Delete(append(idx.key(old), id...)); err != nil {
+				return err
+			}
+		}
+		if err := b.Put(append(idx.key(v), id...), nil); err != nil {
+			return err
+		}
+	}
+	return tx.Bucket(t.name).Put(id, data)
+}
+
+func (t *table[T]) delete(tx *bolt.Tx, v *T) error {
+	id := itob(t.id(v))
+	for _, idx := range t.indexes {
+		if err := tx.Bucket(idx.name).Delete(append(idx.key(v), id...)); err != nil {
+			return err
+		}
+	}
+	return tx.Bucket(t.name).Delete(id)
+}
+
+// all returns every record of the table in id order.
+func (t *table[T]) all(tx *bolt.Tx) ([]*T, error) {
+	var records []*T
+	err := tx.Bucket(t.name).ForEach(func(k, data []byte) error {
+		var v T
+		if err := json.Unmarshal(data, &v); err != nil {
+			return fmt.Errorf("failed to decode %s %d: %w", t.name, btoi(k), err)
+		}
+		records = append(records, &v)
+		return nil
+	})
+	return records, err
+}
+
+// lookup returns the records whose key on idx is key, in id order.
+func (t *table[T]) lookup(tx *bolt.Tx, idx *index[T], key []byte) ([]*T, error) {
+	var records []*T
+	c := tx.Bucket(idx.name).Cursor()
+	for k, _ := c.Seek(key); k != nil && bytes.HasPrefix(k, key); k, _ = c.Next() {
+		v, err := t.get(tx, btoi(k[len(key):]))
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, v)
+	}
+	return records, nil
+}
+
+func itob(id uint) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(id))
+}
+
+func btoi(b []byte) uint {
+	return uint(binary.BigEndian.Uint64(b))
+}
+
+// stringKey ends s with a separator, so that no key is a prefix of another.
+func stringKey(s string) []byte {
+	return append([]byte(s), 0)
+}
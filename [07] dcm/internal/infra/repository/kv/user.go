@@ -0,0 +1,131 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
+	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
+	bolt "go.etcd.io/bbolt"
+)
+
+var errDuplicateAddress = errors.New("address already registered")
+
+var (
+	usersByAddress = &index[entity.User]{
+		name: []byte("users_by_address"),
+		key:  func(u *entity.User) []byte { return u.Address[:] },
+	}
+	usersByRole = &index[entity.User]{
+		name: []byte("users_by_role"),
+		key:  func(u *entity.User) []byte { return stringKey(string(u.Role)) },
+	}
+	users = &table[entity.User]{
+		name:    []byte("users"),
+		id:      func(u *entity.User) uint { return u.Id },
+		setId:   func(u *entity.User, id uint) { u.Id = id },
+		indexes: []*index[entity.User]{usersByAddress, usersByRole},
+	}
+	userColumns = columns[entity.User]{
+		id:   func(u *entity.User) uint { return u.Id },
+		time: func(u *entity.User) int64 { return u.CreatedAt },
+		sort: map[string]func(*entity.User) *Uint256{
+			"id":         intColumn(func(u *entity.User) uint { return u.Id }),
+			"created_at": intColumn(func(u *entity.User) int64 { return u.CreatedAt }),
+			"updated_at": intColumn(func(u *entity.User) int64 { return u.UpdatedAt }),
+		},
+	}
+)
+
+func (r *KVRepository) CreateUser(ctx context.Context, input *entity.User) (*entity.User, error) {
+	if err := r.update(ctx, func(tx *bolt.Tx) error {
+		// Addresses are unique, as the unique index of the SQLite backend
+		// enforces.
+		existing, err := users.lookup(tx, usersByAddress, input.Address[:])
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return errDuplicateAddress
+		}
+		return users.insert(tx, input)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return input, nil
+}
+
+func (r *KVRepository) FindUserByAddress(ctx context.Context, address Address) (*entity.User, error) {
+	var user *entity.User
+	if err := r.view(ctx, func(tx *bolt.Tx) error {
+		records, err := users.lookup(tx, usersByAddress, address[:])
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return errNotFound
+		}
+		user = records[0]
+		return nil
+	}); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, entity.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user by address: %w", err)
+	}
+	return user, nil
+}
+
+func (r *KVRepository) FindUsersByRole(ctx context.Context, role string) ([]*entity.User, error) {
+	var result []*entity.User
+	if err := r.view(ctx, func(tx *bolt.Tx) (err error) {
+		result, err = users.lookup(tx, usersByRole, stringKey(role))
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to find users by role: %w", err)
+	}
+	return result, nil
+}
+
+func (r *KVRepository) FindAllUsers(ctx context.Context, q query.Query) ([]*entity.User, int64, error) {
+	var page []*entity.User
+	var total int64
+	if err := r.view(ctx, func(tx *bolt.Tx) error {
+		var records []*entity.User
+		var err error
+		if q.State != "" {
+			records, err = users.lookup(tx, usersByRole, stringKey(q.State))
+		} else {
+			records, err = users.all(tx)
+		}
+		if err != nil {
+			return err
+		}
+		page, total = findPage(records, q, userColumns)
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to find all users: %w", err)
+	}
+	return page, total, nil
+}
+
+func (r *KVRepository) DeleteUser(ctx context.Context, address Address) error {
+	if err := r.update(ctx, func(tx *bolt.Tx) error {
+		records, err := users.lookup(tx, usersByAddress, address[:])
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return errNotFound
+		}
+		return users.delete(tx, records[0])
+	}); err != nil {
+		if errors.Is(err, errNotFound) {
+			return entity.ErrUserNotFound
+		}
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
+}
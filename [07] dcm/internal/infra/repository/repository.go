@@ -5,6 +5,7 @@ import (
 
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
 )
 
 type CampaignRepository interface {
@@ -12,7 +13,8 @@ type CampaignRepository interface {
 	FindCampaignsByDebtor(ctx context.Context, debtor Address) ([]*entity.Campaign, error)
 	FindCampaignsByInvestor(ctx context.Context, investor Address) ([]*entity.Campaign, error)
 	FindCampaignById(ctx context.Context, id uint) (*entity.Campaign, error)
-	FindAllCampaigns(ctx context.Context) ([]*entity.Campaign, error)
+	// FindAllCampaigns expects a normalized query and does not load the orders of the campaigns.
+	FindAllCampaigns(ctx context.Context, q query.Query) ([]*entity.Campaign, int64, error)
 	UpdateCampaign(ctx context.Context, Campaign *entity.Campaign) (*entity.Campaign, error)
 }
 
@@ -20,9 +22,12 @@ type OrderRepository interface {
 	CreateOrder(ctx context.Context, order *entity.Order) (*entity.Order, error)
 	FindOrderById(ctx context.Context, id uint) (*entity.Order, error)
 	FindOrdersByCampaignId(ctx context.Context, id uint) ([]*entity.Order, error)
+	// FindOrdersByCampaignIdSortedByRate sorts by ascending interest rate, larger
+	// amounts first on equal rates.
+	FindOrdersByCampaignIdSortedByRate(ctx context.Context, id uint) ([]*entity.Order, error)
 	FindOrdersByState(ctx context.Context, CampaignId uint, state string) ([]*entity.Order, error)
 	FindOrdersByInvestor(ctx context.Context, investor Address) ([]*entity.Order, error)
-	FindAllOrders(ctx context.Context) ([]*entity.Order, error)
+	FindAllOrders(ctx context.Context, q query.Query) ([]*entity.Order, int64, error)
 	UpdateOrder(ctx context.Context, order *entity.Order) (*entity.Order, error)
 	DeleteOrder(ctx context.Context, id uint) error
 }
@@ -31,11 +36,18 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, User *entity.User) (*entity.User, error)
 	FindUsersByRole(ctx context.Context, role string) ([]*entity.User, error)
 	FindUserByAddress(ctx context.Context, address Address) (*entity.User, error)
-	FindAllUsers(ctx context.Context) ([]*entity.User, error)
+	FindAllUsers(ctx context.Context, q query.Query) ([]*entity.User, int64, error)
 	DeleteUser(ctx context.Context, address Address) error
 }
 
+// Transactor runs fn in a transaction that repository methods join when
+// called with the context given to fn. It commits only when fn returns nil.
+type Transactor interface {
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type Repository interface {
+	Transactor
 	CampaignRepository
 	OrderRepository
 	UserRepository
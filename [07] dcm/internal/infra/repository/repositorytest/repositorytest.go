@@ -0,0 +1,327 @@
+// Package repositorytest checks that an implementation of repository.Repository
+// behaves like the others, so the application can switch backends without
+// noticing. Each backend runs it from its own tests.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
+	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	debtor    = HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	investorA = HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	investorB = HexToAddress("0x90F79bf6EB2c4f870365E51660A8a5D1B0a4E5d0")
+	token     = HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+)
+
+// Suite runs the conformance tests, each against a new repository returned by
+// Open, holding nothing but the seeded admin. Run it with suite.Run.
+type Suite struct {
+	suite.Suite
+	Open func() (repository.Repository, error)
+
+	repo repository.Repository
+	ctx  context.Context
+}
+
+func (s *Suite) SetupTest() {
+	repo, err := s.Open()
+	s.Require().NoError(err)
+	s.repo = repo
+	s.ctx = context.Background()
+}
+
+func (s *Suite) TearDownTest() {
+	s.Require().NoError(s.repo.Close())
+}
+
+func (s *Suite) createCampaign(debtIssued uint64, createdAt int64) *entity.Campaign {
+	campaign, err := entity.NewCampaign(token, debtor, token, Uint256FromUint64(10000), Uint256FromUint64(debtIssued), Uint256FromUint64(10), createdAt+100, createdAt+200, createdAt)
+	s.Require().NoError(err)
+	campaign, err = s.repo.CreateCampaign(s.ctx, campaign)
+	s.Require().NoError(err)
+	return campaign
+}
+
+func (s *Suite) createOrder(ctx context.Context, campaignId uint, investor Address, amount, rate uint64) *entity.Order {
+	order, err := entity.NewOrder(campaignId, investor, Uint256FromUint64(amount), Uint256FromUint64(rate), 50)
+	s.Require().NoError(err)
+	order, err = s.repo.CreateOrder(ctx, order)
+	s.Require().NoError(err)
+	return order
+}
+
+func (s *Suite) createUser(role entity.UserRole, address Address, createdAt int64) (*entity.User, error) {
+	user, err := entity.NewUser(string(role), address, createdAt)
+	s.Require().NoError(err)
+	return s.repo.CreateUser(s.ctx, user)
+}
+
+func campaignIds(campaigns []*entity.Campaign) []uint {
+	ids := make([]uint, len(campaigns))
+	for i, campaign := range campaigns {
+		ids[i] = campaign.Id
+	}
+	return ids
+}
+
+func orderIds(orders []*entity.Order) []uint {
+	ids := make([]uint, len(orders))
+	for i, order := range orders {
+		ids[i] = order.Id
+	}
+	return ids
+}
+
+func (s *Suite) TestCreateCampaign() {
+	first := s.createCampaign(1000, 10)
+	second := s.createCampaign(2000, 20)
+	s.NotZero(first.Id)
+	s.Greater(second.Id, first.Id)
+
+	found, err := s.repo.FindCampaignById(s.ctx, second.Id)
+	s.Require().NoError(err)
+	s.Equal(debtor, found.Debtor)
+	s.Equal(uint64(2000), found.DebtIssued.Uint64())
+	s.Equal(entity.CampaignStateOngoing, found.State)
+	s.Equal(int64(120), found.ClosesAt)
+	s.Require().NotNil(found.TotalObligation)
+	s.Require().NotNil(found.TotalRaised)
+	s.True(found.TotalObligation.IsZero())
+	s.True(found.TotalRaised.IsZero())
+	s.Empty(found.Orders)
+}
+
+func (s *Suite) TestMissingRecordsAreNotFound() {
+	_, err := s.repo.FindCampaignById(s.ctx, 42)
+	s.ErrorIs(err, entity.ErrCampaignNotFound)
+	_, err = s.repo.FindOrderById(s.ctx, 42)
+	s.ErrorIs(err, entity.ErrOrderNotFound)
+	s.ErrorIs(s.repo.DeleteOrder(s.ctx, 42), entity.ErrOrderNotFound)
+	_, err = s.repo.FindUserByAddress(s.ctx, investorA)
+	s.ErrorIs(err, entity.ErrUserNotFound)
+	s.ErrorIs(s.repo.DeleteUser(s.ctx, investorA), entity.ErrUserNotFound)
+}
+
+func (s *Suite) TestCampaignsLoadTheirOrders() {
+	campaign := s.createCampaign(1000, 10)
+	other := s.createCampaign(1000, 20)
+	s.createOrder(s.ctx, campaign.Id, investorA, 500, 8)
+	s.createOrder(s.ctx, campaign.Id, investorA, 300, 9)
+	s.createOrder(s.ctx, other.Id, investorB, 700, 5)
+
+	found, err := s.repo.FindCampaignById(s.ctx, campaign.Id)
+	s.Require().NoError(err)
+	s.Len(found.Orders, 2)
+
+	byDebtor, err := s.repo.FindCampaignsByDebtor(s.ctx, debtor)
+	s.Require().NoError(err)
+	s.ElementsMatch([]uint{campaign.Id, other.Id}, campaignIds(byDebtor))
+	for _, c := range byDebtor {
+		s.NotEmpty(c.Orders)
+	}
+
+	byInvestor, err := s.repo.FindCampaignsByInvestor(s.ctx, investorB)
+	s.Require().NoError(err)
+	s.Equal([]uint{other.Id}, campaignIds(byInvestor))
+	s.Len(byInvestor[0].Orders, 1)
+
+	byInvestor, err = s.repo.FindCampaignsByInvestor(s.ctx, debtor)
+	s.Require().NoError(err)
+	s.Empty(byInvestor)
+}
+
+func (s *Suite) TestUpdateCampaignKeepsUnsetFields() {
+	campaign := s.createCampaign(1000, 10)
+	s.createOrder(s.ctx, campaign.Id, investorA, 500, 8)
+
+	updated, err := s.repo.UpdateCampaign(s.ctx, &entity.Campaign{
+		Id:          campaign.Id,
+		State:       entity.CampaignStateClosed,
+		TotalRaised: Uint256FromUint64(500),
+		UpdatedAt:   30,
+	})
+	s.Require().NoError(err)
+	s.Equal(entity.CampaignStateClosed, updated.State)
+	s.Equal(uint64(500), updated.TotalRaised.Uint64())
+	s.Equal(int64(30), updated.UpdatedAt)
+	s.Equal(debtor, updated.Debtor)
+	s.Equal(uint64(1000), updated.DebtIssued.Uint64())
+	s.Equal(int64(10), updated.CreatedAt)
+	s.Len(updated.Orders, 1)
+}
+
+func (s *Suite) TestUpdateOrder() {
+	campaign := s.createCampaign(1000, 10)
+	order := s.createOrder(s.ctx, campaign.Id, investorA, 500, 8)
+
+	order.State = entity.OrderStatePartiallyAccepted
+	order.Amount = Uint256FromUint64(200)
+	order.UpdatedAt = 60
+	updated, err := s.repo.UpdateOrder(s.ctx, order)
+	s.Require().NoError(err)
+	s.Equal(entity.OrderStatePartiallyAccepted, updated.State)
+	s.Equal(uint64(200), updated.Amount.Uint64())
+	s.Equal(int64(60), updated.UpdatedAt)
+	s.Equal(int64(50), updated.CreatedAt)
+
+	pending, err := s.repo.FindOrdersByState(s.ctx, campaign.Id, string(entity.OrderStatePending))
+	s.Require().NoError(err)
+	s.Empty(pending)
+	partial, err := s.repo.FindOrdersByState(s.ctx, campaign.Id, string(entity.OrderStatePartiallyAccepted))
+	s.Require().NoError(err)
+	s.Equal([]uint{order.Id}, orderIds(partial))
+
+	s.Require().NoError(s.repo.DeleteOrder(s.ctx, order.Id))
+	_, err = s.repo.FindOrderById(s.ctx, order.Id)
+	s.ErrorIs(err, entity.ErrOrderNotFound)
+}
+
+func (s *Suite) TestFindOrders() {
+	campaign := s.createCampaign(1000, 10)
+	other := s.createCampaign(1000, 20)
+	low := s.createOrder(s.ctx, campaign.Id, investorA, 900, 10)
+	large := s.createOrder(s.ctx, campaign.Id, investorB, 100000, 9)
+	small := s.createOrder(s.ctx, campaign.Id, investorA, 2000, 9)
+	elsewhere := s.createOrder(s.ctx, other.Id, investorA, 50, 1)
+
+	byCampaign, err := s.repo.FindOrdersByCampaignId(s.ctx, campaign.Id)
+	s.Require().NoError(err)
+	s.ElementsMatch([]uint{low.Id, large.Id, small.Id}, orderIds(byCampaign))
+
+	byRate, err := s.repo.FindOrdersByCampaignIdSortedByRate(s.ctx, campaign.Id)
+	s.Require().NoError(err)
+	s.Equal([]uint{large.Id, small.Id, low.Id}, orderIds(byRate))
+
+	byInvestor, err := s.repo.FindOrdersByInvestor(s.ctx, investorA)
+	s.Require().NoError(err)
+	s.ElementsMatch([]uint{low.Id, small.Id, elsewhere.Id}, orderIds(byInvestor))
+
+	tests := []struct {
+		name string
+		q    query.Query
+		want []uint
+	}{
+		{"by id", query.Query{}, []uint{low.Id, large.Id, small.Id, elsewhere.Id}},
+		{"by amount, numerically", query.Query{Sort: "amount"}, []uint{elsewhere.Id, low.Id, small.Id, large.Id}},
+		{"by rate descending, ties by id", query.Query{Sort: "interest_rate", Order: query.Desc}, []uint{low.Id, small.Id, large.Id, elsewhere.Id}},
+		{"page", query.Query{Sort: "amount", Limit: 2, Offset: 1}, []uint{low.Id, small.Id}},
+		{"before cursor descending", query.Query{Cursor: small.Id, Order: query.Desc}, []uint{large.Id, low.Id}},
+	}
+	for _, tt := range tests {
+		q, err := tt.q.Normalize("id", "campaign_id", "amount", "interest_rate", "created_at", "updated_at")
+		s.Require().NoError(err)
+		orders, total, err := s.repo.FindAllOrders(s.ctx, q)
+		s.Require().NoError(err, tt.name)
+		s.Equal(tt.want, orderIds(orders), tt.name)
+		s.Equal(int64(4), total, tt.name)
+	}
+}
+
+func (s *Suite) TestFindAllCampaignsFilters() {
+	early := s.createCampaign(3000, 10)
+	middle := s.createCampaign(20000, 20)
+	late := s.createCampaign(100, 30)
+	_, err := s.repo.UpdateCampaign(s.ctx, &entity.Campaign{Id: middle.Id, State: entity.CampaignStateClosed})
+	s.Require().NoError(err)
+
+	tests := []struct {
+		name  string
+		q     query.Query
+		want  []uint
+		total int64
+	}{
+		{"ongoing", query.Query{State: string(entity.CampaignStateOngoing)}, []uint{early.Id, late.Id}, 2},
+		{"closed", query.Query{State: string(entity.CampaignStateClosed)}, []uint{middle.Id}, 1},
+		{"created from, inclusive", query.Query{From: 20}, []uint{middle.Id, late.Id}, 2},
+		{"created to, inclusive", query.Query{To: 20}, []uint{early.Id, middle.Id}, 2},
+		{"by debt issued, numerically", query.Query{Sort: "debt_issued"}, []uint{late.Id, early.Id, middle.Id}, 3},
+		{"limited", query.Query{Limit: 1, Order: query.Desc}, []uint{late.Id}, 3},
+	}
+	for _, tt := range tests {
+		q, err := tt.q.Normalize("id", "debt_issued", "max_interest_rate", "created_at", "closes_at", "maturity_at", "updated_at")
+		s.Require().NoError(err)
+		campaigns, total, err := s.repo.FindAllCampaigns(s.ctx, q)
+		s.Require().NoError(err, tt.name)
+		s.Equal(tt.want, campaignIds(campaigns), tt.name)
+		s.Equal(tt.total, total, tt.name)
+	}
+}
+
+func (s *Suite) TestSortsSignedTimestamps() {
+	positive := s.createCampaign(1000, 10)
+	negative := s.createCampaign(1000, -20)
+	zeroish := s.createCampaign(1000, -1)
+
+	q, err := query.Query{Sort: "created_at"}.Normalize("id", "debt_issued", "max_interest_rate", "created_at", "closes_at", "maturity_at", "updated_at")
+	s.Require().NoError(err)
+	campaigns, _, err := s.repo.FindAllCampaigns(s.ctx, q)
+	s.Require().NoError(err)
+	s.Equal([]uint{negative.Id, zeroish.Id, positive.Id}, campaignIds(campaigns))
+}
+
+func (s *Suite) TestUsers() {
+	admins, err := s.repo.FindUsersByRole(s.ctx, string(entity.UserRoleAdmin))
+	s.Require().NoError(err)
+	s.Len(admins, 1, "the repository seeds a single admin")
+
+	user, err := s.createUser(entity.UserRoleInvestor, investorA, 10)
+	s.Require().NoError(err)
+	s.NotZero(user.Id)
+	_, err = s.createUser(entity.UserRoleDebtor, investorA, 20)
+	s.Error(err, "addresses are unique")
+	_, err = s.createUser(entity.UserRoleInvestor, investorB, 30)
+	s.Require().NoError(err)
+
+	found, err := s.repo.FindUserByAddress(s.ctx, investorA)
+	s.Require().NoError(err)
+	s.Equal(user.Id, found.Id)
+	s.Equal(entity.UserRoleInvestor, found.Role)
+
+	investors, err := s.repo.FindUsersByRole(s.ctx, string(entity.UserRoleInvestor))
+	s.Require().NoError(err)
+	s.Len(investors, 2)
+
+	q, err := query.Query{State: string(entity.UserRoleInvestor), Order: query.Desc}.Normalize("id", "created_at", "updated_at")
+	s.Require().NoError(err)
+	users, total, err := s.repo.FindAllUsers(s.ctx, q)
+	s.Require().NoError(err)
+	s.Equal(int64(2), total)
+	s.Equal(investorB, users[0].Address)
+
+	s.Require().NoError(s.repo.DeleteUser(s.ctx, investorA))
+	_, err = s.repo.FindUserByAddress(s.ctx, investorA)
+	s.ErrorIs(err, entity.ErrUserNotFound)
+}
+
+func (s *Suite) TestTransactions() {
+	campaign := s.createCampaign(1000, 10)
+	errRejected := errors.New("rejected")
+
+	err := s.repo.RunInTransaction(s.ctx, func(ctx context.Context) error {
+		s.createOrder(ctx, campaign.Id, investorA, 500, 8)
+		orders, err := s.repo.FindOrdersByCampaignId(ctx, campaign.Id)
+		s.Require().NoError(err)
+		s.Len(orders, 1, "a transaction sees its own writes")
+		return errRejected
+	})
+	s.ErrorIs(err, errRejected)
+	orders, err := s.repo.FindOrdersByCampaignId(s.ctx, campaign.Id)
+	s.Require().NoError(err)
+	s.Empty(orders, "a failed transaction is rolled back")
+
+	s.Require().NoError(s.repo.RunInTransaction(s.ctx, func(ctx context.Context) error {
+		s.createOrder(ctx, campaign.Id, investorA, 500, 8)
+		return nil
+	}))
+	orders, err = s.repo.FindOrdersByCampaignId(s.ctx, campaign.Id)
+	s.Require().NoError(err)
+	s.Len(orders, 1, "a successful transaction is committed")
+}
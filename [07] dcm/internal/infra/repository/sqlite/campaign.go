@@ -6,11 +6,12 @@ import (
 
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
 	"gorm.io/gorm"
 )
 
 func (r *SQLiteRepository) CreateCampaign(ctx context.Context, input *entity.Campaign) (*entity.Campaign, error) {
-	if err := r.Db.WithContext(ctx).Create(input).Error; err != nil {
+	if err := r.db(ctx).Create(input).Error; err != nil {
 		return nil, fmt.Errorf("failed to create campaign: %w", err)
 	}
 	return input, nil
@@ -18,7 +19,7 @@ func (r *SQLiteRepository) CreateCampaign(ctx context.Context, input *entity.Cam
 
 func (r *SQLiteRepository) FindCampaignById(ctx context.Context, id uint) (*entity.Campaign, error) {
 	var Campaign entity.Campaign
-	if err := r.Db.WithContext(ctx).
+	if err := r.db(ctx).
 		Preload("Orders").
 		First(&Campaign, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -29,19 +30,18 @@ func (r *SQLiteRepository) FindCampaignById(ctx context.Context, id uint) (*enti
 	return &Campaign, nil
 }
 
-func (r *SQLiteRepository) FindAllCampaigns(ctx context.Context) ([]*entity.Campaign, error) {
+func (r *SQLiteRepository) FindAllCampaigns(ctx context.Context, q query.Query) ([]*entity.Campaign, int64, error) {
 	var Campaigns []*entity.Campaign
-	if err := r.Db.WithContext(ctx).
-		Preload("Orders").
-		Find(&Campaigns).Error; err != nil {
-		return nil, fmt.Errorf("failed to find all campaigns: %w", err)
+	total, err := findPage(r.db(ctx), q, &Campaigns, "state", "created_at")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find all campaigns: %w", err)
 	}
-	return Campaigns, nil
+	return Campaigns, total, nil
 }
 
 func (r *SQLiteRepository) FindCampaignsByInvestor(ctx context.Context, investor Address) ([]*entity.Campaign, error) {
 	var Campaigns []*entity.Campaign
-	if err := r.Db.WithContext(ctx).
+	if err := r.db(ctx).
 		Joins("JOIN orders ON orders.campaign_id = campaigns.id").
 		Where("orders.investor = ?", investor).
 		Preload("Orders").
@@ -53,7 +53,7 @@ func (r *SQLiteRepository) FindCampaignsByInvestor(ctx context.Context, investor
 
 func (r *SQLiteRepository) FindCampaignsByDebtor(ctx context.Context, debtor Address) ([]*entity.Campaign, error) {
 	var Campaigns []*entity.Campaign
-	if err := r.Db.WithContext(ctx).
+	if err := r.db(ctx).
 		Where("debtor = ?", debtor).
 		Preload("Orders").
 		Find(&Campaigns).Error; err != nil {
@@ -63,7 +63,9 @@ func (r *SQLiteRepository) FindCampaignsByDebtor(ctx context.Context, debtor Add
 }
 
 func (r *SQLiteRepository) UpdateCampaign(ctx context.Context, input *entity.Campaign) (*entity.Campaign, error) {
-	if err := r.Db.WithContext(ctx).Updates(&input).Error; err != nil {
+	// UpdateColumns keeps the given updated_at, the block timestamp, instead
+	// of stamping the wall clock time.
+	if err := r.db(ctx).Model(input).UpdateColumns(input).Error; err != nil {
 		return nil, fmt.Errorf("failed to update campaign: %w", err)
 	}
 	Campaign, err := r.FindCampaignById(ctx, input.Id)
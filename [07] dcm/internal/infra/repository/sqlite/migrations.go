@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+// migrations is the schema history of the database. Steps are append-only:
+// a deployed drive may already be at any of these versions, so change the
+// schema by adding a new version rather than editing an old one.
+var migrations = []migrate.Migration{
+	{
+		// Matches the schema AutoMigrate created before migrations were
+		// versioned, so existing drives adopt it unchanged.
+		Version:     1,
+		Description: "create campaigns, orders and users",
+		Up: execAll(
+			"CREATE TABLE IF NOT EXISTS `campaigns` (`id` integer PRIMARY KEY AUTOINCREMENT,`token` text NOT NULL,`debtor` text NOT NULL,`collateral_address` text NOT NULL,`collateral_amount` text NOT NULL,`debt_issued` text NOT NULL,`max_interest_rate` text NOT NULL,`total_obligation` text NOT NULL DEFAULT \"0\",`total_raised` text NOT NULL DEFAULT \"0\",`state` text NOT NULL,`closes_at` integer NOT NULL,`maturity_at` integer NOT NULL,`created_at` integer NOT NULL,`updated_at` integer DEFAULT 0)",
+			"CREATE TABLE IF NOT EXISTS `orders` (`id` integer PRIMARY KEY AUTOINCREMENT,`campaign_id` integer NOT NULL,`investor` text NOT NULL,`amount` text NOT NULL,`interest_rate` text NOT NULL,`state` text NOT NULL,`created_at` integer NOT NULL,`updated_at` integer DEFAULT 0,CONSTRAINT `fk_campaigns_orders` FOREIGN KEY (`campaign_id`) REFERENCES `campaigns`(`id`) ON DELETE CASCADE)",
+			"CREATE INDEX IF NOT EXISTS `idx_orders_campaign_id` ON `orders`(`campaign_id`)",
+			"CREATE TABLE IF NOT EXISTS `users` (`id` integer PRIMARY KEY AUTOINCREMENT,`role` text NOT NULL,`address` text NOT NULL,`created_at` integer NOT NULL,`updated_at` integer DEFAULT 0)",
+			"CREATE UNIQUE INDEX IF NOT EXISTS `idx_users_address` ON `users`(`address`)",
+		),
+	},
+	{
+		// Uint256 columns used to hold unpadded decimals, which SQLite orders
+		// as text. Rebuild campaigns for the padded zero defaults and pad the
+		// stored amounts so they compare numerically.
+		Version:     2,
+		Description: "store uint256 columns as fixed-width decimals",
+		Up: execAll(
+			"CREATE TABLE `campaigns__new` (`id` integer PRIMARY KEY AUTOINCREMENT,`token` text NOT NULL,`debtor` text NOT NULL,`collateral_address` text NOT NULL,`collateral_amount` text NOT NULL,`debt_issued` text NOT NULL,`max_interest_rate` text NOT NULL,`total_obligation` text NOT NULL DEFAULT \"000000000000000000000000000000000000000000000000000000000000000000000000000000\",`total_raised` text NOT NULL DEFAULT \"000000000000000000000000000000000000000000000000000000000000000000000000000000\",`state` text NOT NULL,`closes_at` integer NOT NULL,`maturity_at` integer NOT NULL,`created_at` integer NOT NULL,`updated_at` integer DEFAULT 0)",
+			"INSERT INTO `campaigns__new` SELECT `id`,`token`,`debtor`,`collateral_address`,substr('000000000000000000000000000000000000000000000000000000000000000000000000000000' || `collateral_amount`, -78, 78),substr('000000000000000000000000000000000000000000000000000000000000000000000000000000' || `debt_issued`, -78, 78),substr('000000000000000000000000000000000000000000000000000000000000000000000000000000' || `max_interest_rate`, -78, 78),substr('000000000000000000000000000000000000000000000000000000000000000000000000000000' || `total_obligation`, -78, 78),substr('000000000000000000000000000000000000000000000000000000000000000000000000000000' || `total_raised`, -78, 78),`state`,`closes_at`,`maturity_at`,`created_at`,`updated_at` FROM `campaigns`",
+			"DROP TABLE `campaigns`",
+			"ALTER TABLE `campaigns__new` RENAME TO `campaigns`",
+			"UPDATE `orders` SET `amount` = substr('000000000000000000000000000000000000000000000000000000000000000000000000000000' || `amount`, -78, 78), `interest_rate` = substr('000000000000000000000000000000000000000000000000000000000000000000000000000000' || `interest_rate`, -78, 78)",
+		),
+	},
+}
+
+func execAll(statements ...string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -6,11 +6,12 @@ import (
 
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
 	"gorm.io/gorm"
 )
 
 func (r *SQLiteRepository) CreateOrder(ctx context.Context, input *entity.Order) (*entity.Order, error) {
-	if err := r.Db.WithContext(ctx).Create(input).Error; err != nil {
+	if err := r.db(ctx).Create(input).Error; err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 	return input, nil
@@ -18,7 +19,7 @@ func (r *SQLiteRepository) CreateOrder(ctx context.Context, input *entity.Order)
 
 func (r *SQLiteRepository) FindOrderById(ctx context.Context, id uint) (*entity.Order, error) {
 	var order entity.Order
-	if err := r.Db.WithContext(ctx).First(&order, id).Error; err != nil {
+	if err := r.db(ctx).First(&order, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entity.ErrOrderNotFound
 		}
@@ -29,7 +30,18 @@ func (r *SQLiteRepository) FindOrderById(ctx context.Context, id uint) (*entity.
 
 func (r *SQLiteRepository) FindOrdersByCampaignId(ctx context.Context, id uint) ([]*entity.Order, error) {
 	var orders []*entity.Order
-	if err := r.Db.WithContext(ctx).Where("campaign_id = ?", id).Find(&orders).Error; err != nil {
+	if err := r.db(ctx).Where("campaign_id = ?", id).Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("failed to find orders by campaign ID: %w", err)
+	}
+	return orders, nil
+}
+
+func (r *SQLiteRepository) FindOrdersByCampaignIdSortedByRate(ctx context.Context, id uint) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	if err := r.db(ctx).
+		Where("campaign_id = ?", id).
+		Order("interest_rate ASC, amount DESC").
+		Find(&orders).Error; err != nil {
 		return nil, fmt.Errorf("failed to find orders by campaign ID: %w", err)
 	}
 	return orders, nil
@@ -37,7 +49,7 @@ func (r *SQLiteRepository) FindOrdersByCampaignId(ctx context.Context, id uint)
 
 func (r *SQLiteRepository) FindOrdersByState(ctx context.Context, campaignId uint, state string) ([]*entity.Order, error) {
 	var orders []*entity.Order
-	if err := r.Db.WithContext(ctx).
+	if err := r.db(ctx).
 		Where("campaign_id = ? AND state = ?", campaignId, state).
 		Find(&orders).Error; err != nil {
 		return nil, fmt.Errorf("failed to find orders by state: %w", err)
@@ -47,22 +59,25 @@ func (r *SQLiteRepository) FindOrdersByState(ctx context.Context, campaignId uin
 
 func (r *SQLiteRepository) FindOrdersByInvestor(ctx context.Context, investor Address) ([]*entity.Order, error) {
 	var orders []*entity.Order
-	if err := r.Db.WithContext(ctx).Where("investor = ?", investor).Find(&orders).Error; err != nil {
+	if err := r.db(ctx).Where("investor = ?", investor).Find(&orders).Error; err != nil {
 		return nil, fmt.Errorf("failed to find orders by investor: %w", err)
 	}
 	return orders, nil
 }
 
-func (r *SQLiteRepository) FindAllOrders(ctx context.Context) ([]*entity.Order, error) {
+func (r *SQLiteRepository) FindAllOrders(ctx context.Context, q query.Query) ([]*entity.Order, int64, error) {
 	var orders []*entity.Order
-	if err := r.Db.WithContext(ctx).Find(&orders).Error; err != nil {
-		return nil, fmt.Errorf("failed to find all orders: %w", err)
+	total, err := findPage(r.db(ctx), q, &orders, "state", "created_at")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find all orders: %w", err)
 	}
-	return orders, nil
+	return orders, total, nil
 }
 
 func (r *SQLiteRepository) UpdateOrder(ctx context.Context, input *entity.Order) (*entity.Order, error) {
-	if err := r.Db.WithContext(ctx).Updates(&input).Error; err != nil {
+	// UpdateColumns keeps the given updated_at, the block timestamp, instead
+	// of stamping the wall clock time.
+	if err := r.db(ctx).Model(input).UpdateColumns(input).Error; err != nil {
 		return nil, fmt.Errorf("failed to update order: %w", err)
 	}
 	order, err := r.FindOrderById(ctx, input.Id)
@@ -73,7 +88,7 @@ func (r *SQLiteRepository) UpdateOrder(ctx context.Context, input *entity.Order)
 }
 
 func (r *SQLiteRepository) DeleteOrder(ctx context.Context, id uint) error {
-	res := r.Db.WithContext(ctx).Delete(&entity.Order{}, id)
+	res := r.db(ctx).Delete(&entity.Order{}, id)
 	if res.Error != nil {
 		return fmt.Errorf("failed to delete order: %w", res.Error)
 	}
@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// findPage loads into dest the page of db selected by the normalized query q,
+// filtering by state on stateColumn and by time range on timeColumn, and
+// returns how many rows match the filters.
+func findPage(db *gorm.DB, q query.Query, dest any, stateColumn string, timeColumn string) (int64, error) {
+	db = db.Model(dest)
+	if q.State != "" {
+		db = db.Where(clause.Eq{Column: clause.Column{Name: stateColumn}, Value: q.State})
+	}
+	if q.From != 0 {
+		db = db.Where(clause.Gte{Column: clause.Column{Name: timeColumn}, Value: q.From})
+	}
+	if q.To != 0 {
+		db = db.Where(clause.Lte{Column: clause.Column{Name: timeColumn}, Value: q.To})
+	}
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count: %w", err)
+	}
+
+	desc := q.Order == query.Desc
+	if q.Cursor != 0 {
+		if desc {
+			db = db.Where(clause.Lt{Column: clause.Column{Name: "id"}, Value: q.Cursor})
+		} else {
+			db = db.Where(clause.Gt{Column: clause.Column{Name: "id"}, Value: q.Cursor})
+		}
+	}
+	err := db.
+		Order(clause.OrderByColumn{Column: clause.Column{Name: q.Sort}, Desc: desc}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: desc}).
+		Limit(q.Limit).
+		Offset(q.Offset).
+		Find(dest).Error
+	return total, err
+}
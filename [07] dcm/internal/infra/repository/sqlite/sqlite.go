@@ -1,6 +1,7 @@
 package sqlite
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"gorm.io/gorm/logger"
 
 	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/migrate"
 
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 )
@@ -31,31 +33,18 @@ func (r *SQLiteRepository) Close() error {
 func NewSQLiteRepository(conn string) (*SQLiteRepository, error) {
 	dbPath := strings.TrimPrefix(conn, "sqlite://")
 
-	newLogger := logger.New(
-		log.New(os.Stdout, "\r\n", log.LstdFlags),
-		logger.Config{
-			SlowThreshold:             time.Second,
-			LogLevel:                  logger.Info,
-			IgnoreRecordNotFoundError: true,
-			Colorful:                  true,
-		},
-	)
-
-	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{
-		Logger: newLogger,
-	})
+	db, err := open(dbPath)
 	if err != nil {
 		return nil, err
 	}
 
-	err = db.AutoMigrate(
-		&entity.Campaign{},
-		&entity.Order{},
-		&entity.User{},
-	)
+	migrator, err := migrate.New(db, migrations...)
 	if err != nil {
 		return nil, err
 	}
+	if err := migrator.Up(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
 
 	var adminAddress string
 	if dbPath == ":memory:" {
@@ -70,9 +59,51 @@ func NewSQLiteRepository(conn string) (*SQLiteRepository, error) {
 		CreatedAt: time.Now().Unix(),
 	}
 
-	if err := db.Create(&adminUser).Error; err != nil {
+	// The drive keeps the admin across reboots, so only seed it once.
+	if err := db.Where("address = ?", adminUser.Address).FirstOrCreate(&adminUser).Error; err != nil {
 		return nil, fmt.Errorf("failed to create admin user: %w", err)
 	}
 
 	return &SQLiteRepository{Db: db}, nil
 }
+
+// MigrationStatus reports which migrations have been applied to the database
+// at conn, without migrating it. The database file must already exist, since
+// opening a missing one would create it.
+func MigrationStatus(ctx context.Context, conn string) ([]migrate.Status, error) {
+	dbPath := strings.TrimPrefix(conn, "sqlite://")
+	file, _, _ := strings.Cut(dbPath, "?")
+	if _, err := os.Stat(file); err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db, err := open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	repo := &SQLiteRepository{Db: db}
+	defer repo.Close()
+	db = db.Session(&gorm.Session{Logger: db.Logger.LogMode(logger.Silent)})
+
+	migrator, err := migrate.New(db, migrations...)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Status(ctx)
+}
+
+func open(dbPath string) (*gorm.DB, error) {
+	newLogger := logger.New(
+		log.New(os.Stdout, "\r\n", log.LstdFlags),
+		logger.Config{
+			SlowThreshold:             time.Second,
+			LogLevel:                  logger.Info,
+			IgnoreRecordNotFoundError: true,
+			Colorful:                  true,
+		},
+	)
+
+	return gorm.Open(sqlite.Open(dbPath), &gorm.Config{
+		Logger: newLogger,
+	})
+}
@@ -6,11 +6,12 @@ import (
 
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
 	"gorm.io/gorm"
 )
 
 func (r *SQLiteRepository) CreateUser(ctx context.Context, input *entity.User) (*entity.User, error) {
-	if err := r.Db.WithContext(ctx).Create(input).Error; err != nil {
+	if err := r.db(ctx).Create(input).Error; err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 	return input, nil
@@ -18,7 +19,7 @@ func (r *SQLiteRepository) CreateUser(ctx context.Context, input *entity.User) (
 
 func (r *SQLiteRepository) FindUserByAddress(ctx context.Context, address Address) (*entity.User, error) {
 	var user entity.User
-	if err := r.Db.WithContext(ctx).Where("address = ?", address).First(&user).Error; err != nil {
+	if err := r.db(ctx).Where("address = ?", address).First(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entity.ErrUserNotFound
 		}
@@ -29,22 +30,23 @@ func (r *SQLiteRepository) FindUserByAddress(ctx context.Context, address Addres
 
 func (r *SQLiteRepository) FindUsersByRole(ctx context.Context, role string) ([]*entity.User, error) {
 	var users []*entity.User
-	if err := r.Db.WithContext(ctx).Where("role = ?", role).Find(&users).Error; err != nil {
+	if err := r.db(ctx).Where("role = ?", role).Find(&users).Error; err != nil {
 		return nil, fmt.Errorf("failed to find users by role: %w", err)
 	}
 	return users, nil
 }
 
-func (r *SQLiteRepository) FindAllUsers(ctx context.Context) ([]*entity.User, error) {
+func (r *SQLiteRepository) FindAllUsers(ctx context.Context, q query.Query) ([]*entity.User, int64, error) {
 	var users []*entity.User
-	if err := r.Db.WithContext(ctx).Find(&users).Error; err != nil {
-		return nil, fmt.Errorf("failed to find all users: %w", err)
+	total, err := findPage(r.db(ctx), q, &users, "role", "created_at")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find all users: %w", err)
 	}
-	return users, nil
+	return users, total, nil
 }
 
 func (r *SQLiteRepository) DeleteUser(ctx context.Context, address Address) error {
-	res := r.Db.WithContext(ctx).Where("address = ?", address).Delete(&entity.User{})
+	res := r.db(ctx).Where("address = ?", address).Delete(&entity.User{})
 	if res.Error != nil {
 		return fmt.Errorf("failed to delete user: %w", res.Error)
 	}
@@ -3,7 +3,6 @@ package campaign
 import (
 	"context"
 	"fmt"
-	"sort"
 
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
@@ -21,11 +20,11 @@ type CloseCampaignOutputDTO struct {
 	Token             Address         `json:"token,omitempty"`
 	Debtor            Address         `json:"debtor,omitempty"`
 	CollateralAddress Address         `json:"collateral_address,omitempty"`
-	CollateralAmount  *uint256.Int    `json:"collateral_amount,omitempty"`
-	DebtIssued        *uint256.Int    `json:"debt_issued,omitempty"`
-	MaxInterestRate   *uint256.Int    `json:"max_interest_rate,omitempty"`
-	TotalObligation   *uint256.Int    `json:"total_obligation,omitempty"`
-	TotalRaised       *uint256.Int    `json:"total_raised,omitempty"`
+	CollateralAmount  *Uint256        `json:"collateral_amount,omitempty"`
+	DebtIssued        *Uint256        `json:"debt_issued,omitempty"`
+	MaxInterestRate   *Uint256        `json:"max_interest_rate,omitempty"`
+	TotalObligation   *Uint256        `json:"total_obligation,omitempty"`
+	TotalRaised       *Uint256        `json:"total_raised,omitempty"`
 	State             string          `json:"state,omitempty"`
 	Orders            []*entity.Order `json:"orders,omitempty"`
 	CreatedAt         int64           `json:"created_at,omitempty"`
@@ -73,23 +72,17 @@ func (u *CloseCampaignUseCase) Execute(ctx context.Context, input *CloseCampaign
 	}
 
 	// -------------------------------------------------------------------------
-	// 3. Fetch and sort campaign orders
+	// 3. Fetch campaign orders, best rates first
 	// -------------------------------------------------------------------------
-	orders, err := u.OrderRepository.FindOrdersByCampaignId(ctx, ongoingCampaign.Id)
+	orders, err := u.OrderRepository.FindOrdersByCampaignIdSortedByRate(ctx, ongoingCampaign.Id)
 	if err != nil {
 		return nil, err
 	}
-	sort.Slice(orders, func(i, j int) bool {
-		if orders[i].InterestRate.Cmp(orders[j].InterestRate) == 0 {
-			return orders[i].Amount.Cmp(orders[j].Amount) > 0
-		}
-		return orders[i].InterestRate.Cmp(orders[j].InterestRate) < 0
-	})
 
 	// -------------------------------------------------------------------------
 	// 4. Select winning orders and calculate obligations
 	// -------------------------------------------------------------------------
-	debtRemaining := new(uint256.Int).Set(ongoingCampaign.DebtIssued)
+	debtRemaining := new(uint256.Int).Set(&ongoingCampaign.DebtIssued.Int)
 	totalCollected := uint256.NewInt(0)
 	totalObligation := uint256.NewInt(0)
 
@@ -105,24 +98,24 @@ func (u *CloseCampaignUseCase) Execute(ctx context.Context, input *CloseCampaign
 		}
 
 		// Accept full or partial order
-		acceptAmount := order.Amount
-		if debtRemaining.Lt(order.Amount) {
+		acceptAmount := &order.Amount.Int
+		if debtRemaining.Lt(&order.Amount.Int) {
 			acceptAmount = new(uint256.Int).Set(debtRemaining)
 		}
-		interest := new(uint256.Int).Mul(acceptAmount, order.InterestRate)
+		interest := new(uint256.Int).Mul(acceptAmount, &order.InterestRate.Int)
 		interest.Div(interest, uint256.NewInt(100))
 
 		orderObligation := new(uint256.Int).Add(acceptAmount, interest)
 		totalCollected.Add(totalCollected, acceptAmount)
 		totalObligation.Add(totalObligation, orderObligation)
 
-		if debtRemaining.Cmp(order.Amount) >= 0 {
+		if debtRemaining.Cmp(&order.Amount.Int) >= 0 {
 			order.State = entity.OrderStateAccepted
-			debtRemaining.Sub(debtRemaining, order.Amount)
+			debtRemaining.Sub(debtRemaining, &order.Amount.Int)
 		} else {
 			order.State = entity.OrderStatePartiallyAccepted
 			// Create rejected order for the surplus
-			rejectedAmount := new(uint256.Int).Sub(order.Amount, acceptAmount)
+			rejectedAmount := NewUint256(new(uint256.Int).Sub(&order.Amount.Int, acceptAmount))
 			_, err := u.OrderRepository.CreateOrder(ctx, &entity.Order{
 				CampaignId:   order.CampaignId,
 				Investor:     order.Investor,
@@ -137,7 +130,7 @@ func (u *CloseCampaignUseCase) Execute(ctx context.Context, input *CloseCampaign
 			}
 			debtRemaining.Clear()
 		}
-		order.Amount = acceptAmount
+		order.Amount = NewUint256(acceptAmount)
 		order.UpdatedAt = metadata.BlockTimestamp
 		if _, err := u.OrderRepository.UpdateOrder(ctx, order); err != nil {
 			return nil, err
@@ -147,10 +140,10 @@ func (u *CloseCampaignUseCase) Execute(ctx context.Context, input *CloseCampaign
 	// -------------------------------------------------------------------------
 	// 5. Check if minimum funding (2/3) was reached
 	// -------------------------------------------------------------------------
-	twoThirds := new(uint256.Int).Mul(ongoingCampaign.DebtIssued, uint256.NewInt(2))
+	twoThirds := new(uint256.Int).Mul(&ongoingCampaign.DebtIssued.Int, uint256.NewInt(2))
 	twoThirds.Div(twoThirds, uint256.NewInt(3))
 	if totalCollected.Lt(twoThirds) {
-		// Cancel campaign and reject all orders
+		// Cancel campaign and reject all orders, so that they are refunded
 		for _, order := range orders {
 			order.State = entity.OrderStateRejected
 			order.UpdatedAt = metadata.BlockTimestamp
@@ -159,19 +152,15 @@ func (u *CloseCampaignUseCase) Execute(ctx context.Context, input *CloseCampaign
 			}
 		}
 		ongoingCampaign.State = entity.CampaignStateCanceled
-		ongoingCampaign.UpdatedAt = metadata.BlockTimestamp
-		if _, err := u.CampaignRepository.UpdateCampaign(ctx, ongoingCampaign); err != nil {
-			return nil, err
-		}
-		return nil, fmt.Errorf("campaign canceled due to insufficient funds collected, expected at least 2/3 of the debt issued: %s, got: %s", twoThirds.String(), totalCollected.String())
+	} else {
+		ongoingCampaign.State = entity.CampaignStateClosed
+		ongoingCampaign.TotalObligation = NewUint256(totalObligation)
+		ongoingCampaign.TotalRaised = NewUint256(totalCollected)
 	}
 
 	// -------------------------------------------------------------------------
-	// 6. Close campaign and return result
+	// 6. Close or cancel campaign and return result
 	// -------------------------------------------------------------------------
-	ongoingCampaign.State = entity.CampaignStateClosed
-	ongoingCampaign.TotalObligation = totalObligation
-	ongoingCampaign.TotalRaised = totalCollected
 	ongoingCampaign.UpdatedAt = metadata.BlockTimestamp
 	res, err := u.CampaignRepository.UpdateCampaign(ctx, ongoingCampaign)
 	if err != nil {
@@ -12,11 +12,11 @@ import (
 )
 
 type CreateCampaignInputDTO struct {
-	Token           Address      `json:"token" validate:"required"`
-	DebtIssued      *uint256.Int `json:"debt_issued" validate:"required"`
-	MaxInterestRate *uint256.Int `json:"max_interest_rate" validate:"required"`
-	ClosesAt        int64        `json:"closes_at" validate:"required"`
-	MaturityAt      int64        `json:"maturity_at" validate:"required"`
+	Token           Address  `json:"token" validate:"required"`
+	DebtIssued      *Uint256 `json:"debt_issued" validate:"required,uint256_gt0"`
+	MaxInterestRate *Uint256 `json:"max_interest_rate" validate:"required,uint256_gt0"`
+	ClosesAt        int64    `json:"closes_at" validate:"required"`
+	MaturityAt      int64    `json:"maturity_at" validate:"required"`
 }
 
 type CreateCampaignOutputDTO struct {
@@ -24,9 +24,9 @@ type CreateCampaignOutputDTO struct {
 	Token             Address         `json:"token,omitempty"`
 	Debtor            Address         `json:"debtor,omitempty"`
 	CollateralAddress Address         `json:"collateral_address,omitempty"`
-	CollateralAmount  *uint256.Int    `json:"collateral_amount,omitempty"`
-	DebtIssued        *uint256.Int    `json:"debt_issued"`
-	MaxInterestRate   *uint256.Int    `json:"max_interest_rate"`
+	CollateralAmount  *Uint256        `json:"collateral_amount,omitempty"`
+	DebtIssued        *Uint256        `json:"debt_issued"`
+	MaxInterestRate   *Uint256        `json:"max_interest_rate"`
 	State             string          `json:"state"`
 	Orders            []*entity.Order `json:"orders"`
 	CreatedAt         int64           `json:"created_at"`
@@ -78,7 +78,7 @@ func (c *CreateCampaignUseCase) Execute(ctx context.Context, input *CreateCampai
 		input.Token,
 		Address(erc20Deposit.Sender),
 		Address(erc20Deposit.Token),
-		uint256.MustFromBig(erc20Deposit.Value),
+		NewUint256(uint256.MustFromBig(erc20Deposit.Value)),
 		input.DebtIssued,
 		input.MaxInterestRate,
 		input.ClosesAt,
@@ -3,11 +3,17 @@ package campaign
 import (
 	"context"
 
-	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
 )
 
-type FindAllCampaignsOutputDTO []*FindCampaignOutputDTO
+// campaignSortFields are the columns campaigns can be sorted by, the first
+// being the default.
+var campaignSortFields = []string{"id", "debt_issued", "max_interest_rate", "created_at", "closes_at", "maturity_at", "updated_at"}
+
+type FindAllCampaignsInputDTO = query.Query
+
+type FindAllCampaignsOutputDTO = query.Page[*FindCampaignSummaryOutputDTO]
 
 type FindAllCampaignsUseCase struct {
 	CampaignRepository repository.CampaignRepository
@@ -17,27 +23,18 @@ func NewFindAllCampaignsUseCase(CampaignRepository repository.CampaignRepository
 	return &FindAllCampaignsUseCase{CampaignRepository: CampaignRepository}
 }
 
-func (f *FindAllCampaignsUseCase) Execute(ctx context.Context) (*FindAllCampaignsOutputDTO, error) {
-	res, err := f.CampaignRepository.FindAllCampaigns(ctx)
+func (f *FindAllCampaignsUseCase) Execute(ctx context.Context, input *FindAllCampaignsInputDTO) (*FindAllCampaignsOutputDTO, error) {
+	q, err := input.Normalize(campaignSortFields...)
+	if err != nil {
+		return nil, err
+	}
+	res, total, err := f.CampaignRepository.FindAllCampaigns(ctx, q)
 	if err != nil {
 		return nil, err
 	}
-	output := make(FindAllCampaignsOutputDTO, len(res))
+	output := make([]*FindCampaignSummaryOutputDTO, len(res))
 	for i, Campaign := range res {
-		orders := make([]*entity.Order, len(Campaign.Orders))
-		for j, order := range Campaign.Orders {
-			orders[j] = &entity.Order{
-				Id:           order.Id,
-				CampaignId:   order.CampaignId,
-				Investor:     order.Investor,
-				Amount:       order.Amount,
-				InterestRate: order.InterestRate,
-				State:        order.State,
-				CreatedAt:    order.CreatedAt,
-				UpdatedAt:    order.UpdatedAt,
-			}
-		}
-		output[i] = &FindCampaignOutputDTO{
+		output[i] = &FindCampaignSummaryOutputDTO{
 			Id:                Campaign.Id,
 			Token:             Campaign.Token,
 			Debtor:            Campaign.Debtor,
@@ -48,12 +45,11 @@ func (f *FindAllCampaignsUseCase) Execute(ctx context.Context) (*FindAllCampaign
 			TotalObligation:   Campaign.TotalObligation,
 			TotalRaised:       Campaign.TotalRaised,
 			State:             string(Campaign.State),
-			Orders:            orders,
 			CreatedAt:         Campaign.CreatedAt,
 			ClosesAt:          Campaign.ClosesAt,
 			MaturityAt:        Campaign.MaturityAt,
 			UpdatedAt:         Campaign.UpdatedAt,
 		}
 	}
-	return &output, nil
+	return query.NewPage(output, total, q, func(c *FindCampaignSummaryOutputDTO) uint { return c.Id }), nil
 }
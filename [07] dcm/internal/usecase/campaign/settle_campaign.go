@@ -7,7 +7,6 @@ import (
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
 	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
-	"github.com/holiman/uint256"
 	"github.com/rollmelette/rollmelette"
 )
 
@@ -20,11 +19,11 @@ type SettleCampaignOutputDTO struct {
 	Token             Address         `json:"token"`
 	Debtor            Address         `json:"debtor"`
 	CollateralAddress Address         `json:"collateral_address"`
-	CollateralAmount  *uint256.Int    `json:"collateral_amount"`
-	DebtIssued        *uint256.Int    `json:"debt_issued"`
-	MaxInterestRate   *uint256.Int    `json:"max_interest_rate"`
-	TotalObligation   *uint256.Int    `json:"total_obligation"`
-	TotalRaised       *uint256.Int    `json:"total_raised"`
+	CollateralAmount  *Uint256        `json:"collateral_amount"`
+	DebtIssued        *Uint256        `json:"debt_issued"`
+	MaxInterestRate   *Uint256        `json:"max_interest_rate"`
+	TotalObligation   *Uint256        `json:"total_obligation"`
+	TotalRaised       *Uint256        `json:"total_raised"`
 	State             string          `json:"state"`
 	Orders            []*entity.Order `json:"orders"`
 	CreatedAt         int64           `json:"created_at"`
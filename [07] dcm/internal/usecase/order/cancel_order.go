@@ -7,7 +7,6 @@ import (
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/domain/entity"
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
 	. "github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
-	"github.com/holiman/uint256"
 	"github.com/rollmelette/rollmelette"
 )
 
@@ -20,8 +19,8 @@ type CancelOrderOutputDTO struct {
 	CampaignId   uint
 	Token        Address
 	Investor     Address
-	Amount       *uint256.Int
-	InterestRate *uint256.Int
+	Amount       *Uint256
+	InterestRate *Uint256
 	State        string
 	CreatedAt    int64
 	UpdatedAt    int64
@@ -12,18 +12,18 @@ import (
 )
 
 type CreateOrderInputDTO struct {
-	CampaignId   uint         `json:"campaign_id" validate:"required"`
-	InterestRate *uint256.Int `json:"interest_rate" validate:"required"`
+	CampaignId   uint     `json:"campaign_id" validate:"required"`
+	InterestRate *Uint256 `json:"interest_rate" validate:"required,uint256_gt0"`
 }
 
 type CreateOrderOutputDTO struct {
-	Id           uint         `json:"id"`
-	CampaignId   uint         `json:"campaign_id"`
-	Investor     Address      `json:"investor"`
-	Amount       *uint256.Int `json:"amount"`
-	InterestRate *uint256.Int `json:"interest_rate"`
-	State        string       `json:"state"`
-	CreatedAt    int64        `json:"created_at"`
+	Id           uint     `json:"id"`
+	CampaignId   uint     `json:"campaign_id"`
+	Investor     Address  `json:"investor"`
+	Amount       *Uint256 `json:"amount"`
+	InterestRate *Uint256 `json:"interest_rate"`
+	State        string   `json:"state"`
+	CreatedAt    int64    `json:"created_at"`
 }
 
 type CreateOrderUseCase struct {
@@ -57,14 +57,14 @@ func (c *CreateOrderUseCase) Execute(ctx context.Context, input *CreateOrderInpu
 		return nil, fmt.Errorf("invalid contract address provided for order creation: %v", erc20Deposit.Token)
 	}
 
-	if input.InterestRate.Gt(campaign.MaxInterestRate) {
+	if input.InterestRate.Gt(&campaign.MaxInterestRate.Int) {
 		return nil, fmt.Errorf("order interest rate exceeds active Campaign max interest rate")
 	}
 
 	order, err := entity.NewOrder(
 		campaign.Id,
 		Address(erc20Deposit.Sender),
-		uint256.MustFromBig(erc20Deposit.Value),
+		NewUint256(uint256.MustFromBig(erc20Deposit.Value)),
 		input.InterestRate,
 		metadata.BlockTimestamp,
 	)
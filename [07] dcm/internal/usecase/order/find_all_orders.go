@@ -4,9 +4,16 @@ import (
 	"context"
 
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
 )
 
-type FindAllOrdersOutputDTO []*FindOrderOutputDTO
+// orderSortFields are the columns orders can be sorted by, the first being
+// the default.
+var orderSortFields = []string{"id", "campaign_id", "amount", "interest_rate", "created_at", "updated_at"}
+
+type FindAllOrdersInputDTO = query.Query
+
+type FindAllOrdersOutputDTO = query.Page[*FindOrderOutputDTO]
 
 type FindAllOrdersUseCase struct {
 	OrderRepository repository.OrderRepository
@@ -18,12 +25,16 @@ func NewFindAllOrdersUseCase(orderRepository repository.OrderRepository) *FindAl
 	}
 }
 
-func (f *FindAllOrdersUseCase) Execute(ctx context.Context) (*FindAllOrdersOutputDTO, error) {
-	res, err := f.OrderRepository.FindAllOrders(ctx)
+func (f *FindAllOrdersUseCase) Execute(ctx context.Context, input *FindAllOrdersInputDTO) (*FindAllOrdersOutputDTO, error) {
+	q, err := input.Normalize(orderSortFields...)
+	if err != nil {
+		return nil, err
+	}
+	res, total, err := f.OrderRepository.FindAllOrders(ctx, q)
 	if err != nil {
 		return nil, err
 	}
-	output := make(FindAllOrdersOutputDTO, len(res))
+	output := make([]*FindOrderOutputDTO, len(res))
 	for i, order := range res {
 		output[i] = &FindOrderOutputDTO{
 			Id:           order.Id,
@@ -36,5 +47,5 @@ func (f *FindAllOrdersUseCase) Execute(ctx context.Context) (*FindAllOrdersOutpu
 			UpdatedAt:    order.UpdatedAt,
 		}
 	}
-	return &output, nil
+	return query.NewPage(output, total, q, func(o *FindOrderOutputDTO) uint { return o.Id }), nil
 }
@@ -4,9 +4,16 @@ import (
 	"context"
 
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/query"
 )
 
-type FindAllUsersOutputDTO []*FindUserOutputDTO
+// userSortFields are the columns users can be sorted by, the first being the
+// default. Users are filtered by role instead of state.
+var userSortFields = []string{"id", "created_at", "updated_at"}
+
+type FindAllUsersInputDTO = query.Query
+
+type FindAllUsersOutputDTO = query.Page[*FindUserOutputDTO]
 
 type FindAllUsersUseCase struct {
 	UserRepository repository.UserRepository
@@ -18,20 +25,24 @@ func NewFindAllUsersUseCase(userRepository repository.UserRepository) *FindAllUs
 	}
 }
 
-func (u *FindAllUsersUseCase) Execute(ctx context.Context) (*FindAllUsersOutputDTO, error) {
-	res, err := u.UserRepository.FindAllUsers(ctx)
+func (u *FindAllUsersUseCase) Execute(ctx context.Context, input *FindAllUsersInputDTO) (*FindAllUsersOutputDTO, error) {
+	q, err := input.Normalize(userSortFields...)
+	if err != nil {
+		return nil, err
+	}
+	res, total, err := u.UserRepository.FindAllUsers(ctx, q)
 	if err != nil {
 		return nil, err
 	}
-	output := make(FindAllUsersOutputDTO, len(res))
+	output := make([]*FindUserOutputDTO, len(res))
 	for i, user := range res {
 		output[i] = &FindUserOutputDTO{
-			Id:             user.Id,
-			Role:           string(user.Role),
-			Address:        user.Address,
-			CreatedAt:      user.CreatedAt,
-			UpdatedAt:      user.UpdatedAt,
+			Id:        user.Id,
+			Role:      string(user.Role),
+			Address:   user.Address,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
 		}
 	}
-	return &output, nil
+	return query.NewPage(output, total, q, func(u *FindUserOutputDTO) uint { return u.Id }), nil
 }
@@ -0,0 +1,107 @@
+package custom_type
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/holiman/uint256"
+)
+
+// Uint256Digits is the width of a stored Uint256: the number of decimal digits
+// of the largest uint256.
+const Uint256Digits = 78
+
+// Uint256 is a uint256.Int stored as fixed-width, zero-padded decimal text, so
+// SQL ordering and range filters on it are numeric. It is encoded in JSON as a
+// decimal string, like uint256.Int.
+type Uint256 struct {
+	uint256.Int
+}
+
+func NewUint256(x *uint256.Int) *Uint256 {
+	u := new(Uint256)
+	u.Set(x)
+	return u
+}
+
+func Uint256FromUint64(x uint64) *Uint256 {
+	u := new(Uint256)
+	u.SetUint64(x)
+	return u
+}
+
+// Uint256FromDecimal parses a decimal number, ignoring leading zeros.
+func Uint256FromDecimal(s string) (*Uint256, error) {
+	u := new(Uint256)
+	if err := u.setPadded(s); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// PadUint256 returns the stored form of the decimal number s, for comparing a
+// Uint256 column against a literal in a query.
+func PadUint256(s string) string {
+	if len(s) >= Uint256Digits {
+		return s
+	}
+	return strings.Repeat("0", Uint256Digits-len(s)) + s
+}
+
+func (u *Uint256) setPadded(s string) error {
+	trimmed := strings.TrimLeft(s, "0")
+	if trimmed == "" {
+		u.Clear()
+		return nil
+	}
+	if err := u.SetFromDecimal(trimmed); err != nil {
+		return fmt.Errorf("invalid uint256 %q: %w", s, err)
+	}
+	return nil
+}
+
+func (u *Uint256) Scan(value any) error {
+	switch v := value.(type) {
+	case string:
+		return u.setPadded(v)
+	case []byte:
+		return u.setPadded(string(v))
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("invalid uint256: %d", v)
+		}
+		u.SetUint64(uint64(v))
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for uint256 scan: %T", value)
+	}
+}
+
+func (u Uint256) Value() (driver.Value, error) {
+	return PadUint256(u.Dec()), nil
+}
+
+// MarshalJSON serializes the Uint256 into a JSON decimal string.
+func (u Uint256) MarshalJSON() ([]byte, error) {
+	return u.Int.MarshalJSON()
+}
+
+// UnmarshalJSON deserializes a JSON decimal or hex string, or a JSON number,
+// into the Uint256.
+func (u *Uint256) UnmarshalJSON(data []byte) error {
+	if err := u.Int.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("failed to unmarshal Uint256: %w", err)
+	}
+	return nil
+}
+
+// RegisterUint256Validations adds the uint256_gt0 tag, which rejects zero
+// Uint256 values, to v.
+func RegisterUint256Validations(v *validator.Validate) error {
+	return v.RegisterValidation("uint256_gt0", func(fl validator.FieldLevel) bool {
+		u, ok := fl.Field().Interface().(Uint256)
+		return ok && !u.IsZero()
+	})
+}
@@ -0,0 +1,96 @@
+package custom_type
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestUint256Suite(t *testing.T) {
+	suite.Run(t, new(Uint256Suite))
+}
+
+type Uint256Suite struct {
+	suite.Suite
+}
+
+func (s *Uint256Suite) TestValuePadsToFixedWidth() {
+	value, err := Uint256FromUint64(42).Value()
+	s.Require().NoError(err)
+	s.Equal(strings.Repeat("0", Uint256Digits-2)+"42", value)
+
+	max := NewUint256(new(uint256.Int).SetAllOne())
+	value, err = max.Value()
+	s.Require().NoError(err)
+	s.Len(value, Uint256Digits)
+}
+
+func (s *Uint256Suite) TestScanAcceptsPaddedAndLegacyValues() {
+	for _, stored := range []any{PadUint256("1000"), "1000", []byte(PadUint256("1000")), int64(1000)} {
+		var u Uint256
+		s.Require().NoError(u.Scan(stored), "%v", stored)
+		s.Equal(uint64(1000), u.Uint64())
+	}
+
+	var zero Uint256
+	s.Require().NoError(zero.Scan(PadUint256("0")))
+	s.True(zero.IsZero())
+
+	var u Uint256
+	s.Error(u.Scan("12a"))
+	s.Error(u.Scan(int64(-1)))
+	s.Error(u.Scan(1.5))
+}
+
+func (s *Uint256Suite) TestJSONIsDecimalString() {
+	data, err := json.Marshal(struct {
+		Amount *Uint256 `json:"amount"`
+	}{Uint256FromUint64(123)})
+	s.Require().NoError(err)
+	s.JSONEq(`{"amount":"123"}`, string(data))
+
+	var input struct {
+		Amount *Uint256 `json:"amount"`
+	}
+	s.Require().NoError(json.Unmarshal([]byte(`{"amount":"0x10"}`), &input))
+	s.Equal(uint64(16), input.Amount.Uint64())
+	s.Error(json.Unmarshal([]byte(`{"amount":"-1"}`), &input))
+}
+
+func (s *Uint256Suite) TestGreaterThanZeroValidation() {
+	v := validator.New()
+	s.Require().NoError(RegisterUint256Validations(v))
+
+	type input struct {
+		Amount *Uint256 `validate:"required,uint256_gt0"`
+	}
+	s.NoError(v.Struct(input{Amount: Uint256FromUint64(1)}))
+	s.Error(v.Struct(input{Amount: new(Uint256)}))
+	s.Error(v.Struct(input{}))
+}
+
+func (s *Uint256Suite) TestColumnOrdersNumerically() {
+	type item struct {
+		Id     uint
+		Amount *Uint256 `gorm:"type:text;not null"`
+	}
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	s.Require().NoError(err)
+	s.Require().NoError(db.AutoMigrate(&item{}))
+	for _, amount := range []uint64{9, 100000, 10, 2} {
+		s.Require().NoError(db.Create(&item{Amount: Uint256FromUint64(amount)}).Error)
+	}
+
+	var items []item
+	s.Require().NoError(db.Where("amount > ?", PadUint256("9")).Order("amount").Find(&items).Error)
+	s.Require().Len(items, 2)
+	s.Equal(uint64(10), items[0].Amount.Uint64())
+	s.Equal(uint64(100000), items[1].Amount.Uint64())
+}
@@ -0,0 +1,111 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type abiRoute struct {
+	path   string
+	method abi.Method
+}
+
+// HandleAdvanceABI lets ABI-encoded calldata reach the advance route at path.
+// signature names every argument after the json tag of the matching DTO field,
+// e.g. "createOrder(uint256 campaign_id,uint256 interest_rate)". Inputs whose
+// payload is not JSON are dispatched on their 4-byte selector.
+func (r *Router) HandleAdvanceABI(signature string, path string) {
+	method, err := ParseMethod(signature)
+	if err != nil {
+		panic(fmt.Sprintf("router: %v", err))
+	}
+	if r.abiRoutes == nil {
+		r.abiRoutes = make(map[[4]byte]abiRoute)
+	}
+	r.abiRoutes[[4]byte(method.ID)] = abiRoute{
+		path:   strings.Trim(path, "/"),
+		method: method,
+	}
+}
+
+func (g *Group) HandleAdvanceABI(signature string, path string) {
+	g.router.HandleAdvanceABI(signature, g.fullPath(path))
+}
+
+// ParseMethod parses a "name(type arg,...)" signature. Tuples are not supported.
+func ParseMethod(signature string) (abi.Method, error) {
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return abi.Method{}, fmt.Errorf("invalid method signature: %s", signature)
+	}
+	name := strings.TrimSpace(signature[:open])
+
+	var inputs abi.Arguments
+	if params := strings.TrimSpace(signature[open+1 : len(signature)-1]); params != "" {
+		for _, param := range strings.Split(params, ",") {
+			fields := strings.Fields(param)
+			if len(fields) < 2 {
+				return abi.Method{}, fmt.Errorf("unnamed argument %q in method signature: %s", param, signature)
+			}
+			typ, err := abi.NewType(fields[0], "", nil)
+			if err != nil {
+				return abi.Method{}, fmt.Errorf("invalid argument %q in method signature %s: %w", param, signature, err)
+			}
+			inputs = append(inputs, abi.Argument{Name: fields[len(fields)-1], Type: typ})
+		}
+	}
+	return abi.NewMethod(name, name, abi.Function, "", false, false, inputs, nil), nil
+}
+
+func (r *Router) parseAdvanceRequest(payload []byte) (*Request, error) {
+	if len(r.abiRoutes) == 0 || json.Valid(payload) {
+		return parseRequestRawPayload(payload)
+	}
+	if len(payload) < 4 {
+		return nil, fmt.Errorf("%w: payload is neither JSON nor ABI-encoded", ErrInvalidRequest)
+	}
+
+	route, ok := r.abiRoutes[[4]byte(payload[:4])]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown function selector %s", ErrInvalidRequest, hexutil.Encode(payload[:4]))
+	}
+	args := make(map[string]any)
+	if err := route.method.Inputs.UnpackIntoMap(args, payload[4:]); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode %s arguments: %w", ErrInvalidRequest, route.method.Name, err)
+	}
+	for name, value := range args {
+		args[name] = abiValueToJSON(reflect.ValueOf(value))
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode %s arguments: %w", ErrInvalidRequest, route.method.Name, err)
+	}
+	return &Request{Path: route.path, Data: data}, nil
+}
+
+// abiValueToJSON makes decoded values marshal the way the DTOs expect: integers
+// as JSON numbers, addresses as hex strings and byte strings as 0x-prefixed hex.
+func abiValueToJSON(v reflect.Value) any {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return v.Interface()
+	}
+	if v.Type().Elem().Kind() == reflect.Uint8 {
+		if v.Type().Implements(textMarshalerType) {
+			return v.Interface()
+		}
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return hexutil.Bytes(b)
+	}
+	items := make([]any, v.Len())
+	for i := range items {
+		items[i] = abiValueToJSON(v.Index(i))
+	}
+	return items
+}
@@ -0,0 +1,74 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Bind decodes the request data into v and then overrides every field whose json
+// tag matches a path parameter, so "campaign/:id" and {"id":1} yield the same DTO.
+func Bind(ctx context.Context, payload []byte, v any) error {
+	if len(payload) > 0 && string(payload) != "null" {
+		if err := json.Unmarshal(payload, v); err != nil {
+			return err
+		}
+	}
+
+	params := ParamsFromContext(ctx)
+	if len(params) == 0 {
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a pointer to struct, got %T", v)
+	}
+	rt := rv.Elem().Type()
+
+	overlay := make(map[string]json.RawMessage)
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		value, ok := params[name]
+		if !ok {
+			continue
+		}
+		raw := paramToJSON(field.Type, value)
+		if !json.Valid(raw) {
+			return fmt.Errorf("invalid path parameter %s: %q", name, value)
+		}
+		overlay[name] = raw
+	}
+	if len(overlay) == 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(overlay)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("invalid path parameter: %w", err)
+	}
+	return nil
+}
+
+func paramToJSON(t reflect.Type, value string) json.RawMessage {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Bool:
+		return json.RawMessage(value)
+	}
+	quoted, _ := json.Marshal(value)
+	return quoted
+}
@@ -1,16 +1,27 @@
 package router
 
 import (
+	"context"
+	"reflect"
+	"slices"
 	"strings"
+
+	"github.com/rollmelette/rollmelette"
 )
 
 type Group struct {
 	router     *Router
+	parent     *Group
 	prefix     string
 	middleware []Middleware
 }
 
+// Use appends group middleware. Like the router-level middleware it is applied
+// when a request is dispatched, so it also covers handlers registered before the call.
 func (g *Group) Use(middleware ...Middleware) {
+	for _, m := range middleware {
+		m.check()
+	}
 	g.middleware = append(g.middleware, middleware...)
 }
 
@@ -24,44 +35,65 @@ func (g *Group) Group(prefix string) *Group {
 
 	return &Group{
 		router: g.router,
+		parent: g,
 		prefix: fullPrefix,
 	}
 }
 
-func (g *Group) registerHandler(path string, wrap func(handler any) any, register func(fullPath string, handler any), handler any) {
+// chain returns the middleware of every enclosing group, outermost first.
+func (g *Group) chain() []Middleware {
+	if g.parent == nil {
+		return g.middleware
+	}
+	return slices.Concat(g.parent.chain(), g.middleware)
+}
+
+// wrapAdvance resolves the group chain each time handler runs, so a Use after
+// registration still applies.
+func (g *Group) wrapAdvance(handler AdvanceHandlerFunc) AdvanceHandlerFunc {
+	return func(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
+		next := handler
+		chain := g.chain()
+		for i := len(chain) - 1; i >= 0; i-- {
+			next = chain[i].wrapAdvance(next)
+		}
+		return next(ctx, env, metadata, deposit, payload)
+	}
+}
+
+func (g *Group) wrapInspect(handler InspectHandlerFunc) InspectHandlerFunc {
+	return func(ctx context.Context, env rollmelette.EnvInspector, payload []byte) error {
+		next := handler
+		chain := g.chain()
+		for i := len(chain) - 1; i >= 0; i-- {
+			next = chain[i].wrapInspect(next)
+		}
+		return next(ctx, env, payload)
+	}
+}
+
+func (g *Group) fullPath(path string) string {
 	var fullPath string
 	if path == "" {
 		fullPath = g.prefix
 	} else {
 		fullPath = g.prefix + "/" + strings.Trim(path, "/")
 	}
-	fullPath = strings.Trim(fullPath, "/")
+	return strings.Trim(fullPath, "/")
+}
 
-	h := handler
-	for i := len(g.middleware) - 1; i >= 0; i-- {
-		h = wrap(g.middleware[i](h))
-	}
-	register(fullPath, h)
+func (g *Group) HandleAdvance(path string, handler AdvanceHandlerFunc, middleware ...Middleware) {
+	g.handleAdvance(path, handler, nil, middleware)
+}
+
+func (g *Group) HandleInspect(path string, handler InspectHandlerFunc, middleware ...Middleware) {
+	g.handleInspect(path, handler, nil, middleware)
 }
 
-func (g *Group) HandleAdvance(path string, handler AdvanceHandlerFunc) {
-	g.registerHandler(
-		path,
-		func(h any) any { return h.(AdvanceHandlerFunc) },
-		func(fullPath string, h any) {
-			g.router.HandleAdvance(fullPath, h.(AdvanceHandlerFunc))
-		},
-		handler,
-	)
+func (g *Group) handleAdvance(path string, handler AdvanceHandlerFunc, input reflect.Type, middleware []Middleware) {
+	g.router.addAdvance(g, g.fullPath(path), handler, input, middleware)
 }
 
-func (g *Group) HandleInspect(path string, handler InspectHandlerFunc) {
-	g.registerHandler(
-		path,
-		func(h any) any { return h.(InspectHandlerFunc) },
-		func(fullPath string, h any) {
-			g.router.HandleInspect(fullPath, h.(InspectHandlerFunc))
-		},
-		handler,
-	)
+func (g *Group) handleInspect(path string, handler InspectHandlerFunc, input reflect.Type, middleware []Middleware) {
+	g.router.addInspect(g, g.fullPath(path), handler, input, middleware)
 }
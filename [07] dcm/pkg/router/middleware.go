@@ -1,6 +1,7 @@
 package router
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -8,48 +9,103 @@ import (
 	"github.com/rollmelette/rollmelette"
 )
 
-type Middleware func(any) any
+type AdvanceMiddleware func(next AdvanceHandlerFunc) AdvanceHandlerFunc
 
-func LoggingMiddleware(handler any) any {
-	switch h := handler.(type) {
-	case AdvanceHandlerFunc:
-		return AdvanceHandlerFunc(func(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
+type InspectMiddleware func(next InspectHandlerFunc) InspectHandlerFunc
+
+// Middleware pairs the advance and inspect halves of a middleware. A nil half
+// leaves handlers of that kind untouched.
+type Middleware struct {
+	Name    string
+	Advance AdvanceMiddleware
+	Inspect InspectMiddleware
+}
+
+func (m Middleware) wrapAdvance(handler AdvanceHandlerFunc) AdvanceHandlerFunc {
+	if m.Advance == nil {
+		return handler
+	}
+	return m.Advance(handler)
+}
+
+func (m Middleware) wrapInspect(handler InspectHandlerFunc) InspectHandlerFunc {
+	if m.Inspect == nil {
+		return handler
+	}
+	return m.Inspect(handler)
+}
+
+// check wraps placeholder handlers, so that a middleware whose shape is wrong
+// panics when it is installed rather than when a request is dispatched.
+func (m Middleware) check() {
+	m.wrapAdvance(func(context.Context, rollmelette.Env, rollmelette.Metadata, rollmelette.Deposit, []byte) error {
+		return nil
+	})
+	m.wrapInspect(func(context.Context, rollmelette.EnvInspector, []byte) error { return nil })
+}
+
+// Adapt turns an untyped func(any) any middleware into a Middleware. The result
+// of fn is checked when the middleware is installed: by Router.Use, or when a
+// handler is registered for group and per-route middleware.
+func Adapt(name string, fn func(handler any) any) Middleware {
+	return Middleware{
+		Name: name,
+		Advance: func(next AdvanceHandlerFunc) AdvanceHandlerFunc {
+			wrapped, ok := fn(next).(AdvanceHandlerFunc)
+			if !ok {
+				panic(fmt.Sprintf("router: middleware %s did not return an AdvanceHandlerFunc", name))
+			}
+			return wrapped
+		},
+		Inspect: func(next InspectHandlerFunc) InspectHandlerFunc {
+			wrapped, ok := fn(next).(InspectHandlerFunc)
+			if !ok {
+				panic(fmt.Sprintf("router: middleware %s did not return an InspectHandlerFunc", name))
+			}
+			return wrapped
+		},
+	}
+}
+
+var LoggingMiddleware = Middleware{
+	Name: "logging",
+	Advance: func(next AdvanceHandlerFunc) AdvanceHandlerFunc {
+		return func(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
 			res, err := json.Marshal(metadata)
 			if err != nil {
 				return fmt.Errorf("failed to marshal metadata: %w", err)
 			}
 			log.Printf("Advance request - metadata: %s", string(res))
-			return h(env, metadata, deposit, payload)
-		})
-	case InspectHandlerFunc:
-		return InspectHandlerFunc(func(env rollmelette.EnvInspector, payload []byte) error {
+			return next(ctx, env, metadata, deposit, payload)
+		}
+	},
+	Inspect: func(next InspectHandlerFunc) InspectHandlerFunc {
+		return func(ctx context.Context, env rollmelette.EnvInspector, payload []byte) error {
 			log.Printf("Inspect request - payload: %s", string(payload))
-			return h(env, payload)
-		})
-	default:
-		return handler
-	}
+			return next(ctx, env, payload)
+		}
+	},
 }
 
-func ErrorHandlingMiddleware(handler any) any {
-	switch h := handler.(type) {
-	case AdvanceHandlerFunc:
-		return AdvanceHandlerFunc(func(env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
-			err := h(env, metadata, deposit, payload)
+// ErrorHandlingMiddleware reports failed requests as a JSON ErrorReport.
+var ErrorHandlingMiddleware = Middleware{
+	Name: "error-handling",
+	Advance: func(next AdvanceHandlerFunc) AdvanceHandlerFunc {
+		return func(ctx context.Context, env rollmelette.Env, metadata rollmelette.Metadata, deposit rollmelette.Deposit, payload []byte) error {
+			err := next(ctx, env, metadata, deposit, payload)
 			if err != nil {
-				env.Report([]byte(fmt.Sprintf("Error: %v", err)))
+				env.Report(NewErrorReport(ctx, err, &metadata.Index).Bytes())
 			}
 			return err
-		})
-	case InspectHandlerFunc:
-		return InspectHandlerFunc(func(env rollmelette.EnvInspector, payload []byte) error {
-			err := h(env, payload)
+		}
+	},
+	Inspect: func(next InspectHandlerFunc) InspectHandlerFunc {
+		return func(ctx context.Context, env rollmelette.EnvInspector, payload []byte) error {
+			err := next(ctx, env, payload)
 			if err != nil {
-				env.Report([]byte(fmt.Sprintf("Error: %v", err)))
+				env.Report(NewErrorReport(ctx, err, nil).Bytes())
 			}
 			return err
-		})
-	default:
-		return handler
-	}
+		}
+	},
 }
@@ -0,0 +1,58 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rollmelette/rollmelette"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewareSuite))
+}
+
+type MiddlewareSuite struct {
+	suite.Suite
+}
+
+func (s *MiddlewareSuite) TestUseChecksAdaptedMiddleware() {
+	passThrough := Adapt("pass-through", func(handler any) any { return handler })
+	s.NotPanics(func() { NewRouter().Use(passThrough) })
+
+	wrongShape := Adapt("wrong-shape", func(handler any) any { return "not a handler" })
+	s.PanicsWithValue("router: middleware wrong-shape did not return an AdvanceHandlerFunc", func() {
+		NewRouter().Use(LoggingMiddleware, wrongShape)
+	})
+}
+
+func (s *MiddlewareSuite) TestGroupUseAfterRegistration() {
+	var calls []string
+	record := func(name string) Middleware {
+		return Middleware{
+			Name: name,
+			Inspect: func(next InspectHandlerFunc) InspectHandlerFunc {
+				return func(ctx context.Context, env rollmelette.EnvInspector, payload []byte) error {
+					calls = append(calls, name)
+					return next(ctx, env, payload)
+				}
+			},
+		}
+	}
+
+	r := NewRouter()
+	api := r.Group("api")
+	users := api.Group("users")
+	users.HandleInspect("list", func(ctx context.Context, env rollmelette.EnvInspector, payload []byte) error {
+		calls = append(calls, "handler")
+		return nil
+	}, record("route"))
+	users.Use(record("users"))
+	api.Use(record("api"))
+
+	s.Require().NoError(r.Inspect(nil, []byte(`{"path":"api/users/list"}`)))
+	s.Equal([]string{"api", "users", "route", "handler"}, calls)
+
+	routes := r.Routes()
+	s.Equal([]string{"api", "users", "route"}, routes[len(routes)-1].Middleware)
+}
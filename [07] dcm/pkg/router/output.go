@@ -0,0 +1,159 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/rollmelette/rollmelette"
+)
+
+// OutputEncoder encodes the result of a handler as a notice payload. A nil
+// payload means the encoder has nothing to emit for that event.
+type OutputEncoder interface {
+	EncodeOutput(event string, v any) ([]byte, error)
+}
+
+// JSONOutputEncoder emits the "<event> - <json>" text notices.
+type JSONOutputEncoder struct{}
+
+func (JSONOutputEncoder) EncodeOutput(event string, v any) ([]byte, error) {
+	return NoticeEnvelope(event, v)
+}
+
+// ABIOutputEncoder emits notices an L1 contract can decode: the 4-byte selector
+// of the event signature followed by its ABI-encoded arguments. Events without a
+// registered signature are skipped. Reports are not encoded: contracts cannot
+// read them, so inspect results and error reports are always JSON.
+type ABIOutputEncoder struct {
+	events map[string]abi.Method
+}
+
+func NewABIOutputEncoder() *ABIOutputEncoder {
+	return &ABIOutputEncoder{
+		events: make(map[string]abi.Method),
+	}
+}
+
+// Register binds event to signature. As with HandleAdvanceABI, every argument
+// is named after the json tag of the result field it is read from, e.g.
+// "CampaignClosed(uint256 id,uint256 total_raised)".
+func (e *ABIOutputEncoder) Register(event string, signature string) {
+	method, err := ParseMethod(signature)
+	if err != nil {
+		panic(fmt.Sprintf("router: %v", err))
+	}
+	e.events[event] = method
+}
+
+func (e *ABIOutputEncoder) EncodeOutput(event string, v any) ([]byte, error) {
+	method, ok := e.events[event]
+	if !ok {
+		return nil, nil
+	}
+
+	values := make([]any, len(method.Inputs))
+	for i, arg := range method.Inputs {
+		value, err := abiArgument(v, arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s notice: %w", method.Name, err)
+		}
+		values[i] = value
+	}
+	args, err := method.Inputs.Pack(values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s notice: %w", method.Name, err)
+	}
+	return append(append([]byte{}, method.ID...), args...), nil
+}
+
+// abiArgument reads the field of v tagged arg.Name and converts it to the Go
+// type the ABI packer expects for arg.
+func abiArgument(v any, arg abi.Argument) (any, error) {
+	field, ok := fieldByJSONName(reflect.ValueOf(v), arg.Name)
+	if !ok {
+		return nil, fmt.Errorf("no field for argument %s", arg.Name)
+	}
+	target := arg.Type.GetType()
+
+	for field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return reflect.Zero(target).Interface(), nil
+		}
+		if toBig, ok := field.Interface().(interface{ ToBig() *big.Int }); ok && target == reflect.TypeFor[*big.Int]() {
+			return toBig.ToBig(), nil
+		}
+		field = field.Elem()
+	}
+
+	if target == reflect.TypeFor[*big.Int]() {
+		switch {
+		case field.CanInt():
+			return big.NewInt(field.Int()), nil
+		case field.CanUint():
+			return new(big.Int).SetUint64(field.Uint()), nil
+		}
+	}
+	if field.Type().ConvertibleTo(target) {
+		return field.Convert(target).Interface(), nil
+	}
+	return nil, fmt.Errorf("cannot encode %s as %s", field.Type(), arg.Type)
+}
+
+func fieldByJSONName(v reflect.Value, name string) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == name || (tag == "" && field.Name == name) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// SetOutputEncoders replaces the encoders used by Emit, which default to
+// JSONOutputEncoder. Every encoder may contribute one notice per event.
+func (r *Router) SetOutputEncoders(encoders ...OutputEncoder) {
+	r.outputEncoders = encoders
+}
+
+type outputEncodersKey struct{}
+
+func withOutputEncoders(ctx context.Context, encoders []OutputEncoder) context.Context {
+	return context.WithValue(ctx, outputEncodersKey{}, encoders)
+}
+
+func outputEncodersFromContext(ctx context.Context) []OutputEncoder {
+	encoders, ok := ctx.Value(outputEncodersKey{}).([]OutputEncoder)
+	if !ok {
+		return []OutputEncoder{JSONOutputEncoder{}}
+	}
+	return encoders
+}
+
+// Emit sends v as a notice through every output encoder of the router that is
+// dispatching the request.
+func Emit(ctx context.Context, env rollmelette.Env, event string, v any) error {
+	for _, encoder := range outputEncodersFromContext(ctx) {
+		notice, err := encoder.EncodeOutput(event, v)
+		if err != nil {
+			return err
+		}
+		if notice != nil {
+			env.Notice(notice)
+		}
+	}
+	return nil
+}
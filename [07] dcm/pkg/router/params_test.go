@@ -0,0 +1,118 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestParamsSuite(t *testing.T) {
+	suite.Run(t, new(ParamsSuite))
+}
+
+type ParamsSuite struct {
+	suite.Suite
+}
+
+func routesFor(patterns ...string) (map[string]string, []route) {
+	handlers := make(map[string]string, len(patterns))
+	routes := make([]route, 0, len(patterns))
+	for _, pattern := range patterns {
+		handlers[pattern] = pattern
+		routes = append(routes, compileRoute(pattern))
+	}
+	return handlers, routes
+}
+
+func (s *ParamsSuite) TestCompileRoute() {
+	r := compileRoute("/voting/:id/options/*rest/")
+	s.Equal("voting/:id/options/*rest", r.pattern)
+	s.Equal([]segment{
+		{kind: staticSegment, value: "voting"},
+		{kind: paramSegment, value: "id"},
+		{kind: staticSegment, value: "options"},
+		{kind: wildcardSegment, value: "rest"},
+	}, r.segments)
+
+	// A bare ":" and a "*" before the last segment are plain text.
+	r = compileRoute("a/:/*/b")
+	s.True(r.isStatic())
+	s.Equal(segment{kind: wildcardSegment, value: "*"}, compileRoute("files/*").segments[1])
+}
+
+func (s *ParamsSuite) TestMatch() {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		params  Params
+		ok      bool
+	}{
+		{"param", "voting/:id", "voting/7", Params{"id": "7"}, true},
+		{"several params", "voting/:id/options/:option", "voting/7/options/2", Params{"id": "7", "option": "2"}, true},
+		{"surrounding slashes", "voting/:id", "/voting/7/", Params{"id": "7"}, true},
+		{"too short", "voting/:id", "voting", nil, false},
+		{"too long", "voting/:id", "voting/7/8", nil, false},
+		{"static mismatch", "voting/:id", "votes/7", nil, false},
+		{"empty param segment", "voting/:id/options", "voting//options", nil, false},
+		{"wildcard captures the rest", "files/*", "files/a/b/c", Params{"*": "a/b/c"}, true},
+		{"named wildcard", "files/*path", "files/a/b", Params{"path": "a/b"}, true},
+		{"wildcard keeps empty segments", "files/*", "files/a//b", Params{"*": "a//b"}, true},
+		{"wildcard matches nothing", "voting/*", "voting", Params{"*": ""}, true},
+		{"literal pattern text is a value", "voting/:id", "voting/:other", Params{"id": ":other"}, true},
+	}
+	for _, tt := range tests {
+		params, ok := compileRoute(tt.pattern).match(splitPath(tt.path))
+		s.Equal(tt.ok, ok, tt.name)
+		s.Equal(tt.params, params, tt.name)
+	}
+}
+
+func (s *ParamsSuite) TestMoreSpecific() {
+	static, param, wildcard := compileRoute("voting/active"), compileRoute("voting/:id"), compileRoute("voting/*")
+	s.True(static.moreSpecific(param))
+	s.True(param.moreSpecific(wildcard))
+	s.True(static.moreSpecific(wildcard))
+	s.False(wildcard.moreSpecific(param))
+	s.False(param.moreSpecific(static))
+
+	// The first differing segment decides, then the longer route wins.
+	s.True(compileRoute("voting/:id/options").moreSpecific(compileRoute("voting/:id/*")))
+	s.True(compileRoute(":a/static").moreSpecific(compileRoute(":a/:b")))
+	s.True(compileRoute("voting/:id/*").moreSpecific(compileRoute("voting/*")))
+}
+
+func (s *ParamsSuite) TestLookupPicksTheMostSpecificRoute() {
+	// Registered least specific first so that order cannot decide.
+	handlers, routes := routesFor("voting/*", "voting/:id", "voting/:id/options", "voting/active")
+
+	tests := []struct {
+		path    string
+		handler string
+		params  Params
+	}{
+		{"voting/active", "voting/active", Params{}},
+		{"voting/7", "voting/:id", Params{"id": "7"}},
+		{"voting/7/options", "voting/:id/options", Params{"id": "7"}},
+		{"voting/7/results", "voting/*", Params{"*": "7/results"}},
+		{"voting", "voting/*", Params{"*": ""}},
+	}
+	for _, tt := range tests {
+		for range 10 {
+			handler, params, ok := lookup(handlers, routes, tt.path)
+			s.Require().True(ok, tt.path)
+			s.Equal(tt.handler, handler, tt.path)
+			s.Equal(tt.params, params, tt.path)
+		}
+	}
+}
+
+func (s *ParamsSuite) TestLookupRejectsPatternText() {
+	handlers, routes := routesFor("campaign/:id", "files/*")
+	for _, path := range []string{"campaign/:id", "files/*", "unknown"} {
+		handler, params, ok := lookup(handlers, routes, path)
+		s.False(ok, path)
+		s.Empty(handler, path)
+		s.Nil(params, path)
+	}
+}
@@ -0,0 +1,99 @@
+package router
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestReportSuite(t *testing.T) {
+	suite.Run(t, new(ReportSuite))
+}
+
+type ReportSuite struct {
+	suite.Suite
+}
+
+func (s *ReportSuite) split(payload []byte, size int) [][]byte {
+	chunks, err := SplitReport(payload, size)
+	s.Require().NoError(err)
+	return chunks
+}
+
+func (s *ReportSuite) TestSplitReportFits() {
+	payload := []byte(`{"items":[]}`)
+	chunks := s.split(payload, minMaxReportSize)
+	s.Equal([][]byte{payload}, chunks)
+
+	reassembled, err := ReassembleReports(chunks)
+	s.Require().NoError(err)
+	s.Equal(payload, reassembled)
+}
+
+func (s *ReportSuite) TestSplitReportRoundTrip() {
+	for _, length := range []int{minMaxReportSize + 1, 1000, 4096, 10000} {
+		payload := bytes.Repeat([]byte("0123456789abcdef"), length/3)
+		chunks := s.split(payload, minMaxReportSize)
+		s.Greater(len(chunks), 1)
+		for _, chunk := range chunks {
+			s.LessOrEqual(len(chunk), minMaxReportSize)
+		}
+
+		// Chunks may be read back in any order.
+		chunks[0], chunks[len(chunks)-1] = chunks[len(chunks)-1], chunks[0]
+		reassembled, err := ReassembleReports(chunks)
+		s.Require().NoError(err)
+		s.Equal(payload, reassembled)
+	}
+}
+
+func (s *ReportSuite) TestSplitReportChunksPayloadsLookingLikeChunks() {
+	payload := []byte("chunk: a report that was never split")
+	chunks := s.split(payload, minMaxReportSize)
+	s.Require().Len(chunks, 1)
+	s.NotEqual(payload, chunks[0])
+
+	reassembled, err := ReassembleReports(chunks)
+	s.Require().NoError(err)
+	s.Equal(payload, reassembled)
+}
+
+func (s *ReportSuite) TestSplitReportRejectsSmallSizes() {
+	payload := bytes.Repeat([]byte("x"), 1000)
+	// 75 is the header of a single chunk: no room is left for the payload.
+	for _, size := range []int{-1, 0, 10, 75, minMaxReportSize - 1} {
+		chunks, err := SplitReport(payload, size)
+		s.ErrorIs(err, ErrReportSizeTooSmall, size)
+		s.Nil(chunks, size)
+	}
+	_, err := SplitReport([]byte("{}"), 10)
+	s.ErrorIs(err, ErrReportSizeTooSmall, "checked even when the payload fits")
+}
+
+func (s *ReportSuite) TestReassembleReportsErrors() {
+	payload := bytes.Repeat([]byte("x"), 1000)
+	chunks := s.split(payload, minMaxReportSize)
+	s.Require().Greater(len(chunks), 2)
+
+	_, err := ReassembleReports(chunks[1:])
+	s.ErrorIs(err, ErrIncompleteReport)
+
+	duplicated := append([][]byte{chunks[0]}, chunks[:len(chunks)-1]...)
+	_, err = ReassembleReports(duplicated)
+	s.ErrorIs(err, ErrInvalidChunk)
+
+	tampered := append([][]byte{}, chunks...)
+	tampered[1] = append([]byte{}, chunks[1]...)
+	tampered[1][len(tampered[1])-1] = 'y'
+	_, err = ReassembleReports(tampered)
+	s.ErrorIs(err, ErrInvalidChunk)
+
+	other := s.split(bytes.Repeat([]byte("z"), 1000), minMaxReportSize)
+	mixed := append([][]byte{other[0]}, chunks[1:]...)
+	_, err = ReassembleReports(mixed)
+	s.ErrorIs(err, ErrInvalidChunk)
+
+	_, _, err = ParseReportChunk([]byte("chunk:1/0:" + string(bytes.Repeat([]byte("0"), 64)) + "\n"))
+	s.ErrorIs(err, ErrInvalidChunk)
+}
@@ -0,0 +1,36 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRouterSuite(t *testing.T) {
+	suite.Run(t, new(RouterSuite))
+}
+
+type RouterSuite struct {
+	suite.Suite
+}
+
+func (s *RouterSuite) TestInvalidRequestReports() {
+	tests := []struct {
+		name    string
+		payload string
+		message string
+	}{
+		{"malformed", `{"path":"secret-value`, "invalid request format: unexpected end of JSON input"},
+		{"missing path", `{"data":"secret-value"}`, "invalid request: Key: 'Request.Path' Error:Field validation for 'Path' failed on the 'required' tag"},
+	}
+	for _, tt := range tests {
+		ctx, handler, _ := NewRouter().routeInspect([]byte(tt.payload))
+		err := handler(ctx, nil, nil)
+		s.ErrorIs(err, ErrInvalidRequest, tt.name)
+
+		report := NewErrorReport(ctx, err, nil)
+		s.Equal("invalid_request", report.Code, tt.name)
+		s.Equal(tt.message, report.Message, tt.name)
+		s.NotContains(string(report.Bytes()), "secret-value", tt.name)
+	}
+}
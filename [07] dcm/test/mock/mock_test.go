@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"math/big"
@@ -14,6 +15,9 @@ import (
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/cmd/root"
 
 	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/infra/repository/factory"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/internal/usecase/order"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/custom_type"
+	"github.com/henriquemarlon/cartesi-golang-series/dcm/pkg/router"
 	"github.com/rollmelette/rollmelette"
 	"github.com/stretchr/testify/suite"
 )
@@ -227,6 +231,56 @@ func (s *DCMSystemSuite) TestCloseCampaign() {
 	s.Equal(`"100000"`, string(erc20BalanceOutput.Reports[0].Payload))
 }
 
+func (s *DCMSystemSuite) TestCloseUnderfundedCampaign() {
+	admin := common.HexToAddress("0x976EA74026E726554dB657fA54763abd0C3a0aa9")
+	anyone := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	investor := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	debtor := common.HexToAddress("0x0000000000000000000000000000000000000007")
+	collateral := common.HexToAddress("0x0000000000000000000000000000000000000008")
+	token := common.HexToAddress("0x0000000000000000000000000000000000000009")
+
+	baseTime := time.Now().Unix()
+	closesAt := baseTime + 2
+	maturityAt := baseTime + 10
+
+	createUserInput := []byte(fmt.Sprintf(`{"path":"user/admin/create","data":{"address":"%s","role":"debtor"}}`, debtor))
+	createUserOutput := s.Tester.Advance(admin, createUserInput)
+	s.Require().NoError(createUserOutput.Err)
+
+	createUserInput = []byte(fmt.Sprintf(`{"path":"user/admin/create","data":{"address":"%s","role":"investor"}}`, investor))
+	createUserOutput = s.Tester.Advance(admin, createUserInput)
+	s.Require().NoError(createUserOutput.Err)
+
+	createCampaignInput := []byte(fmt.Sprintf(`{"path":"campaign/debtor/create","data":{"token":"%s", "max_interest_rate":"10", "debt_issued":"100000", "closes_at":%d,"maturity_at":%d}}`, token, closesAt, maturityAt))
+	createCampaignOutput := s.Tester.DepositERC20(collateral, debtor, big.NewInt(10000), createCampaignInput)
+	s.Require().NoError(createCampaignOutput.Err)
+
+	createOrderInput := []byte(`{"path": "order/create", "data": {"campaign_id":1,"interest_rate":"9"}}`)
+	createOrderOutput := s.Tester.DepositERC20(token, investor, big.NewInt(1000), createOrderInput)
+	s.Require().NoError(createOrderOutput.Err)
+
+	time.Sleep(time.Until(time.Unix(closesAt, 0)))
+
+	// Less than 2/3 of the debt was raised, so closing cancels the campaign
+	// and refunds its orders instead of rejecting the input.
+	closeCampaignInput := []byte(fmt.Sprintf(`{"path":"campaign/close", "data":{"debtor":"%s"}}`, debtor))
+	closeCampaignOutput := s.Tester.Advance(anyone, closeCampaignInput)
+	s.Require().NoError(closeCampaignOutput.Err)
+	s.Require().Len(closeCampaignOutput.Notices, 1)
+	s.True(strings.HasPrefix(string(closeCampaignOutput.Notices[0].Payload), "campaign canceled - "))
+	s.Contains(string(closeCampaignOutput.Notices[0].Payload), `"state":"canceled"`)
+
+	findCampaignOutput := s.Tester.Inspect([]byte(`{"path":"campaign/1"}`))
+	s.Require().NoError(findCampaignOutput.Err)
+	s.Contains(string(findCampaignOutput.Reports[0].Payload), `"state":"canceled"`)
+	s.Contains(string(findCampaignOutput.Reports[0].Payload), `"state":"rejected"`)
+
+	erc20BalanceInput := []byte(fmt.Sprintf(`{"path":"user/erc20-balance","data":{"address":"%s","token":"%s"}}`, investor.Hex(), token.Hex()))
+	erc20BalanceOutput := s.Tester.Inspect(erc20BalanceInput)
+	s.Require().Len(erc20BalanceOutput.Reports, 1)
+	s.Equal(`"1000"`, string(erc20BalanceOutput.Reports[0].Payload))
+}
+
 func (s *DCMSystemSuite) TestSettleCampaign() {
 	admin := common.HexToAddress("0x976EA74026E726554dB657fA54763abd0C3a0aa9")
 	anyone := common.HexToAddress("0x0000000000000000000000000000000000000001")
@@ -691,8 +745,102 @@ func (s *DCMSystemSuite) TestFindAllCampaigns() {
 	findAllCampaignsOutput := s.Tester.Inspect(findAllCampaignsInput)
 	s.Len(findAllCampaignsOutput.Reports, 1)
 
-	expectedFindAllCampaignsOutput := fmt.Sprintf(`[{"id":1,"token":"0x0000000000000000000000000000000000000009","debtor":"0x0000000000000000000000000000000000000007","collateral_address":"0x0000000000000000000000000000000000000008","collateral_amount":"10000","debt_issued":"100000","max_interest_rate":"10","total_obligation":"0","total_raised":"0","state":"ongoing","orders":[],"created_at":%d,"closes_at":%d,"maturity_at":%d,"updated_at":0}]`, baseTime, closesAt, maturityAt)
+	expectedFindAllCampaignsOutput := fmt.Sprintf(`{"items":[{"id":1,"token":"0x0000000000000000000000000000000000000009","debtor":"0x0000000000000000000000000000000000000007","collateral_address":"0x0000000000000000000000000000000000000008","collateral_amount":"10000","debt_issued":"100000","max_interest_rate":"10","total_obligation":"0","total_raised":"0","state":"ongoing","created_at":%d,"closes_at":%d,"maturity_at":%d,"updated_at":0}],"total":1,"limit":20,"offset":0}`, baseTime, closesAt, maturityAt)
 	s.Equal(expectedFindAllCampaignsOutput, string(findAllCampaignsOutput.Reports[0].Payload))
+
+	findClosedCampaignsOutput := s.Tester.Inspect([]byte(`{"path":"campaign","data":{"state":"closed"}}`))
+	s.Len(findClosedCampaignsOutput.Reports, 1)
+	s.Equal(`{"items":[],"total":0,"limit":20,"offset":0}`, string(findClosedCampaignsOutput.Reports[0].Payload))
+}
+
+func (s *DCMSystemSuite) TestFindAllUsersPaged() {
+	admin := common.HexToAddress("0x976EA74026E726554dB657fA54763abd0C3a0aa9")
+	for i := 1; i <= 3; i++ {
+		investor := common.BigToAddress(big.NewInt(int64(0x10 + i)))
+		createUserOutput := s.Tester.Advance(admin, []byte(fmt.Sprintf(`{"path":"user/admin/create","data":{"address":"%s","role":"investor"}}`, investor)))
+		s.Require().NoError(createUserOutput.Err)
+	}
+
+	type page struct {
+		Items []struct {
+			Id   uint   `json:"id"`
+			Role string `json:"role"`
+		} `json:"items"`
+		Total      int64 `json:"total"`
+		Limit      int   `json:"limit"`
+		Offset     int   `json:"offset"`
+		NextCursor uint  `json:"next_cursor"`
+	}
+	inspect := func(data string) page {
+		output := s.Tester.Inspect([]byte(fmt.Sprintf(`{"path":"user","data":%s}`, data)))
+		s.Require().Len(output.Reports, 1)
+		var p page
+		s.Require().NoError(json.Unmarshal(output.Reports[0].Payload, &p))
+		return p
+	}
+
+	first := inspect(`{"limit":2}`)
+	s.Equal(int64(4), first.Total)
+	s.Require().Len(first.Items, 2)
+	s.Equal(uint(1), first.Items[0].Id)
+	s.Equal(uint(2), first.NextCursor)
+
+	next := inspect(fmt.Sprintf(`{"limit":2,"cursor":%d}`, first.NextCursor))
+	s.Require().Len(next.Items, 2)
+	s.Equal(uint(3), next.Items[0].Id)
+
+	investors := inspect(`{"state":"investor","sort":"id","order":"desc","offset":1}`)
+	s.Equal(int64(3), investors.Total)
+	s.Equal(1, investors.Offset)
+	s.Require().Len(investors.Items, 2)
+	s.Equal(uint(3), investors.Items[0].Id)
+	s.Equal("investor", investors.Items[1].Role)
+
+	var report router.ErrorReport
+	invalidSortOutput := s.Tester.Inspect([]byte(`{"path":"user","data":{"sort":"address"}}`))
+	s.Require().Len(invalidSortOutput.Reports, 1)
+	s.Require().NoError(json.Unmarshal(invalidSortOutput.Reports[0].Payload, &report))
+	s.Equal("invalid_query", report.Code)
+
+	invalidLimitOutput := s.Tester.Inspect([]byte(`{"path":"user","data":{"limit":1000}}`))
+	s.Require().Len(invalidLimitOutput.Reports, 1)
+	s.Require().NoError(json.Unmarshal(invalidLimitOutput.Reports[0].Payload, &report))
+	s.Equal("invalid_input", report.Code)
+}
+
+func (s *DCMSystemSuite) TestChunkedReports() {
+	repo, err := factory.NewRepositoryFromConnectionString("sqlite://:memory:")
+	s.Require().NoError(err)
+	dapp := root.NewDCMSystem(repo)
+	dapp.SetMaxReportSize(256)
+	tester := rollmelette.NewTester(dapp)
+
+	admin := common.HexToAddress("0x976EA74026E726554dB657fA54763abd0C3a0aa9")
+	for i := 1; i <= 5; i++ {
+		investor := common.BigToAddress(big.NewInt(int64(0x10 + i)))
+		createUserOutput := tester.Advance(admin, []byte(fmt.Sprintf(`{"path":"user/admin/create","data":{"address":"%s","role":"investor"}}`, investor)))
+		s.Require().NoError(createUserOutput.Err)
+	}
+
+	findAllUsersOutput := tester.Inspect([]byte(`{"path":"user"}`))
+	s.Require().NoError(findAllUsersOutput.Err)
+	s.Greater(len(findAllUsersOutput.Reports), 1)
+
+	reports := make([][]byte, len(findAllUsersOutput.Reports))
+	for i, report := range findAllUsersOutput.Reports {
+		s.LessOrEqual(len(report.Payload), 256)
+		reports[i] = report.Payload
+	}
+	payload, err := router.ReassembleReports(reports)
+	s.Require().NoError(err)
+
+	var page struct {
+		Items []json.RawMessage `json:"items"`
+		Total int64             `json:"total"`
+	}
+	s.Require().NoError(json.Unmarshal(payload, &page))
+	s.Equal(int64(6), page.Total)
+	s.Len(page.Items, 6)
 }
 
 func (s *DCMSystemSuite) TestFindCampaignById() {
@@ -728,6 +876,65 @@ func (s *DCMSystemSuite) TestFindCampaignById() {
 
 	expectedFindCampaignByIdOutput := fmt.Sprintf(`{"id":1,"token":"0x0000000000000000000000000000000000000009","debtor":"0x0000000000000000000000000000000000000007","collateral_address":"0x0000000000000000000000000000000000000008","collateral_amount":"10000","debt_issued":"100000","max_interest_rate":"10","total_obligation":"0","total_raised":"0","state":"ongoing","orders":[],"created_at":%d,"closes_at":%d,"maturity_at":%d,"updated_at":0}`, baseTime, closesAt, maturityAt)
 	s.Equal(expectedFindCampaignByIdOutput, string(findCampaignByIdOutput.Reports[0].Payload))
+
+	// same lookup through the path parameter
+	findCampaignByPathOutput := s.Tester.Inspect([]byte(`{"path":"campaign/1"}`))
+	s.Len(findCampaignByPathOutput.Reports, 1)
+	s.Equal(expectedFindCampaignByIdOutput, string(findCampaignByPathOutput.Reports[0].Payload))
+
+	findCampaignOrdersOutput := s.Tester.Inspect([]byte(`{"path":"campaign/1/orders"}`))
+	s.Len(findCampaignOrdersOutput.Reports, 1)
+	s.Equal(`[]`, string(findCampaignOrdersOutput.Reports[0].Payload))
+
+	findCampaignsByDebtorOutput := s.Tester.Inspect([]byte(fmt.Sprintf(`{"path":"campaign/debtor/%s"}`, debtor)))
+	s.Len(findCampaignsByDebtorOutput.Reports, 1)
+	s.Equal("["+expectedFindCampaignByIdOutput+"]", string(findCampaignsByDebtorOutput.Reports[0].Payload))
+
+	invalidParamOutput := s.Tester.Inspect([]byte(`{"path":"campaign/abc"}`))
+	s.ErrorContains(invalidParamOutput.Err, "invalid path parameter")
+
+	unknownPathOutput := s.Tester.Inspect([]byte(`{"path":"campaign/1/unknown"}`))
+	s.ErrorContains(unknownPathOutput.Err, "no handler found for path: campaign/1/unknown")
+}
+
+func (s *DCMSystemSuite) TestErrorReports() {
+	var report router.ErrorReport
+
+	notFoundOutput := s.Tester.Inspect([]byte(`{"path":"campaign/99"}`))
+	s.Require().Len(notFoundOutput.Reports, 1)
+	s.Require().NoError(json.Unmarshal(notFoundOutput.Reports[0].Payload, &report))
+	s.Equal("campaign_not_found", report.Code)
+	s.Equal("campaign/99", report.Path)
+	s.Nil(report.InputIndex)
+
+	invalidParamOutput := s.Tester.Inspect([]byte(`{"path":"campaign/abc"}`))
+	s.Require().Len(invalidParamOutput.Reports, 1)
+	s.Require().NoError(json.Unmarshal(invalidParamOutput.Reports[0].Payload, &report))
+	s.Equal("invalid_input", report.Code)
+
+	unknownPathOutput := s.Tester.Inspect([]byte(`{"path":"campaign/1/unknown"}`))
+	s.Require().Len(unknownPathOutput.Reports, 1)
+	s.Equal(`{"code":"route_not_found","message":"no handler found for path: campaign/1/unknown","path":"campaign/1/unknown"}`, string(unknownPathOutput.Reports[0].Payload))
+
+	invalidRequestOutput := s.Tester.Inspect([]byte(`not json`))
+	s.Require().Len(invalidRequestOutput.Reports, 1)
+	report = router.ErrorReport{}
+	s.Require().NoError(json.Unmarshal(invalidRequestOutput.Reports[0].Payload, &report))
+	s.Equal("invalid_request", report.Code)
+	s.Empty(report.Path)
+
+	admin := common.HexToAddress("0x976EA74026E726554dB657fA54763abd0C3a0aa9")
+	debtor := common.HexToAddress("0x0000000000000000000000000000000000000007")
+	createUserOutput := s.Tester.Advance(admin, []byte(fmt.Sprintf(`{"path":"user/admin/create","data":{"address":"%s","role":"debtor"}}`, debtor)))
+	s.Require().NoError(createUserOutput.Err)
+
+	unauthorizedOutput := s.Tester.Advance(debtor, []byte(fmt.Sprintf(`{"path":"user/admin/create","data":{"address":"%s","role":"investor"}}`, admin)))
+	s.Require().Len(unauthorizedOutput.Reports, 1)
+	report = router.ErrorReport{}
+	s.Require().NoError(json.Unmarshal(unauthorizedOutput.Reports[0].Payload, &report))
+	s.Equal("unauthorized", report.Code)
+	s.Equal("user/admin/create", report.Path)
+	s.Require().NotNil(report.InputIndex)
 }
 
 func (s *DCMSystemSuite) TestFindCampaignsByDebtor() {
@@ -976,3 +1183,110 @@ func (s *DCMSystemSuite) TestEmergencyEtherWithdraw() {
 	s.Equal(admin, unpacked[0].(common.Address))
 	s.Equal(to, unpacked[1].(common.Address))
 }
+
+func (s *DCMSystemSuite) TestABIInputs() {
+	admin := common.HexToAddress("0x976EA74026E726554dB657fA54763abd0C3a0aa9")
+	debtor := common.HexToAddress("0x0000000000000000000000000000000000000007")
+
+	baseTime := time.Now().Unix()
+
+	createUser, err := router.ParseMethod("createUser(string role,address address)")
+	s.Require().NoError(err)
+	args, err := createUser.Inputs.Pack("debtor", debtor)
+	s.Require().NoError(err)
+
+	createUserOutput := s.Tester.Advance(admin, append(createUser.ID, args...))
+	s.Require().NoError(createUserOutput.Err)
+	s.Len(createUserOutput.Notices, 1)
+	expectedCreateUserOutput := fmt.Sprintf(`user created - {"id":2,"role":"debtor","address":"%s","created_at":%d}`, debtor, baseTime)
+	s.Equal(expectedCreateUserOutput, string(createUserOutput.Notices[0].Payload))
+
+	unauthorizedOutput := s.Tester.Advance(debtor, append(createUser.ID, args...))
+	s.ErrorContains(unauthorizedOutput.Err, "lacks required permissions")
+
+	unknownSelectorOutput := s.Tester.Advance(admin, []byte{0xde, 0xad, 0xbe, 0xef})
+	s.ErrorIs(unknownSelectorOutput.Err, router.ErrInvalidRequest)
+	s.Require().Len(unknownSelectorOutput.Reports, 1)
+	var report router.ErrorReport
+	s.Require().NoError(json.Unmarshal(unknownSelectorOutput.Reports[0].Payload, &report))
+	s.Equal("invalid_request", report.Code)
+	s.Equal("invalid request: unknown function selector 0xdeadbeef", report.Message)
+}
+
+func (s *DCMSystemSuite) TestABINotices() {
+	admin := common.HexToAddress("0x976EA74026E726554dB657fA54763abd0C3a0aa9")
+	debtor := common.HexToAddress("0x0000000000000000000000000000000000000007")
+
+	repo, err := factory.NewRepositoryFromConnectionString("sqlite://:memory:")
+	s.Require().NoError(err)
+	tester := rollmelette.NewTester(root.NewDCMSystem(repo, router.JSONOutputEncoder{}, root.NewABIOutputEncoder()))
+
+	createUserInput := []byte(fmt.Sprintf(`{"path":"user/admin/create","data":{"address":"%s","role":"debtor"}}`, debtor))
+	createUserOutput := tester.Advance(admin, createUserInput)
+	s.Require().NoError(createUserOutput.Err)
+	s.Require().Len(createUserOutput.Notices, 2)
+	s.True(strings.HasPrefix(string(createUserOutput.Notices[0].Payload), "user created - "))
+
+	userCreated, err := router.ParseMethod("UserCreated(address address,string role)")
+	s.Require().NoError(err)
+	notice := createUserOutput.Notices[1].Payload
+	s.Equal(userCreated.ID, notice[:4])
+	values, err := userCreated.Inputs.Unpack(notice[4:])
+	s.Require().NoError(err)
+	s.Equal([]any{debtor, "debtor"}, values)
+
+	repo, err = factory.NewRepositoryFromConnectionString("sqlite://:memory:")
+	s.Require().NoError(err)
+	tester = rollmelette.NewTester(root.NewDCMSystem(repo, root.NewABIOutputEncoder()))
+
+	createUserOutput = tester.Advance(admin, createUserInput)
+	s.Require().NoError(createUserOutput.Err)
+	s.Require().Len(createUserOutput.Notices, 1)
+	s.Equal(notice, createUserOutput.Notices[0].Payload)
+
+	orderCreated, err := root.NewABIOutputEncoder().EncodeOutput("order created", &order.CreateOrderOutputDTO{
+		Id:           3,
+		CampaignId:   1,
+		Investor:     custom_type.Address(debtor),
+		Amount:       custom_type.Uint256FromUint64(5000),
+		InterestRate: custom_type.Uint256FromUint64(9),
+	})
+	s.Require().NoError(err)
+	method, err := router.ParseMethod("OrderCreated(uint256 id,uint256 campaign_id,address investor,uint256 amount,uint256 interest_rate)")
+	s.Require().NoError(err)
+	values, err = method.Inputs.Unpack(orderCreated[4:])
+	s.Require().NoError(err)
+	s.Equal([]any{big.NewInt(3), big.NewInt(1), debtor, big.NewInt(5000), big.NewInt(9)}, values)
+
+	skipped, err := root.NewABIOutputEncoder().EncodeOutput("order canceled", &order.CancelOrderOutputDTO{})
+	s.NoError(err)
+	s.Nil(skipped)
+}
+
+func (s *DCMSystemSuite) TestRoutesManifest() {
+	routesOutput := s.Tester.Inspect([]byte(`{"path":"__routes"}`))
+	s.Require().NoError(routesOutput.Err)
+	s.Len(routesOutput.Reports, 1)
+
+	var manifest []router.RouteManifest
+	s.Require().NoError(json.Unmarshal(routesOutput.Reports[0].Payload, &manifest))
+
+	routes := make(map[string]router.RouteManifest)
+	for _, route := range manifest {
+		routes[string(route.Kind)+" "+route.Path] = route
+	}
+
+	createCampaign, ok := routes["advance campaign/debtor/create"]
+	s.Require().True(ok)
+	s.Equal([]string{"logging", "error-handling", "transaction", "rbac[debtor]"}, createCampaign.Middleware)
+	s.Equal("object", createCampaign.Input.Type)
+	s.Equal([]string{"token", "debt_issued", "max_interest_rate", "closes_at", "maturity_at"}, createCampaign.Input.Required)
+	s.Equal("string", createCampaign.Input.Properties["debt_issued"].Type)
+	s.Equal("integer", createCampaign.Input.Properties["closes_at"].Type)
+	s.Equal("createCampaign(address,uint256,uint256,uint64,uint64)", createCampaign.Signature)
+
+	campaignOrders, ok := routes["inspect campaign/:campaign_id/orders"]
+	s.Require().True(ok)
+	s.Equal([]string{"campaign_id"}, campaignOrders.Params)
+	s.Equal([]string{"logging", "error-handling", "transaction"}, campaignOrders.Middleware)
+}